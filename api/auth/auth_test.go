@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCallbackTestAuthenticator returns an Authenticator using a stand-in for the
+// Supabase PKCE grant. Instead of opening a browser, the callback URL the auth
+// service would redirect to is sent on the returned channel.
+func newCallbackTestAuthenticator(t *testing.T, opts ...Option) (*Authenticator, <-chan string) {
+	t.Setenv("HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+			return
+		}
+
+		if payload["auth_code"] != "abc-123" {
+			http.Error(w, "invalid code", http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"expires_in":   3600,
+			"user": map[string]interface{}{
+				"user_metadata": map[string]interface{}{"user_name": "jpmcb"},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	a := NewAuthenticator(opts...)
+	a.supabaseURL = server.URL
+	a.httpClient = server.Client()
+
+	callbacks := make(chan string, 1)
+	a.openURL = func(authURL string) error {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+
+		callbacks <- u.Query().Get("redirect_to")
+		return nil
+	}
+
+	return a, callbacks
+}
+
+type loginResult struct {
+	username string
+	err      error
+}
+
+func login(a *Authenticator) <-chan loginResult {
+	results := make(chan loginResult, 1)
+	go func() {
+		username, err := a.Login()
+		results <- loginResult{username, err}
+	}()
+
+	return results
+}
+
+func TestLoginCallback(t *testing.T) {
+	a, callbacks := newCallbackTestAuthenticator(t)
+	results := login(a)
+
+	callback := <-callbacks
+	u, err := url.Parse(callback)
+	require.NoError(t, err)
+	assert.NotEqual(t, "0", u.Port(), "a free port is picked")
+
+	res, err := http.Get(callback + "?code=abc-123")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	result := <-results
+	require.NoError(t, result.err)
+	assert.Equal(t, "jpmcb", result.username)
+}
+
+func TestLoginCallbackMissingCode(t *testing.T) {
+	a, callbacks := newCallbackTestAuthenticator(t)
+	results := login(a)
+
+	res, err := http.Get(<-callbacks)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	result := <-results
+	require.ErrorContains(t, result.err, "'code' query param not found")
+}
+
+func TestLoginTimeout(t *testing.T) {
+	a, callbacks := newCallbackTestAuthenticator(t, WithTimeout(100*time.Millisecond))
+	results := login(a)
+	<-callbacks
+
+	result := <-results
+	require.ErrorContains(t, result.err, "authentication timeout")
+}
+
+func TestLoginCallbackPort(t *testing.T) {
+	// Reserve a port to find one that's free, then hand it to the authenticator
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	a, callbacks := newCallbackTestAuthenticator(t, WithCallbackPort(port))
+	results := login(a)
+
+	callback := <-callbacks
+	assert.Equal(t, "http://localhost:"+strconv.Itoa(port)+"/", callback)
+
+	// A second login on the same port fails gracefully instead of panicking
+	busy, _ := newCallbackTestAuthenticator(t, WithCallbackPort(port))
+	_, err = busy.Login()
+	require.ErrorContains(t, err, "--callback-port")
+
+	res, err := http.Get(callback + "?code=abc-123")
+	require.NoError(t, err)
+	res.Body.Close()
+
+	require.NoError(t, (<-results).err)
+}
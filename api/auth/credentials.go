@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+)
+
+// The names of the credential stores, as chosen with "--credential-store"
+const (
+	CredentialStoreFile          = "file"
+	CredentialStoreEncryptedFile = "encrypted-file"
+	CredentialStoreKeyring       = "keyring"
+)
+
+// CredentialStores are the names of all credential stores
+var CredentialStores = []string{CredentialStoreFile, CredentialStoreEncryptedFile, CredentialStoreKeyring}
+
+// CredentialStore stores the session of the logged in user. Sessions are only
+// read and written while holding the session lock, so implementations don't
+// need to guard against concurrent CLI processes.
+type CredentialStore interface {
+	// Name is the name the store is chosen by
+	Name() string
+
+	// Location describes where the session is stored, like a file path
+	Location() string
+
+	// Load returns the stored session. ErrNoSession is returned if there is none.
+	Load() ([]byte, error)
+
+	// Save replaces the stored session
+	Save(data []byte) error
+
+	// Delete removes the stored session. ErrNoSession is returned if there is none.
+	Delete() error
+}
+
+// NewCredentialStore returns the credential store with the given name. The key
+// file is only used by the encrypted file store, which otherwise reads its
+// passphrase from the "PIZZA_CREDENTIAL_PASSPHRASE" environment variable.
+func NewCredentialStore(name, keyFile string) (CredentialStore, error) {
+	switch name {
+	case CredentialStoreFile, "":
+		return &fileStore{fileName: sessionFileName}, nil
+	case CredentialStoreEncryptedFile:
+		return newEncryptedFileStore(keyFile)
+	case CredentialStoreKeyring:
+		return newKeyringStore()
+	default:
+		return nil, fmt.Errorf("unknown credential store %q, must be one of: %v", name, CredentialStores)
+	}
+}
+
+// fileStore stores the session as plain JSON in the config directory, only
+// readable by the user. It's the default as it works everywhere.
+type fileStore struct {
+	fileName string
+}
+
+func (f *fileStore) Name() string {
+	return CredentialStoreFile
+}
+
+func (f *fileStore) Location() string {
+	path, _ := configFilePath(f.fileName)
+	return path
+}
+
+func (f *fileStore) Load() ([]byte, error) {
+	return readConfigFile(f.fileName)
+}
+
+func (f *fileStore) Save(data []byte) error {
+	return writeConfigFile(f.fileName, data)
+}
+
+func (f *fileStore) Delete() error {
+	return removeConfigFile(f.fileName)
+}
+
+// configFilePath returns the path of the given file in the config directory
+func configFilePath(fileName string) (string, error) {
+	dir, err := config.GetConfigDirectory()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	return filepath.Join(dir, fileName), nil
+}
+
+// readConfigFile reads the given file in the config directory, returning
+// ErrNoSession if it doesn't exist
+func readConfigFile(fileName string) ([]byte, error) {
+	path, err := configFilePath(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSession
+	}
+
+	return data, err
+}
+
+// writeConfigFile atomically replaces the given file in the config directory:
+// the data is written to a temporary file which is then renamed over it, so
+// readers never see a partially written file
+func writeConfigFile(fileName string, data []byte) error {
+	path, err := configFilePath(fileName)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), fileName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing to file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing to file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing file: %w", err)
+	}
+
+	return nil
+}
+
+// removeConfigFile removes the given file in the config directory, returning
+// ErrNoSession if it doesn't exist
+func removeConfigFile(fileName string) error {
+	path, err := configFilePath(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNoSession
+	}
+
+	return err
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedSessionFileName = "session.json.enc"
+
+	// PassphraseEnvVar is the environment variable the encrypted file store
+	// reads its passphrase from when no key file is given
+	PassphraseEnvVar = "PIZZA_CREDENTIAL_PASSPHRASE"
+
+	// the scrypt parameters recommended for interactive logins
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLength   = 16
+)
+
+// encryptedFileStore stores the session in the config directory encrypted with
+// AES-256-GCM. The key is derived with scrypt from a passphrase or the contents
+// of a key file.
+type encryptedFileStore struct {
+	secret []byte
+}
+
+// encryptedSession is the on disk format of the encrypted file store
+type encryptedSession struct {
+	Version    int    `json:"version"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func newEncryptedFileStore(keyFile string) (*encryptedFileStore, error) {
+	if keyFile != "" {
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read credential key file: %w", err)
+		}
+
+		secret := strings.TrimSpace(string(data))
+		if secret == "" {
+			return nil, fmt.Errorf("credential key file is empty: %s", keyFile)
+		}
+
+		return &encryptedFileStore{secret: []byte(secret)}, nil
+	}
+
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		return &encryptedFileStore{secret: []byte(passphrase)}, nil
+	}
+
+	return nil, fmt.Errorf("the %s credential store needs a key file or the %s environment variable", CredentialStoreEncryptedFile, PassphraseEnvVar)
+}
+
+func (e *encryptedFileStore) Name() string {
+	return CredentialStoreEncryptedFile
+}
+
+func (e *encryptedFileStore) Location() string {
+	path, _ := configFilePath(encryptedSessionFileName)
+	return path
+}
+
+func (e *encryptedFileStore) Load() ([]byte, error) {
+	data, err := readConfigFile(encryptedSessionFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var enc encryptedSession
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, fmt.Errorf("could not decode encrypted session: %w", err)
+	}
+
+	if enc.Version != 1 {
+		return nil, fmt.Errorf("unsupported encrypted session version: %d", enc.Version)
+	}
+
+	gcm, err := e.cipher(enc.Salt, enc.N, enc.R, enc.P)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(enc.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted session nonce")
+	}
+
+	plaintext, err := gcm.Open(nil, enc.Nonce, enc.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("could not decrypt session: wrong passphrase or key file")
+	}
+
+	return plaintext, nil
+}
+
+func (e *encryptedFileStore) Save(data []byte) error {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to read random bytes: %w", err)
+	}
+
+	gcm, err := e.cipher(salt, scryptN, scryptR, scryptP)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to read random bytes: %w", err)
+	}
+
+	enc, err := json.Marshal(encryptedSession{
+		Version:    1,
+		N:          scryptN,
+		R:          scryptR,
+		P:          scryptP,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, data, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling encrypted session failed: %w", err)
+	}
+
+	return writeConfigFile(encryptedSessionFileName, enc)
+}
+
+func (e *encryptedFileStore) Delete() error {
+	return removeConfigFile(encryptedSessionFileName)
+}
+
+// cipher derives the key from the store's secret and returns its AES-GCM cipher
+func (e *encryptedFileStore) cipher(salt []byte, n, r, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key(e.secret, salt, n, r, p, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	keyringService = "pizza-cli"
+	keyringAccount = "session"
+)
+
+// keyringStore stores the session in the operating system's keyring: the
+// Secret Service on Linux, through "secret-tool", and the login keychain on
+// macOS, through "security". Other platforms aren't supported.
+type keyringStore struct {
+	// the name of the keyring command line tool and its path
+	tool string
+	path string
+}
+
+func newKeyringStore() (*keyringStore, error) {
+	var tool string
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		tool = "secret-tool"
+	case "darwin":
+		tool = "security"
+	default:
+		return nil, fmt.Errorf("the %s credential store is not supported on %s", CredentialStoreKeyring, runtime.GOOS)
+	}
+
+	path, err := exec.LookPath(tool)
+	if err != nil {
+		return nil, fmt.Errorf("the %s credential store needs %q on the PATH: %w", CredentialStoreKeyring, tool, err)
+	}
+
+	return &keyringStore{tool: tool, path: path}, nil
+}
+
+func (k *keyringStore) Name() string {
+	return CredentialStoreKeyring
+}
+
+func (k *keyringStore) Location() string {
+	return fmt.Sprintf("%s service %q, account %q", k.tool, keyringService, keyringAccount)
+}
+
+func (k *keyringStore) Load() ([]byte, error) {
+	var out []byte
+	var err error
+
+	if k.tool == "security" {
+		out, err = k.run(nil, "find-generic-password", "-s", keyringService, "-a", keyringAccount, "-w")
+	} else {
+		out, err = k.run(nil, "lookup", "service", keyringService, "account", keyringAccount)
+	}
+
+	// Both tools exit with an error and print nothing if there is no such secret
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(out)) == 0 {
+		return nil, ErrNoSession
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	secret := bytes.TrimSpace(out)
+	if len(secret) == 0 {
+		return nil, ErrNoSession
+	}
+
+	if k.tool == "security" {
+		return base64.StdEncoding.DecodeString(string(secret))
+	}
+
+	return secret, nil
+}
+
+func (k *keyringStore) Save(data []byte) error {
+	if k.tool == "security" {
+		// The secret is passed through "security"'s interactive mode on stdin so
+		// it doesn't show up in the process list. It's base64 encoded so it
+		// doesn't need quoting.
+		command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", keyringService, keyringAccount, base64.StdEncoding.EncodeToString(data))
+		_, err := k.run(strings.NewReader(command), "-i")
+		return err
+	}
+
+	// "secret-tool" reads the secret from stdin
+	_, err := k.run(bytes.NewReader(data), "store", "--label=Pizza CLI session", "service", keyringService, "account", keyringAccount)
+	return err
+}
+
+func (k *keyringStore) Delete() error {
+	if _, err := k.Load(); err != nil {
+		return err
+	}
+
+	var err error
+	if k.tool == "security" {
+		_, err = k.run(nil, "delete-generic-password", "-s", keyringService, "-a", keyringAccount)
+	} else {
+		_, err = k.run(nil, "clear", "service", keyringService, "account", keyringAccount)
+	}
+
+	return err
+}
+
+// run runs the keyring tool with the given stdin and args, returning its stdout
+func (k *keyringStore) run(stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.Command(k.path, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.Bytes(), fmt.Errorf("%s %s failed: %w: %s", k.tool, args[0], err, msg)
+		}
+
+		return stdout.Bytes(), fmt.Errorf("%s %s failed: %w", k.tool, args[0], err)
+	}
+
+	return stdout.Bytes(), nil
+}
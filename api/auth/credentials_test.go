@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := NewCredentialStore(CredentialStoreFile, "")
+	require.NoError(t, err)
+
+	_, err = store.Load()
+	require.ErrorIs(t, err, ErrNoSession)
+
+	require.NoError(t, store.Save([]byte(`{"access_token":"access-1"}`)))
+
+	info, err := os.Stat(store.Location())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	data, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, `{"access_token":"access-1"}`, string(data))
+
+	require.NoError(t, store.Delete())
+	require.ErrorIs(t, store.Delete(), ErrNoSession)
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(PassphraseEnvVar, "")
+
+	_, err := NewCredentialStore(CredentialStoreEncryptedFile, "")
+	require.ErrorContains(t, err, PassphraseEnvVar)
+
+	t.Setenv(PassphraseEnvVar, "correct horse battery staple")
+	store, err := NewCredentialStore(CredentialStoreEncryptedFile, "")
+	require.NoError(t, err)
+
+	_, err = store.Load()
+	require.ErrorIs(t, err, ErrNoSession)
+
+	require.NoError(t, store.Save([]byte(`{"access_token":"access-1"}`)))
+
+	raw, err := os.ReadFile(store.Location())
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "access-1")
+
+	data, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, `{"access_token":"access-1"}`, string(data))
+
+	// A key file takes precedence over the passphrase
+	keyFile := filepath.Join(home, "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("a different secret\n"), 0600))
+
+	other, err := NewCredentialStore(CredentialStoreEncryptedFile, keyFile)
+	require.NoError(t, err)
+
+	_, err = other.Load()
+	require.ErrorContains(t, err, "wrong passphrase or key file")
+
+	require.NoError(t, store.Delete())
+}
+
+func TestKeyringStore(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the fake secret-tool is a shell script")
+	}
+
+	// A fake "secret-tool" keeping the secret in a file
+	bin := t.TempDir()
+	secretTool := `#!/bin/sh
+secret="$(dirname "$0")/secret"
+case "$1" in
+  store) cat > "$secret" ;;
+  lookup) [ -f "$secret" ] && cat "$secret" || exit 1 ;;
+  clear) rm -f "$secret" ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "secret-tool"), []byte(secretTool), 0700))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := NewCredentialStore(CredentialStoreKeyring, "")
+	require.NoError(t, err)
+
+	// Sessions round trip through the authenticator
+	a := NewAuthenticator(WithCredentialStore(store))
+
+	_, err = a.Status()
+	require.ErrorIs(t, err, ErrNoSession)
+
+	require.NoError(t, a.saveSession(&session{
+		AccessToken: "access-1",
+		ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+	}))
+
+	token, err := a.GetSessionToken()
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token)
+
+	status, err := a.Status()
+	require.NoError(t, err)
+	assert.Equal(t, CredentialStoreKeyring, status.CredentialStore)
+	assert.Equal(t, `secret-tool service "pizza-cli", account "session"`, status.Location)
+
+	require.NoError(t, store.Delete())
+	require.ErrorIs(t, store.Delete(), ErrNoSession)
+}
+
+func TestUnknownCredentialStore(t *testing.T) {
+	_, err := NewCredentialStore("vault", "")
+	require.ErrorContains(t, err, `unknown credential store "vault"`)
+}
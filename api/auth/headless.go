@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LoginWithCode performs the login flow without a local browser or callback
+// server, for remote dev boxes and CI runners. The authentication URL is written
+// to out to be opened on any machine. After logging in, the browser is redirected
+// to a localhost URL that won't load: the user pastes that URL, or just its
+// "code" query param, into in and it's exchanged for a session.
+func (a *Authenticator) LoginWithCode(in io.Reader, out io.Writer) (string, error) {
+	codeVerifier, codeChallenge, err := a.generatePkce(codeChallengeLength)
+	if err != nil {
+		return "", fmt.Errorf("PKCE error: %v", err)
+	}
+
+	port := a.callbackPort
+	if port == 0 {
+		port = defaultHeadlessCallbackPort
+	}
+
+	fmt.Fprintf(out, "Open this URL in a browser on any machine to log in:\n\n%s\n\n", a.authorizeURL(codeChallenge, port))
+	fmt.Fprintf(out, "After logging in, the browser is sent to a %s address that won't load.\n", authCallbackHost)
+	fmt.Fprint(out, "Paste that page's full URL or its \"code\" value here: ")
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("could not read code: %w", err)
+	}
+
+	code, err := parseAuthCode(line)
+	if err != nil {
+		return "", err
+	}
+
+	sessionData, err := a.getSession(code, codeVerifier)
+	if err != nil {
+		return "", fmt.Errorf("getting session failed: %w", err)
+	}
+
+	if err := a.saveSession(sessionData); err != nil {
+		return "", fmt.Errorf("could not save session: %w", err)
+	}
+
+	return sessionData.User.username(), nil
+}
+
+// parseAuthCode returns the auth code from either a pasted callback URL or the bare code
+func parseAuthCode(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", errors.New("no code given")
+	}
+
+	if !strings.Contains(input, "code=") {
+		return input, nil
+	}
+
+	u, err := url.Parse(input)
+	if err != nil {
+		return "", fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	code := u.Query().Get("code")
+	if code == "" {
+		return "", errors.New("'code' query param not found")
+	}
+
+	return code, nil
+}
+
+// LoginWithToken logs in with an existing access token, like one issued to a
+// CI runner. The token is verified with the auth service and saved as the
+// session. Since there is no refresh token, the session can't be refreshed
+// once the access token expires.
+func (a *Authenticator) LoginWithToken(token string) (string, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.New("no access token given")
+	}
+
+	user, err := a.getUser(token)
+	if err != nil {
+		return "", fmt.Errorf("could not verify access token: %w", err)
+	}
+
+	// Supabase access tokens last an hour unless the token says otherwise
+	expiresAt := tokenExpiry(token)
+	if expiresAt == 0 {
+		expiresAt = time.Now().Add(time.Hour).Unix()
+	}
+
+	sessionData := &session{
+		AccessToken: token,
+		TokenType:   "bearer",
+		ExpiresAt:   expiresAt,
+		ExpiresIn:   expiresAt - time.Now().Unix(),
+		User:        *user,
+	}
+
+	if err := a.saveSession(sessionData); err != nil {
+		return "", fmt.Errorf("could not save session: %w", err)
+	}
+
+	return user.username(), nil
+}
+
+// getUser gets the user an access token belongs to from the Supabase auth service
+func (a *Authenticator) getUser(token string) (*sessionUser, error) {
+	req, _ := http.NewRequest("GET", a.supabaseURL+"/auth/v1/user", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Apikey", a.supabasePublicKey)
+
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't make a request with the default client: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", res.Status)
+	}
+
+	var user sessionUser
+	if err := json.NewDecoder(res.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %s", err)
+	}
+
+	return &user, nil
+}
+
+// tokenExpiry returns the "exp" claim of a JWT access token, or 0 if the
+// token isn't a JWT or has no expiry. The signature isn't verified: the auth
+// service already accepted the token.
+func tokenExpiry(token string) int64 {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return 0
+	}
+
+	return claims.Exp
+}
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuthCode(t *testing.T) {
+	t.Parallel()
+
+	code, err := parseAuthCode("http://localhost:3000/?code=abc-123\n")
+	require.NoError(t, err)
+	assert.Equal(t, "abc-123", code)
+
+	code, err = parseAuthCode("  abc-123 ")
+	require.NoError(t, err)
+	assert.Equal(t, "abc-123", code)
+
+	_, err = parseAuthCode("\n")
+	require.Error(t, err)
+
+	_, err = parseAuthCode("http://localhost:3000/?error=access_denied&code=")
+	require.Error(t, err)
+}
+
+func TestTokenExpiry(t *testing.T) {
+	t.Parallel()
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1700000000}`))
+	assert.Equal(t, int64(1700000000), tokenExpiry("header."+payload+".signature"))
+	assert.Equal(t, int64(0), tokenExpiry("not-a-jwt"))
+}
+
+// newHeadlessTestAuthenticator returns an Authenticator using a stand-in for the
+// Supabase auth service's PKCE grant and user endpoints
+func newHeadlessTestAuthenticator(t *testing.T) *Authenticator {
+	t.Setenv("HOME", t.TempDir())
+
+	user := map[string]interface{}{
+		"id":            "1",
+		"user_metadata": map[string]interface{}{"user_name": "jpmcb"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/v1/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "pkce", r.URL.Query().Get("grant_type"))
+
+		var payload map[string]string
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+			return
+		}
+
+		if payload["auth_code"] != "abc-123" || payload["code_verifier"] == "" {
+			http.Error(w, "invalid code", http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access",
+			"refresh_token": "refresh",
+			"expires_in":    3600,
+			"user":          user,
+		})
+	})
+	mux.HandleFunc("/auth/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer valid") {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(user)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	a := NewAuthenticator()
+	a.supabaseURL = server.URL
+	a.httpClient = server.Client()
+
+	return a
+}
+
+func TestLoginWithCode(t *testing.T) {
+	a := newHeadlessTestAuthenticator(t)
+
+	var out bytes.Buffer
+	username, err := a.LoginWithCode(strings.NewReader("http://localhost:3000/?code=abc-123\n"), &out)
+	require.NoError(t, err)
+	assert.Equal(t, "jpmcb", username)
+	assert.Contains(t, out.String(), "/auth/v1/authorize?")
+	assert.Contains(t, out.String(), "code_challenge=")
+
+	token, err := a.GetSessionToken()
+	require.NoError(t, err)
+	assert.Equal(t, "access", token)
+
+	_, err = a.LoginWithCode(strings.NewReader("wrong-code\n"), &out)
+	require.Error(t, err)
+}
+
+func TestLoginWithToken(t *testing.T) {
+	a := newHeadlessTestAuthenticator(t)
+
+	exp := time.Now().Add(2 * time.Hour).Unix()
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp)))
+	token := "valid." + payload + ".signature"
+
+	username, err := a.LoginWithToken(token + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, "jpmcb", username)
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(home, ".pizza-cli", sessionFileName))
+	require.NoError(t, err)
+
+	var saved session
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, token, saved.AccessToken)
+	assert.Equal(t, exp, saved.ExpiresAt)
+
+	_, err = a.LoginWithToken("invalid")
+	require.Error(t, err)
+}
@@ -42,3 +42,10 @@ type sessionUseFactor struct {
 	FriendlyName string `json:"friendly_name"`
 	FactorType   string `json:"factor_type"`
 }
+
+// username returns the GitHub login of the user, or an empty string if the
+// auth service didn't include it
+func (u *sessionUser) username() string {
+	username, _ := u.UserMetadata["user_name"].(string)
+	return username
+}
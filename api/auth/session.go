@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+)
+
+const (
+	sessionFileName     = "session.json"
+	sessionLockFileName = "session.json.lock"
+
+	// sessions expiring within this window are refreshed before they're used
+	sessionRefreshWindow = 5 * time.Minute
+
+	// how long to wait for another CLI process to release the session lock, and
+	// how old a lock must be before it's considered left behind by a dead process
+	sessionLockTimeout    = 10 * time.Second
+	sessionLockStaleAfter = 30 * time.Second
+)
+
+// ErrNoSession is returned when there is no session on disk
+var ErrNoSession = errors.New("not logged in")
+
+// ErrSessionExpired is returned when the session expired and can't be
+// refreshed, as it has no refresh token or its refresh token was revoked
+var ErrSessionExpired = errors.New("session expired")
+
+// CheckSession checks if a session is already authenticated based on the expiration
+// time for the given session on disk. Sessions that are expired or about to
+// expire are refreshed.
+func (a *Authenticator) CheckSession() error {
+	_, err := a.validSession()
+	return err
+}
+
+// GetSessionToken returns the access token for a given session. Sessions that
+// are expired or about to expire are refreshed first.
+func (a *Authenticator) GetSessionToken() (string, error) {
+	session, err := a.validSession()
+	if err != nil {
+		return "", err
+	}
+
+	return session.AccessToken, nil
+}
+
+// validSession returns the stored session, refreshing it with its refresh
+// token if it expires within the refresh window. The session is locked while
+// refreshing so concurrent CLI processes don't each use the single use
+// refresh token.
+func (a *Authenticator) validSession() (*session, error) {
+	session, err := a.readSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	if !session.expiresWithin(sessionRefreshWindow) {
+		return session, nil
+	}
+
+	dir, err := config.GetConfigDirectory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	unlock, err := lockSessionFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Another process may have refreshed the session while waiting for the lock
+	session, err = a.readSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	if !session.expiresWithin(sessionRefreshWindow) {
+		return session, nil
+	}
+
+	if session.RefreshToken == "" {
+		return nil, ErrSessionExpired
+	}
+
+	refreshed, err := a.refreshSession(session.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w and could not be refreshed: %w", ErrSessionExpired, err)
+	}
+
+	if err := a.writeSession(refreshed); err != nil {
+		return nil, fmt.Errorf("could not save session: %w", err)
+	}
+
+	return refreshed, nil
+}
+
+// expiresWithin checks if the session is expired or expires within the given duration
+func (s *session) expiresWithin(d time.Duration) bool {
+	return time.Now().Add(d).After(time.Unix(s.ExpiresAt, 0))
+}
+
+// readSession reads the session from the credential store. ErrNoSession is
+// returned if there is none.
+func (a *Authenticator) readSession() (*session, error) {
+	data, err := a.store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var session session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// saveSession saves a session to the credential store
+func (a *Authenticator) saveSession(sessionData *session) error {
+	dir, err := config.GetConfigDirectory()
+	if err != nil {
+		return fmt.Errorf("could not get user config directory: %w", err)
+	}
+
+	unlock, err := lockSessionFile(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return a.writeSession(sessionData)
+}
+
+// writeSession replaces the session in the credential store. The caller must
+// hold the lock.
+func (a *Authenticator) writeSession(sessionData *session) error {
+	jsonData, err := json.Marshal(sessionData)
+	if err != nil {
+		return fmt.Errorf("marshaling session data failed: %w", err)
+	}
+
+	return a.store.Save(jsonData)
+}
+
+// lockSessionFile takes an exclusive lock on the session in the given
+// directory, shared by every CLI process and credential store, and returns the
+// function that releases it. The lock is a file created exclusively, which works the same on
+// every platform.
+func lockSessionFile(dir string) (func(), error) {
+	lockPath := filepath.Join(dir, sessionLockFileName)
+	deadline := time.Now().Add(sessionLockTimeout)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("could not lock session file: %w", err)
+		}
+
+		// Break locks left behind by a process that exited while holding one
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > sessionLockStaleAfter {
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for session lock: %s", lockPath)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Status describes the session stored on disk
+type Status struct {
+	// Username is the GitHub login of the logged in user
+	Username string `json:"username" yaml:"username"`
+
+	// Email is the email of the logged in user, if shared
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+
+	// ExpiresAt is when the access token expires
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+
+	// Expired denotes that the access token is expired or about to expire
+	Expired bool `json:"expired" yaml:"expired"`
+
+	// Refreshable denotes that the session has a refresh token, so an expired
+	// access token is refreshed the next time it's used
+	Refreshable bool `json:"refreshable" yaml:"refreshable"`
+
+	// AuthURL is the auth service the session was issued by
+	AuthURL string `json:"auth_url" yaml:"auth_url"`
+
+	// CredentialStore is the name of the store the session is kept in
+	CredentialStore string `json:"credential_store" yaml:"credential_store"`
+
+	// Location is where the store keeps the session, like a file path
+	Location string `json:"location" yaml:"location"`
+}
+
+// Status returns the status of the stored session without refreshing it.
+// ErrNoSession is returned if there is no session.
+func (a *Authenticator) Status() (*Status, error) {
+	session, err := a.readSession()
+	if errors.Is(err, ErrNoSession) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	return &Status{
+		Username:    session.User.username(),
+		Email:       session.User.Email,
+		ExpiresAt:   time.Unix(session.ExpiresAt, 0),
+		Expired:     session.expiresWithin(sessionRefreshWindow),
+		Refreshable: session.RefreshToken != "",
+		AuthURL:     a.supabaseURL,
+
+		CredentialStore: a.store.Name(),
+		Location:        a.store.Location(),
+	}, nil
+}
+
+// Logout revokes the session with the auth service and deletes it from the
+// credential store.
+// The session is deleted even if it can't be revoked, for example because it
+// already expired, in which case the revocation error is returned alongside
+// a true deleted value. ErrNoSession is returned if there is no session.
+func (a *Authenticator) Logout() (bool, error) {
+	dir, err := config.GetConfigDirectory()
+	if err != nil {
+		return false, fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	unlock, err := lockSessionFile(dir)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	session, err := a.readSession()
+	if errors.Is(err, ErrNoSession) {
+		return false, err
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	revokeErr := a.revokeSession(session.AccessToken)
+
+	if err := a.store.Delete(); err != nil {
+		return false, fmt.Errorf("could not delete session: %w", err)
+	}
+
+	if revokeErr != nil {
+		return true, fmt.Errorf("could not revoke session: %w", revokeErr)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAuthenticator returns an Authenticator using a stand-in for the
+// Supabase auth service that rotates the refresh token on every refresh
+func newTestAuthenticator(t *testing.T, refreshes *atomic.Int32) *Authenticator {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/v1/token", r.URL.Path)
+		assert.Equal(t, "refresh_token", r.URL.Query().Get("grant_type"))
+		assert.Equal(t, "test-key", r.Header.Get("Apikey"))
+
+		var payload map[string]string
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+			return
+		}
+
+		if payload["refresh_token"] != "refresh-1" {
+			http.Error(w, "invalid refresh token", http.StatusBadRequest)
+			return
+		}
+
+		refreshes.Add(1)
+
+		// simulate a slow auth service so concurrent refreshes overlap
+		time.Sleep(50 * time.Millisecond)
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access-2",
+			"refresh_token": "refresh-2",
+			"expires_in":    3600,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	a := NewAuthenticator()
+	a.supabaseURL = server.URL
+	a.supabasePublicKey = "test-key"
+	a.httpClient = server.Client()
+
+	return a
+}
+
+func writeTestSession(t *testing.T, s *session) string {
+	t.Setenv("HOME", t.TempDir())
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	store := &fileStore{fileName: sessionFileName}
+	require.NoError(t, store.Save(data))
+
+	return store.Location()
+}
+
+func TestGetSessionTokenRefreshes(t *testing.T) {
+	var refreshes atomic.Int32
+	a := newTestAuthenticator(t, &refreshes)
+
+	sessionPath := writeTestSession(t, &session{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    time.Now().Add(time.Minute).Unix(),
+	})
+
+	token, err := a.GetSessionToken()
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", token)
+
+	data, err := os.ReadFile(sessionPath)
+	require.NoError(t, err)
+
+	var saved session
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, "refresh-2", saved.RefreshToken)
+	assert.Greater(t, saved.ExpiresAt, time.Now().Add(sessionRefreshWindow).Unix())
+
+	// The refreshed session is used as is
+	require.NoError(t, a.CheckSession())
+	assert.Equal(t, int32(1), refreshes.Load())
+}
+
+func TestGetSessionTokenValidSession(t *testing.T) {
+	var refreshes atomic.Int32
+	a := newTestAuthenticator(t, &refreshes)
+
+	writeTestSession(t, &session{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    time.Now().Add(time.Hour).Unix(),
+	})
+
+	token, err := a.GetSessionToken()
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token)
+	assert.Equal(t, int32(0), refreshes.Load())
+}
+
+func TestGetSessionTokenConcurrentRefresh(t *testing.T) {
+	var refreshes atomic.Int32
+
+	writeTestSession(t, &session{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    time.Now().Add(-time.Minute).Unix(),
+	})
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 8)
+	errs := make([]error, len(tokens))
+
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], errs[i] = newTestAuthenticator(t, &refreshes).GetSessionToken()
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range tokens {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "access-2", tokens[i])
+	}
+
+	// The single use refresh token is only spent once
+	assert.Equal(t, int32(1), refreshes.Load())
+}
+
+func TestCheckSessionRefreshFails(t *testing.T) {
+	var refreshes atomic.Int32
+	a := newTestAuthenticator(t, &refreshes)
+
+	writeTestSession(t, &session{
+		AccessToken:  "access-1",
+		RefreshToken: "revoked",
+		ExpiresAt:    time.Now().Add(-time.Minute).Unix(),
+	})
+
+	require.ErrorIs(t, a.CheckSession(), ErrSessionExpired)
+}
+
+func TestGetSessionTokenNoRefreshToken(t *testing.T) {
+	var refreshes atomic.Int32
+	a := newTestAuthenticator(t, &refreshes)
+
+	// Sessions logged in with an access token can't be refreshed
+	writeTestSession(t, &session{
+		AccessToken: "access-1",
+		ExpiresAt:   time.Now().Add(-time.Minute).Unix(),
+	})
+
+	_, err := a.GetSessionToken()
+	require.ErrorIs(t, err, ErrSessionExpired)
+	assert.Equal(t, int32(0), refreshes.Load())
+}
+
+func TestStatusAndLogout(t *testing.T) {
+	var revoked atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/v1/logout", r.URL.Path)
+		assert.Equal(t, "Bearer access-1", r.Header.Get("Authorization"))
+		revoked.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	a := NewAuthenticator()
+	a.supabaseURL = server.URL
+	a.httpClient = server.Client()
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	sessionPath := writeTestSession(t, &session{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    expiresAt.Unix(),
+		User: sessionUser{
+			Email:        "john@opensauced.pizza",
+			UserMetadata: map[string]interface{}{"user_name": "jpmcb"},
+		},
+	})
+
+	status, err := a.Status()
+	require.NoError(t, err)
+	assert.Equal(t, &Status{
+		Username:    "jpmcb",
+		Email:       "john@opensauced.pizza",
+		ExpiresAt:   expiresAt,
+		Refreshable: true,
+		AuthURL:     server.URL,
+
+		CredentialStore: CredentialStoreFile,
+		Location:        sessionPath,
+	}, status)
+
+	deleted, err := a.Logout()
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	assert.Equal(t, int32(1), revoked.Load())
+	assert.NoFileExists(t, sessionPath)
+
+	_, err = a.Status()
+	require.ErrorIs(t, err, ErrNoSession)
+
+	_, err = a.Logout()
+	require.ErrorIs(t, err, ErrNoSession)
+}
@@ -0,0 +1,303 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultCacheMaxSize is how large the response cache may grow before the
+	// least recently used responses are evicted
+	DefaultCacheMaxSize int64 = 100 << 20
+
+	// DefaultCacheTTL is how long responses are served from the cache without
+	// asking the API, for endpoints without a TTL of their own
+	DefaultCacheTTL = 15 * time.Minute
+
+	cacheFileExt = ".json"
+)
+
+// cacheTTLs are how long responses of endpoints starting with the given paths
+// are fresh. The first matching path wins. Responses with a TTL of 0 are
+// revalidated on every request, so they're only sent again when they changed.
+var cacheTTLs = []struct {
+	path string
+	ttl  time.Duration
+}{
+	// Workspaces and their lists are edited by the CLI itself
+	{path: "/v2/workspaces", ttl: 0},
+	{path: "/v2/histogram/", ttl: 15 * time.Minute},
+	{path: "/v2/contributors/", ttl: time.Hour},
+	{path: "/v2/repos/", ttl: time.Hour},
+}
+
+// CacheOptions configures the on-disk response cache
+type CacheOptions struct {
+	// Dir is the directory responses are stored in
+	Dir string
+
+	// MaxSize is how many bytes of responses are kept. DefaultCacheMaxSize is
+	// used when it's 0.
+	MaxSize int64
+
+	// Refresh revalidates every cached response with the API, regardless of its
+	// TTL, and stores the fresh ones
+	Refresh bool
+}
+
+// WithCache caches successful GET responses on disk, so repeated calls don't
+// need to ask the API again. By default, nothing is cached.
+func WithCache(opts CacheOptions) Option {
+	return func(c *Client) {
+		c.cache = &opts
+	}
+}
+
+// cacheEntry is a response stored in the cache
+type cacheEntry struct {
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	StoredAt   time.Time   `json:"stored_at"`
+}
+
+// cacheTransport is an http.RoundTripper serving GET requests from responses
+// stored on disk while they're fresh. Stale responses with an "ETag" are
+// revalidated with "If-None-Match", so the API only sends them again when they
+// changed. Failing to read or write the cache never fails a request.
+type cacheTransport struct {
+	base    http.RoundTripper
+	dir     string
+	maxSize int64
+	refresh bool
+	now     func() time.Time
+}
+
+func newCacheTransport(base http.RoundTripper, opts CacheOptions) *cacheTransport {
+	maxSize := opts.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultCacheMaxSize
+	}
+
+	return &cacheTransport{
+		base:    base,
+		dir:     opts.Dir,
+		maxSize: maxSize,
+		refresh: opts.Refresh,
+		now:     time.Now,
+	}
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	path := t.entryPath(req)
+	entry, _ := t.load(path)
+
+	if entry != nil && !t.refresh && t.now().Sub(entry.StoredAt) < cacheTTL(req.URL.Path) {
+		t.touch(path)
+		return entry.response(req), nil
+	}
+
+	sendReq := req
+	if entry != nil && entry.Header.Get("ETag") != "" {
+		// RoundTrippers mustn't modify the request
+		sendReq = req.Clone(req.Context())
+		sendReq.Header.Set("If-None-Match", entry.Header.Get("ETag"))
+	}
+
+	resp, err := t.base.RoundTrip(sendReq)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		entry.StoredAt = t.now()
+		t.store(path, entry)
+		return entry.response(req), nil
+
+	case resp.StatusCode == http.StatusOK && isCacheable(resp):
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		t.store(path, &cacheEntry{
+			URL:        req.URL.String(),
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       body,
+			StoredAt:   t.now(),
+		})
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+
+	default:
+		return resp, nil
+	}
+}
+
+// entryPath returns the file a request's response is stored in. Requests
+// authenticated as different users are stored separately.
+func (t *cacheTransport) entryPath(req *http.Request) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.URL.String()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.Header.Get("Authorization")))
+
+	return filepath.Join(t.dir, hex.EncodeToString(hash.Sum(nil))+cacheFileExt)
+}
+
+func (t *cacheTransport) load(path string) (*cacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("invalid cache entry %s: %w", path, err)
+	}
+
+	return &entry, nil
+}
+
+// store atomically writes the entry, then evicts the least recently used
+// entries if the cache grew too large
+func (t *cacheTransport) store(path string, entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(t.dir, "entry.*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err != nil || closeErr != nil {
+		return
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return
+	}
+
+	_ = t.evict()
+}
+
+// touch marks the entry as recently used
+func (t *cacheTransport) touch(path string) {
+	now := t.now()
+	_ = os.Chtimes(path, now, now)
+}
+
+// evict removes the least recently used entries until the cache fits in its
+// maximum size
+func (t *cacheTransport) evict() error {
+	dirEntries, err := os.ReadDir(t.dir)
+	if err != nil {
+		return err
+	}
+
+	var files []fs.FileInfo
+	var size int64
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || filepath.Ext(dirEntry.Name()) != cacheFileExt {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		files = append(files, info)
+		size += info.Size()
+	}
+
+	if size <= t.maxSize {
+		return nil
+	}
+
+	slices.SortFunc(files, func(a, b fs.FileInfo) int {
+		return a.ModTime().Compare(b.ModTime())
+	})
+
+	var errs []error
+	for _, file := range files {
+		if size <= t.maxSize {
+			break
+		}
+
+		err := os.Remove(filepath.Join(t.dir, file.Name()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+			continue
+		}
+
+		size -= file.Size()
+	}
+
+	return errors.Join(errs...)
+}
+
+// response rebuilds the stored response for the given request
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheTTL returns how long responses of the endpoint at the given path are fresh
+func cacheTTL(path string) time.Duration {
+	for _, rule := range cacheTTLs {
+		if strings.HasPrefix(path, rule.path) {
+			return rule.ttl
+		}
+	}
+
+	return DefaultCacheTTL
+}
+
+// isCacheable checks if the API allows storing the response. As the cache
+// belongs to a single user, private responses are stored too.
+func isCacheable(resp *http.Response) bool {
+	return !strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store")
+}
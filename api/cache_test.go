@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCacheTestServer returns a server responding with the request's path and
+// an "ETag", or with 304 Not Modified when the client already has it
+func newCacheTestServer(t *testing.T, requests, revalidations *atomic.Int32, header http.Header) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+
+		etag := `"` + r.URL.Path + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			revalidations.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte("body of " + r.URL.Path))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// cacheTestGet sends a GET request through the transport, returning the body
+func cacheTestGet(t *testing.T, transport http.RoundTripper, url, authorization string) string {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	require.NoError(t, err)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestCacheFresh(t *testing.T) {
+	t.Parallel()
+	var requests, revalidations atomic.Int32
+	server := newCacheTestServer(t, &requests, &revalidations, nil)
+	transport := newCacheTransport(http.DefaultTransport, CacheOptions{Dir: t.TempDir()})
+
+	for range 3 {
+		assert.Equal(t, "body of /v2/repos/open-sauced/pizza-cli", cacheTestGet(t, transport, server.URL+"/v2/repos/open-sauced/pizza-cli", ""))
+	}
+	assert.Equal(t, int32(1), requests.Load())
+
+	// Requests authenticated as someone else aren't served their responses
+	cacheTestGet(t, transport, server.URL+"/v2/repos/open-sauced/pizza-cli", "Bearer someone-else")
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestCacheRevalidate(t *testing.T) {
+	t.Parallel()
+	var requests, revalidations atomic.Int32
+	server := newCacheTestServer(t, &requests, &revalidations, nil)
+	transport := newCacheTransport(http.DefaultTransport, CacheOptions{Dir: t.TempDir()})
+
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	url := server.URL + "/v2/repos/open-sauced/pizza-cli"
+	cacheTestGet(t, transport, url, "")
+
+	now = now.Add(2 * time.Hour)
+	assert.Equal(t, "body of /v2/repos/open-sauced/pizza-cli", cacheTestGet(t, transport, url, ""))
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(1), revalidations.Load())
+
+	// The revalidated response is fresh again
+	cacheTestGet(t, transport, url, "")
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestCacheRefresh(t *testing.T) {
+	t.Parallel()
+	var requests, revalidations atomic.Int32
+	server := newCacheTestServer(t, &requests, &revalidations, nil)
+	dir := t.TempDir()
+
+	url := server.URL + "/v2/contributors/insights/new"
+	cacheTestGet(t, newCacheTransport(http.DefaultTransport, CacheOptions{Dir: dir}), url, "")
+
+	refreshing := newCacheTransport(http.DefaultTransport, CacheOptions{Dir: dir, Refresh: true})
+	assert.Equal(t, "body of /v2/contributors/insights/new", cacheTestGet(t, refreshing, url, ""))
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(1), revalidations.Load())
+}
+
+func TestCacheSkipped(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no TTL", func(t *testing.T) {
+		t.Parallel()
+		var requests, revalidations atomic.Int32
+		server := newCacheTestServer(t, &requests, &revalidations, nil)
+		transport := newCacheTransport(http.DefaultTransport, CacheOptions{Dir: t.TempDir()})
+
+		cacheTestGet(t, transport, server.URL+"/v2/workspaces", "")
+		cacheTestGet(t, transport, server.URL+"/v2/workspaces", "")
+		assert.Equal(t, int32(2), requests.Load())
+		assert.Equal(t, int32(1), revalidations.Load())
+	})
+
+	t.Run("no-store", func(t *testing.T) {
+		t.Parallel()
+		var requests, revalidations atomic.Int32
+		server := newCacheTestServer(t, &requests, &revalidations, http.Header{"Cache-Control": {"no-store"}})
+		dir := t.TempDir()
+		transport := newCacheTransport(http.DefaultTransport, CacheOptions{Dir: dir})
+
+		cacheTestGet(t, transport, server.URL+"/v2/repos/open-sauced/pizza-cli", "")
+		cacheTestGet(t, transport, server.URL+"/v2/repos/open-sauced/pizza-cli", "")
+		assert.Equal(t, int32(2), requests.Load())
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("POST", func(t *testing.T) {
+		t.Parallel()
+		var requests, revalidations atomic.Int32
+		server := newCacheTestServer(t, &requests, &revalidations, nil)
+		client := &http.Client{Transport: newCacheTransport(http.DefaultTransport, CacheOptions{Dir: t.TempDir()})}
+
+		for range 2 {
+			req, err := http.NewRequestWithContext(context.Background(), "POST", server.URL+"/v2/repos/open-sauced/pizza-cli", strings.NewReader("{}"))
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+		}
+		assert.Equal(t, int32(2), requests.Load())
+	})
+}
+
+func TestCacheEviction(t *testing.T) {
+	t.Parallel()
+	var requests, revalidations atomic.Int32
+	server := newCacheTestServer(t, &requests, &revalidations, nil)
+	dir := t.TempDir()
+	transport := newCacheTransport(http.DefaultTransport, CacheOptions{Dir: dir})
+
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	cacheTestGet(t, transport, server.URL+"/v2/repos/open-sauced/first", "")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	info, err := entries[0].Info()
+	require.NoError(t, err)
+
+	// Room for two entries: the least recently used one is evicted for the third
+	transport.maxSize = 2*info.Size() + 10
+	setModTime := func(path string, modTime time.Time) {
+		require.NoError(t, os.Chtimes(transport.entryPath(mustGetRequest(t, server.URL+path)), modTime, modTime))
+	}
+
+	now = now.Add(time.Minute)
+	cacheTestGet(t, transport, server.URL+"/v2/repos/open-sauced/second", "")
+	setModTime("/v2/repos/open-sauced/first", now.Add(-time.Hour))
+
+	now = now.Add(time.Minute)
+	cacheTestGet(t, transport, server.URL+"/v2/repos/open-sauced/third", "")
+
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	_, err = os.Stat(transport.entryPath(mustGetRequest(t, server.URL+"/v2/repos/open-sauced/first")))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func mustGetRequest(t *testing.T, url string) *http.Request {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	require.NoError(t, err)
+
+	return req
+}
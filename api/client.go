@@ -6,10 +6,16 @@ import (
 
 	"github.com/open-sauced/pizza-cli/v2/api/services/contributors"
 	"github.com/open-sauced/pizza-cli/v2/api/services/histogram"
+	"github.com/open-sauced/pizza-cli/v2/api/services/issues"
+	"github.com/open-sauced/pizza-cli/v2/api/services/pulls"
 	"github.com/open-sauced/pizza-cli/v2/api/services/repository"
+	"github.com/open-sauced/pizza-cli/v2/api/services/users"
 	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces"
 )
 
+// DefaultTimeout is how long an API call may take, including its retries
+const DefaultTimeout = 30 * time.Second
+
 // Client is the API client for OpenSauced API
 type Client struct {
 	// API services
@@ -17,6 +23,9 @@ type Client struct {
 	ContributorService *contributors.Service
 	HistogramService   *histogram.Service
 	WorkspacesService  *workspaces.Service
+	PullsService       *pulls.Service
+	IssuesService      *issues.Service
+	UsersService       *users.Service
 
 	// The configured http client for making API requests
 	httpClient *http.Client
@@ -24,25 +33,110 @@ type Client struct {
 	// The API endpoint to use when making requests
 	// Example: https://api.opensauced.pizza
 	endpoint string
+
+	// How long an API call may take, including its retries
+	timeout time.Duration
+
+	// How many times requests failing with a transient error are retried
+	maxRetries int
+
+	// The transport requests are sent with, http.DefaultTransport by default
+	transport http.RoundTripper
+
+	// Provides the access token requests are authenticated with, if any
+	tokenSource TokenSource
+
+	// How many requests may be in flight at once
+	concurrency int
+
+	// How many requests per second are sent at most, and how many at once after
+	// being idle. 0 burst means as many as may be in flight.
+	rateLimit float64
+	rateBurst int
+
+	// Configures the on-disk response cache, nil when responses aren't cached
+	cache *CacheOptions
 }
 
-// NewClient returns a new API Client based on provided inputs
-func NewClient(endpoint string) *Client {
-	httpClient := &http.Client{
-		// TODO (jpmcb): in the future, we can allow users to configure the API
-		// timeout via some global flag
-		Timeout: time.Second * 10,
+// Option configures a Client
+type Option func(*Client)
+
+// WithTimeout sets how long an API call may take, including its retries.
+// 0 means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithMaxRetries sets how many times requests failing with a transient error
+// are retried. 0 disables retries.
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithTransport sets the transport requests are sent with
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithTokenSource sets the source of the access token requests are
+// authenticated with. By default, requests are sent unauthenticated.
+func WithTokenSource(source TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = source
 	}
+}
 
+// NewClient returns a new API Client based on provided inputs
+func NewClient(endpoint string, opts ...Option) *Client {
 	client := Client{
-		httpClient: httpClient,
-		endpoint:   endpoint,
+		endpoint:    endpoint,
+		timeout:     DefaultTimeout,
+		maxRetries:  DefaultMaxRetries,
+		transport:   http.DefaultTransport,
+		concurrency: DefaultConcurrency,
+		rateLimit:   DefaultRateLimit,
+	}
+
+	for _, opt := range opts {
+		opt(&client)
+	}
+
+	burst := client.rateBurst
+	if burst == 0 {
+		burst = client.concurrency
+	}
+
+	transport := http.RoundTripper(newLimitTransport(client.transport, client.concurrency, client.rateLimit, burst))
+	if client.cache != nil {
+		transport = newCacheTransport(transport, *client.cache)
+	}
+	if client.tokenSource != nil {
+		transport = &authTransport{base: transport, source: client.tokenSource}
+	}
+
+	client.httpClient = &http.Client{
+		Timeout: client.timeout,
+		Transport: &retryTransport{
+			base:       transport,
+			maxRetries: client.maxRetries,
+			minBackoff: defaultMinBackoff,
+			maxBackoff: defaultMaxBackoff,
+		},
 	}
 
 	client.ContributorService = contributors.NewContributorsService(client.httpClient, client.endpoint)
 	client.RepositoryService = repository.NewRepositoryService(client.httpClient, client.endpoint)
 	client.HistogramService = histogram.NewHistogramService(client.httpClient, client.endpoint)
 	client.WorkspacesService = workspaces.NewWorkspacesService(client.httpClient, client.endpoint)
+	client.PullsService = pulls.NewPullsService(client.httpClient, client.endpoint)
+	client.IssuesService = issues.NewIssuesService(client.httpClient, client.endpoint)
+	client.UsersService = users.NewUsersService(client.httpClient, client.endpoint)
 
 	return &client
 }
@@ -0,0 +1,16 @@
+package api
+
+import "github.com/open-sauced/pizza-cli/v2/api/services"
+
+// Error is returned by the API services when the API responds with an
+// unexpected status code. It carries the status, endpoint, request ID, and the
+// API's error message. Match it with errors.As, or its status with errors.Is
+// and the sentinel errors below.
+type Error = services.Error
+
+// Sentinel errors matching an Error by its status code
+var (
+	ErrNotFound     = services.ErrNotFound
+	ErrUnauthorized = services.ErrUnauthorized
+	ErrRateLimited  = services.ErrRateLimited
+)
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultConcurrency is how many requests may be in flight at once
+	DefaultConcurrency = 8
+
+	// DefaultRateLimit is how many requests per second are sent at most, before
+	// the API's rate limit headers say otherwise
+	DefaultRateLimit = 10.0
+
+	// resetEpochThreshold tells "X-RateLimit-Reset" headers given as a Unix
+	// timestamp apart from ones given in seconds from now
+	resetEpochThreshold = 1_000_000_000
+)
+
+// WithConcurrency sets how many requests may be in flight at once, shared by
+// everything using the client
+func WithConcurrency(concurrency int) Option {
+	return func(c *Client) {
+		c.concurrency = concurrency
+	}
+}
+
+// WithRateLimit sets how many requests per second are sent at most, and how
+// many may be sent at once after being idle. The rate slows down when the
+// API's rate limit headers say the remaining requests won't last otherwise.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(c *Client) {
+		c.rateLimit = rate
+		c.rateBurst = burst
+	}
+}
+
+// limitTransport is an http.RoundTripper bounding how many requests are in
+// flight at once and how fast they're sent. Requests hold their slot until
+// their response's body is closed.
+type limitTransport struct {
+	base   http.RoundTripper
+	slots  chan struct{}
+	bucket *tokenBucket
+}
+
+func newLimitTransport(base http.RoundTripper, concurrency int, rate float64, burst int) *limitTransport {
+	return &limitTransport{
+		base:   base,
+		slots:  make(chan struct{}, max(concurrency, 1)),
+		bucket: newTokenBucket(rate, burst),
+	}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	select {
+	case t.slots <- struct{}{}:
+	case <-ctx.Done():
+		closeBody(req)
+		return nil, ctx.Err()
+	}
+
+	if err := t.bucket.wait(ctx); err != nil {
+		<-t.slots
+		closeBody(req)
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		<-t.slots
+		return nil, err
+	}
+
+	t.bucket.observe(resp)
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { <-t.slots }}
+
+	return resp, nil
+}
+
+// releasingBody is a response body releasing its request's slot when closed
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// tokenBucket is a rate limiter handing out tokens at a steady rate, up to a
+// burst. Its rate adapts to the API's rate limit headers, spreading the
+// remaining requests over the rest of the rate limit window, and it pauses
+// until the window resets when there are none left.
+type tokenBucket struct {
+	mu sync.Mutex
+
+	// maxRate is the configured rate, rate the current one
+	maxRate float64
+	rate    float64
+	burst   float64
+
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+
+	now func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if rate <= 0 {
+		rate = DefaultRateLimit
+	}
+
+	b := &tokenBucket{
+		maxRate: rate,
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		now:     time.Now,
+	}
+	b.tokens = b.burst
+	b.last = b.now()
+
+	return b
+}
+
+// wait blocks until a token is available and takes it
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := b.take()
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take takes a token if one is available, otherwise it returns how long to
+// wait for one
+func (b *tokenBucket) take() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.refill(now)
+
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now)
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return max(time.Duration((1-b.tokens)/b.rate*float64(time.Second)), time.Millisecond)
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// observe adapts the rate to the rate limit headers of the response
+func (b *tokenBucket) observe(resp *http.Response) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.refill(now)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			b.pause(now.Add(retryAfter))
+			return
+		}
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-Ratelimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	reset, ok := parseRateLimitReset(resp.Header.Get("X-Ratelimit-Reset"), now)
+	if !ok {
+		return
+	}
+
+	if remaining <= 0 {
+		b.pause(now.Add(reset))
+		return
+	}
+
+	// Spread the remaining requests over the rest of the window
+	b.rate = math.Min(b.maxRate, float64(remaining)/math.Max(reset.Seconds(), 1))
+}
+
+// pause holds every request until the given time, after which they're sent
+// at the configured rate again
+func (b *tokenBucket) pause(until time.Time) {
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+
+	// Resume with a single request, to see how the window looks after the reset
+	b.tokens = 1
+	b.last = until
+	b.rate = b.maxRate
+}
+
+// parseRateLimitReset parses an "X-RateLimit-Reset" header, given either in
+// seconds from now or as a Unix timestamp, into how long until the reset
+func parseRateLimitReset(value string, now time.Time) (time.Duration, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	if seconds >= resetEpochThreshold {
+		return max(time.Unix(seconds, 0).Sub(now), 0), true
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitConcurrency(t *testing.T) {
+	t.Parallel()
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithConcurrency(3), WithRateLimit(1000, 100))
+
+	var wg sync.WaitGroup
+	for range 12 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+			assert.NoError(t, err)
+
+			resp, err := client.httpClient.Do(req)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), maxInFlight.Load())
+}
+
+func TestLimitCanceled(t *testing.T) {
+	t.Parallel()
+	transport := newLimitTransport(http.DefaultTransport, 1, 1000, 1)
+	transport.slots <- struct{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://localhost", nil)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(req) //nolint:bodyclose // no response on errors
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTokenBucketRate(t *testing.T) {
+	t.Parallel()
+	bucket := newTokenBucket(200, 1)
+
+	start := time.Now()
+	for range 6 {
+		require.NoError(t, bucket.wait(context.Background()))
+	}
+
+	// The first token is there right away, the other five take 5ms each
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestTokenBucketObserve(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, time.September, 1, 12, 0, 0, 0, time.UTC)
+
+	newResponse := func(status int, header map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		for key, value := range header {
+			resp.Header.Set(key, value)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name   string
+		resp   *http.Response
+		rate   float64
+		paused time.Duration
+	}{
+		{
+			name: "no rate limit headers",
+			resp: newResponse(http.StatusOK, nil),
+			rate: 10,
+		},
+		{
+			name: "plenty remaining",
+			resp: newResponse(http.StatusOK, map[string]string{"X-Ratelimit-Remaining": "5000", "X-Ratelimit-Reset": "60"}),
+			rate: 10,
+		},
+		{
+			name: "running out",
+			resp: newResponse(http.StatusOK, map[string]string{"X-Ratelimit-Remaining": "30", "X-Ratelimit-Reset": "60"}),
+			rate: 0.5,
+		},
+		{
+			name: "reset as a Unix timestamp",
+			resp: newResponse(http.StatusOK, map[string]string{"X-Ratelimit-Remaining": "30", "X-Ratelimit-Reset": strconv.FormatInt(now.Add(15*time.Second).Unix(), 10)}),
+			rate: 2,
+		},
+		{
+			name:   "none remaining",
+			resp:   newResponse(http.StatusOK, map[string]string{"X-Ratelimit-Remaining": "0", "X-Ratelimit-Reset": "42"}),
+			rate:   10,
+			paused: 42 * time.Second,
+		},
+		{
+			name:   "rate limited",
+			resp:   newResponse(http.StatusTooManyRequests, map[string]string{"Retry-After": "7"}),
+			rate:   10,
+			paused: 7 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			bucket := newTokenBucket(10, 5)
+			bucket.now = func() time.Time { return now }
+
+			bucket.observe(tt.resp)
+			assert.InDelta(t, tt.rate, bucket.rate, 0.001)
+
+			if tt.paused > 0 {
+				assert.Equal(t, tt.paused, bucket.take())
+			} else {
+				assert.Equal(t, time.Duration(0), bucket.take())
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package mock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recorderTestGet(t *testing.T, recorder *Recorder, url string) (*http.Response, string) {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret-token")
+
+	resp, err := (&http.Client{Transport: recorder}).Do(req)
+	if err != nil {
+		return nil, err.Error()
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, string(body)
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		if r.URL.Path == "/v2/repos/open-sauced/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `","range":"` + r.URL.Query().Get("range") + `"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recording := &Recorder{Dir: dir, Record: true}
+
+	resp, body := recorderTestGet(t, recording, server.URL+"/v2/repos/open-sauced/pizza-cli?range=30")
+	require.NotNil(t, resp)
+	assert.JSONEq(t, `{"path":"/v2/repos/open-sauced/pizza-cli","range":"30"}`, body)
+	recorderTestGet(t, recording, server.URL+"/v2/repos/open-sauced/missing")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		data, err := os.ReadFile(dir + "/" + entry.Name())
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "secret")
+	}
+
+	// Fixtures replay against any endpoint, with no network access
+	server.Close()
+	replaying := NewRecorder(dir)
+
+	resp, body = recorderTestGet(t, replaying, "https://api.example.com/v2/repos/open-sauced/pizza-cli?range=30")
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"path":"/v2/repos/open-sauced/pizza-cli","range":"30"}`, body)
+
+	resp, _ = recorderTestGet(t, replaying, "https://api.example.com/v2/repos/open-sauced/missing")
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, errMsg := recorderTestGet(t, replaying, "https://api.example.com/v2/repos/open-sauced/pizza-cli?range=90")
+	assert.Nil(t, resp)
+	assert.Contains(t, errMsg, "no recorded fixture for GET /v2/repos/open-sauced/pizza-cli?range=90: record it with PIZZA_RECORD=1")
+}
+
+func TestReplayer(t *testing.T) {
+	t.Setenv(RecordEnvVar, "1")
+
+	dir := t.TempDir()
+	fixture := `{"request":{"method":"GET","path":"/v2/repos/open-sauced/pizza-cli"},"response":{"status_code":200,"body":{"hand":"authored"}}}`
+	require.NoError(t, os.WriteFile(dir+"/GET_v2_repos_open-sauced_pizza-cli.json", []byte(fixture), 0600))
+
+	// Hand-authored fixtures are replayed even when recording
+	replayer := NewReplayer(dir)
+	resp, body := recorderTestGet(t, replayer, "https://api.example.com/v2/repos/open-sauced/pizza-cli")
+	require.NotNil(t, resp)
+	assert.JSONEq(t, `{"hand":"authored"}`, body)
+
+	resp, errMsg := recorderTestGet(t, replayer, "https://api.example.com/v2/repos/open-sauced/missing")
+	assert.Nil(t, resp)
+	assert.Contains(t, errMsg, "no recorded fixture for GET /v2/repos/open-sauced/missing: write it by hand in "+dir)
+
+	data, err := os.ReadFile(dir + "/GET_v2_repos_open-sauced_pizza-cli.json")
+	require.NoError(t, err)
+	assert.Equal(t, fixture, string(data))
+}
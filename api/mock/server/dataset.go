@@ -0,0 +1,218 @@
+package server
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services/repository"
+)
+
+// sampleDataset is the dataset served when none is given
+//
+//go:embed dataset.yaml
+var sampleDataset []byte
+
+// Dataset is the data the mock server serves. It's loaded from YAML or JSON,
+// with the field names of the OpenSauced API.
+type Dataset struct {
+	// User is the user authenticated requests are made as, owning the workspaces
+	User User `json:"user"`
+
+	// Users are the GitHub users contributing to the repositories. Authors
+	// and reviewers missing from it are added with just their login.
+	Users []User `json:"users"`
+
+	// Repositories are the indexed repositories
+	Repositories []repository.DbRepository `json:"repositories"`
+
+	// PullRequests are the pull requests the insights are computed from
+	PullRequests []PullRequest `json:"pull_requests"`
+
+	// Issues are the issues of the repositories
+	Issues []Issue `json:"issues"`
+
+	// Workspaces are the workspaces of the authenticated user
+	Workspaces []Workspace `json:"workspaces"`
+}
+
+// User is a GitHub user
+type User struct {
+	ID        int     `json:"id"`
+	Login     string  `json:"login"`
+	Name      string  `json:"name"`
+	Bio       string  `json:"bio"`
+	AvatarURL string  `json:"avatar_url"`
+	Company   string  `json:"company"`
+	Location  string  `json:"location"`
+	OSCR      float64 `json:"oscr"`
+
+	// Highlights are the contributions the user highlighted on their profile
+	Highlights []Highlight `json:"highlights"`
+}
+
+// Highlight is a contribution a user highlighted on their profile
+type Highlight struct {
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Highlight string `json:"highlight"`
+}
+
+// PullRequest is a pull request to an indexed repository
+type PullRequest struct {
+	// Repo is the full name of the repository, like "open-sauced/pizza-cli"
+	Repo string `json:"repo"`
+
+	// Author is the login of the pull request's author
+	Author string `json:"author"`
+
+	// Number is the pull request's number in its repository. Pull requests
+	// without one are numbered in the order they were opened.
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+
+	// State is one of "open", "closed", or "merged"
+	State string `json:"state"`
+
+	Draft   bool `json:"draft"`
+	Spam    bool `json:"spam"`
+	Commits int  `json:"commits"`
+
+	// Reviewers are the logins of the users who reviewed the pull request
+	Reviewers []string `json:"reviewers"`
+
+	// CreatedAt is when the pull request was opened. Datasets that shouldn't
+	// age out of the ranges insights are filtered by use DaysAgo instead.
+	CreatedAt *time.Time `json:"created_at"`
+	DaysAgo   int        `json:"days_ago"`
+}
+
+// Issue is an issue of an indexed repository
+type Issue struct {
+	// Repo is the full name of the repository, like "open-sauced/pizza-cli"
+	Repo string `json:"repo"`
+
+	// Author is the login of the issue's author
+	Author string `json:"author"`
+
+	// Number is the issue's number in its repository. Issues without one are
+	// numbered after the repository's pull requests, in the order they were
+	// opened.
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+
+	// State is either "open" or "closed"
+	State string `json:"state"`
+
+	Comments int `json:"comments"`
+
+	// CreatedAt is when the issue was opened. Datasets that shouldn't age out
+	// of the ranges issues are searched by use DaysAgo instead.
+	CreatedAt *time.Time `json:"created_at"`
+	DaysAgo   int        `json:"days_ago"`
+}
+
+// Workspace is a workspace of the authenticated user
+type Workspace struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	IsPublic     bool       `json:"is_public"`
+	Repositories []string   `json:"repositories"`
+	Members      []string   `json:"members"`
+	UserLists    []UserList `json:"user_lists"`
+}
+
+// UserList is a contributor list in a workspace
+type UserList struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	IsPublic     bool     `json:"is_public"`
+	Contributors []string `json:"contributors"`
+}
+
+// LoadDataset reads a YAML or JSON dataset from the given file
+func LoadDataset(path string) (*Dataset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read dataset: %w", err)
+	}
+
+	dataset, err := ParseDataset(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dataset %s: %w", path, err)
+	}
+
+	return dataset, nil
+}
+
+// SampleDataset returns a small dataset of the OpenSauced repositories, so the
+// mock server can be tried out without writing one
+func SampleDataset() *Dataset {
+	dataset, err := ParseDataset(sampleDataset)
+	if err != nil {
+		panic(fmt.Sprintf("invalid sample dataset: %v", err))
+	}
+
+	return dataset
+}
+
+// ParseDataset parses a YAML or JSON dataset
+func ParseDataset(data []byte) (*Dataset, error) {
+	// JSON is YAML, and going through JSON lets the dataset reuse the API's
+	// field names from the json tags of the API types
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataset Dataset
+	if err := json.Unmarshal(jsonData, &dataset); err != nil {
+		return nil, err
+	}
+
+	for i, pr := range dataset.PullRequests {
+		switch pr.State {
+		case stateOpen, stateClosed, stateMerged:
+		default:
+			return nil, fmt.Errorf("pull request %d of %s by %s has unknown state %q, must be one of: open, closed, merged", i, pr.Repo, pr.Author, pr.State)
+		}
+	}
+
+	for i, issue := range dataset.Issues {
+		switch issue.State {
+		case stateOpen, stateClosed:
+		default:
+			return nil, fmt.Errorf("issue %d of %s by %s has unknown state %q, must be one of: open, closed", i, issue.Repo, issue.Author, issue.State)
+		}
+	}
+
+	return &dataset, nil
+}
+
+// createdAt returns when the pull request was opened, relative to now
+func (pr PullRequest) createdAt(now time.Time) time.Time {
+	if pr.CreatedAt != nil {
+		return *pr.CreatedAt
+	}
+
+	return now.AddDate(0, 0, -pr.DaysAgo)
+}
+
+// createdAt returns when the issue was opened, relative to now
+func (issue Issue) createdAt(now time.Time) time.Time {
+	if issue.CreatedAt != nil {
+		return *issue.CreatedAt
+	}
+
+	return now.AddDate(0, 0, -issue.DaysAgo)
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services/contributors"
+	"github.com/open-sauced/pizza-cli/v2/api/services/histogram"
+	"github.com/open-sauced/pizza-cli/v2/api/services/repository"
+)
+
+// activity is what a contributor did in the repositories insights are
+// computed for
+type activity struct {
+	login string
+
+	// lastContributed is when the contributor's latest pull request was opened
+	lastContributed time.Time
+
+	// inRange and beforeRange count the contributor's pull requests opened
+	// within the range and before it. inPreviousRange counts the ones opened in
+	// the range of the same length right before it.
+	inRange         int
+	beforeRange     int
+	inPreviousRange int
+
+	// commits is how many commits the pull requests within the range have
+	commits int
+}
+
+func (s *Server) handleGetRepository(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, ok := s.findRepository(r.PathValue("owner") + "/" + r.PathValue("name"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Repository not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, repo)
+}
+
+func (s *Server) handleGetRepositoryContributors(w http.ResponseWriter, r *http.Request) {
+	rangeVal, ok := rangeQuery(r)
+	page, limit, pageOK := pageQuery(r)
+	if !ok || !pageOK {
+		writeError(w, http.StatusBadRequest, "range, page, and limit must be positive integers")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, ok := s.findRepository(r.PathValue("owner") + "/" + r.PathValue("name"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Repository not found")
+		return
+	}
+
+	var infos []repository.DbContributorInfo
+	for _, a := range s.activities([]string{repo.FullName}, rangeVal) {
+		if a.inRange == 0 {
+			continue
+		}
+
+		user := s.lookupUser(a.login)
+		infos = append(infos, repository.DbContributorInfo{
+			ID:                 user.ID,
+			Login:              user.Login,
+			AvatarURL:          user.AvatarURL,
+			Company:            user.Company,
+			Location:           user.Location,
+			OSCR:               user.OSCR,
+			Repos:              []string{repo.FullName},
+			Tags:               []string{},
+			Commits:            a.commits,
+			PRsCreated:         a.inRange,
+			TotalContributions: a.commits + a.inRange,
+			LastContributed:    a.lastContributed,
+		})
+	}
+
+	slices.SortStableFunc(infos, func(a, b repository.DbContributorInfo) int {
+		return cmp.Compare(b.TotalContributions, a.TotalContributions)
+	})
+
+	data, meta := paginate(infos, page, limit)
+	writeJSON(w, http.StatusOK, repository.ContributorsResponse{Data: data, Meta: meta})
+}
+
+func (s *Server) handleGetContributorInsights(w http.ResponseWriter, r *http.Request) {
+	var include func(a activity) bool
+
+	switch r.PathValue("kind") {
+	case "new":
+		include = func(a activity) bool { return a.inRange > 0 && a.beforeRange == 0 }
+	case "recent":
+		include = func(a activity) bool { return a.inRange > 0 }
+	case "alumni":
+		include = func(a activity) bool { return a.inRange == 0 && a.inPreviousRange > 0 }
+	case "repeat":
+		include = func(a activity) bool { return a.inRange > 0 && a.beforeRange > 0 }
+	default:
+		writeError(w, http.StatusNotFound, "Cannot GET "+r.URL.Path)
+		return
+	}
+
+	s.writeContributors(w, r, include)
+}
+
+func (s *Server) handleSearchContributors(w http.ResponseWriter, r *http.Request) {
+	s.writeContributors(w, r, func(a activity) bool { return a.inRange > 0 })
+}
+
+// writeContributors writes the page of contributors to the requested
+// repositories whose activity is included
+func (s *Server) writeContributors(w http.ResponseWriter, r *http.Request, include func(a activity) bool) {
+	rangeVal, ok := rangeQuery(r)
+	page, limit, pageOK := pageQuery(r)
+	if !ok || !pageOK {
+		writeError(w, http.StatusBadRequest, "range, page, and limit must be positive integers")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var contribs []contributors.DbContributor
+	for _, a := range s.activities(listQuery(r, "repos"), rangeVal) {
+		if include(a) {
+			contribs = append(contribs, contributors.DbContributor{
+				AuthorLogin: s.lookupUser(a.login).Login,
+				UserID:      s.lookupUser(a.login).ID,
+				UpdatedAt:   a.lastContributed,
+			})
+		}
+	}
+
+	data, meta := paginate(contribs, page, limit)
+	writeJSON(w, http.StatusOK, contributors.ContribResponse{Data: data, Meta: meta})
+}
+
+func (s *Server) handleGetPullRequestHistogram(w http.ResponseWriter, r *http.Request) {
+	rangeVal, ok := rangeQuery(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "range must be a positive integer")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	start := now.AddDate(0, 0, -rangeVal)
+	buckets := make(map[time.Time]*histogram.PrHistogramData)
+
+	for _, pr := range s.pullRequestsOf([]string{r.URL.Query().Get("repo")}) {
+		createdAt := pr.createdAt(now)
+		if !createdAt.After(start) {
+			continue
+		}
+
+		day := createdAt.UTC().Truncate(24 * time.Hour)
+		bucket, ok := buckets[day]
+		if !ok {
+			bucket = &histogram.PrHistogramData{Bucket: day}
+			buckets[day] = bucket
+		}
+
+		bucket.PrCount++
+		switch {
+		case pr.Spam:
+			bucket.SpamPrs++
+		case pr.State == stateMerged:
+			bucket.AcceptedPrs++
+		case pr.State == stateClosed:
+			bucket.ClosedPrs++
+		case pr.Draft:
+			bucket.DraftPrs++
+		default:
+			bucket.OpenPrs++
+			bucket.ActivePrs++
+		}
+	}
+
+	data := make([]histogram.PrHistogramData, 0, len(buckets))
+	for _, bucket := range buckets {
+		data = append(data, *bucket)
+	}
+	slices.SortFunc(data, func(a, b histogram.PrHistogramData) int {
+		return b.Bucket.Compare(a.Bucket)
+	})
+
+	writeJSON(w, http.StatusOK, data)
+}
+
+// findRepository returns the repository with the given full name, ignoring case
+func (s *Server) findRepository(fullName string) (repository.DbRepository, bool) {
+	for _, repo := range s.repositories {
+		if strings.EqualFold(repo.FullName, fullName) {
+			return repo, true
+		}
+	}
+
+	return repository.DbRepository{}, false
+}
+
+// pullRequestsOf returns the pull requests to the given repositories
+func (s *Server) pullRequestsOf(repos []string) []PullRequest {
+	var prs []PullRequest
+	for _, pr := range s.pullRequests {
+		if slices.ContainsFunc(repos, func(repo string) bool { return strings.EqualFold(repo, pr.Repo) }) {
+			prs = append(prs, pr)
+		}
+	}
+
+	return prs
+}
+
+// activities returns what the contributors to the given repositories did,
+// relative to the range of days ending now. The most recently active
+// contributors come first.
+func (s *Server) activities(repos []string, rangeVal int) []activity {
+	now := s.now()
+	start := now.AddDate(0, 0, -rangeVal)
+	previousStart := start.AddDate(0, 0, -rangeVal)
+
+	byLogin := make(map[string]*activity)
+	var activities []*activity
+	for _, pr := range s.pullRequestsOf(repos) {
+		key := strings.ToLower(pr.Author)
+		a, ok := byLogin[key]
+		if !ok {
+			a = &activity{login: pr.Author}
+			byLogin[key] = a
+			activities = append(activities, a)
+		}
+
+		createdAt := pr.createdAt(now)
+		if createdAt.After(a.lastContributed) {
+			a.lastContributed = createdAt
+		}
+
+		switch {
+		case createdAt.After(start):
+			a.inRange++
+			a.commits += pr.Commits
+		case createdAt.After(previousStart):
+			a.beforeRange++
+			a.inPreviousRange++
+		default:
+			a.beforeRange++
+		}
+	}
+
+	result := make([]activity, 0, len(activities))
+	for _, a := range activities {
+		result = append(result, *a)
+	}
+	slices.SortStableFunc(result, func(a, b activity) int {
+		return b.lastContributed.Compare(a.lastContributed)
+	})
+
+	return result
+}
@@ -0,0 +1,226 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services/issues"
+	"github.com/open-sauced/pizza-cli/v2/api/services/pulls"
+)
+
+// numberPullRequestsAndIssues numbers the pull requests and issues without a
+// number in the order they were opened. As on GitHub, they share their
+// repository's numbers; they're numbered after the highest number given,
+// pull requests first.
+func (s *Server) numberPullRequestsAndIssues() {
+	now := s.now()
+	next := make(map[string]int)
+	nextNumber := func(repo string) int {
+		key := strings.ToLower(repo)
+		next[key]++
+		return next[key]
+	}
+
+	for _, pr := range s.pullRequests {
+		key := strings.ToLower(pr.Repo)
+		next[key] = max(next[key], pr.Number)
+	}
+	for _, issue := range s.issues {
+		key := strings.ToLower(issue.Repo)
+		next[key] = max(next[key], issue.Number)
+	}
+
+	prOrder := make([]int, len(s.pullRequests))
+	for i := range prOrder {
+		prOrder[i] = i
+	}
+	slices.SortStableFunc(prOrder, func(a, b int) int {
+		return s.pullRequests[a].createdAt(now).Compare(s.pullRequests[b].createdAt(now))
+	})
+	for _, i := range prOrder {
+		if pr := &s.pullRequests[i]; pr.Number == 0 {
+			pr.Number = nextNumber(pr.Repo)
+		}
+	}
+
+	issueOrder := make([]int, len(s.issues))
+	for i := range issueOrder {
+		issueOrder[i] = i
+	}
+	slices.SortStableFunc(issueOrder, func(a, b int) int {
+		return s.issues[a].createdAt(now).Compare(s.issues[b].createdAt(now))
+	})
+	for _, i := range issueOrder {
+		if issue := &s.issues[i]; issue.Number == 0 {
+			issue.Number = nextNumber(issue.Repo)
+		}
+	}
+}
+
+func (s *Server) handleSearchPullRequests(w http.ResponseWriter, r *http.Request) {
+	rangeVal, ok := rangeQuery(r)
+	page, limit, pageOK := pageQuery(r)
+	if !ok || !pageOK {
+		writeError(w, http.StatusBadRequest, "range, page, and limit must be positive integers")
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", stateOpen, stateClosed, stateMerged:
+	default:
+		writeError(w, http.StatusBadRequest, "status must be one of: open, closed, merged")
+		return
+	}
+
+	contributor := r.URL.Query().Get("contributor")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	start := now.AddDate(0, 0, -rangeVal)
+
+	var prs []pulls.DbPullRequest
+	for _, pr := range s.pullRequestsOf(listQuery(r, "repos")) {
+		createdAt := pr.createdAt(now)
+		if !createdAt.After(start) ||
+			(status != "" && pr.State != status) ||
+			(contributor != "" && !strings.EqualFold(pr.Author, contributor)) {
+			continue
+		}
+
+		prs = append(prs, s.dbPullRequest(pr, createdAt))
+	}
+
+	slices.SortStableFunc(prs, func(a, b pulls.DbPullRequest) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	data, meta := paginate(prs, page, limit)
+	writeJSON(w, http.StatusOK, pulls.PullRequestsResponse{Data: data, Meta: meta})
+}
+
+// dbPullRequest returns the pull request the way the API does
+func (s *Server) dbPullRequest(pr PullRequest, createdAt time.Time) pulls.DbPullRequest {
+	title := pr.Title
+	if title == "" {
+		title = fmt.Sprintf("Pull request #%d", pr.Number)
+	}
+
+	dbPR := pulls.DbPullRequest{
+		EventID:           pr.Number,
+		Number:            pr.Number,
+		Title:             title,
+		State:             pulls.StateOpen,
+		IsDraft:           pr.Draft,
+		AuthorLogin:       s.lookupUser(pr.Author).Login,
+		RepoName:          s.repoName(pr.Repo),
+		Commits:           pr.Commits,
+		CreatedAt:         createdAt,
+		UpdatedAt:         createdAt,
+		AuthorAssociation: "CONTRIBUTOR",
+	}
+
+	if pr.State != stateOpen {
+		closedAt := createdAt.Add(24 * time.Hour)
+		dbPR.State = pulls.StateClosed
+		dbPR.ClosedAt = &closedAt
+		dbPR.UpdatedAt = closedAt
+
+		if pr.State == stateMerged {
+			dbPR.IsMerged = true
+			dbPR.MergedAt = &closedAt
+		}
+	}
+
+	return dbPR
+}
+
+func (s *Server) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
+	rangeVal, ok := rangeQuery(r)
+	page, limit, pageOK := pageQuery(r)
+	if !ok || !pageOK {
+		writeError(w, http.StatusBadRequest, "range, page, and limit must be positive integers")
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", stateOpen, stateClosed:
+	default:
+		writeError(w, http.StatusBadRequest, "status must be one of: open, closed")
+		return
+	}
+
+	contributor := r.URL.Query().Get("contributor")
+	repos := listQuery(r, "repos")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	start := now.AddDate(0, 0, -rangeVal)
+
+	var result []issues.DbIssue
+	for _, issue := range s.issues {
+		createdAt := issue.createdAt(now)
+		if !slices.ContainsFunc(repos, func(repo string) bool { return strings.EqualFold(repo, issue.Repo) }) ||
+			!createdAt.After(start) ||
+			(status != "" && issue.State != status) ||
+			(contributor != "" && !strings.EqualFold(issue.Author, contributor)) {
+			continue
+		}
+
+		result = append(result, s.dbIssue(issue, createdAt))
+	}
+
+	slices.SortStableFunc(result, func(a, b issues.DbIssue) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	data, meta := paginate(result, page, limit)
+	writeJSON(w, http.StatusOK, issues.IssuesResponse{Data: data, Meta: meta})
+}
+
+// dbIssue returns the issue the way the API does
+func (s *Server) dbIssue(issue Issue, createdAt time.Time) issues.DbIssue {
+	title := issue.Title
+	if title == "" {
+		title = fmt.Sprintf("Issue #%d", issue.Number)
+	}
+
+	dbIssue := issues.DbIssue{
+		EventID:           issue.Number,
+		Number:            issue.Number,
+		Title:             title,
+		State:             issue.State,
+		AuthorLogin:       s.lookupUser(issue.Author).Login,
+		RepoName:          s.repoName(issue.Repo),
+		Comments:          issue.Comments,
+		AuthorAssociation: "CONTRIBUTOR",
+		CreatedAt:         createdAt,
+		UpdatedAt:         createdAt,
+	}
+
+	if issue.State == stateClosed {
+		closedAt := createdAt.Add(24 * time.Hour)
+		dbIssue.ClosedAt = &closedAt
+		dbIssue.UpdatedAt = closedAt
+	}
+
+	return dbIssue
+}
+
+// repoName returns the full name of the indexed repository, as it's cased
+// in the dataset
+func (s *Server) repoName(fullName string) string {
+	if repo, ok := s.findRepository(fullName); ok {
+		return repo.FullName
+	}
+
+	return fullName
+}
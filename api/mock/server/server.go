@@ -0,0 +1,271 @@
+// Package server is a stand-in for the OpenSauced API, serving a dataset of
+// repositories, pull requests, and workspaces from memory. Point the CLI's
+// "--endpoint" at it for demos, integration tests, and air-gapped environments.
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/repository"
+)
+
+const (
+	defaultPage  = 1
+	defaultLimit = 10
+	maxLimit     = 1000
+	defaultRange = 30
+
+	stateOpen   = "open"
+	stateClosed = "closed"
+	stateMerged = "merged"
+)
+
+// Server is an http.Handler serving the OpenSauced API endpoints the CLI uses
+// from a Dataset. Workspaces and their contributor lists can be created,
+// edited, and deleted; the changes live as long as the server.
+type Server struct {
+	mux *http.ServeMux
+
+	// now is the time insights are computed at
+	now func() time.Time
+
+	// mu guards the fields below
+	mu           sync.Mutex
+	user         User
+	users        map[string]User
+	repositories []repository.DbRepository
+	pullRequests []PullRequest
+	issues       []Issue
+	workspaces   []*workspaceState
+}
+
+// New returns a new Server serving the given dataset
+func New(dataset *Dataset) *Server {
+	s := &Server{
+		mux:          http.NewServeMux(),
+		now:          time.Now,
+		user:         dataset.User,
+		users:        make(map[string]User),
+		repositories: dataset.Repositories,
+		pullRequests: slices.Clone(dataset.PullRequests),
+		issues:       slices.Clone(dataset.Issues),
+	}
+	s.numberPullRequestsAndIssues()
+
+	if s.user.Login == "" {
+		s.user.Login = "pizza"
+	}
+	if s.user.ID == 0 {
+		s.user.ID = 1
+	}
+
+	s.addUser(s.user)
+	for _, user := range dataset.Users {
+		s.addUser(user)
+	}
+	for _, pr := range dataset.PullRequests {
+		s.addUser(User{Login: pr.Author})
+		for _, reviewer := range pr.Reviewers {
+			s.addUser(User{Login: reviewer})
+		}
+	}
+	for _, issue := range dataset.Issues {
+		s.addUser(User{Login: issue.Author})
+	}
+
+	for _, workspace := range dataset.Workspaces {
+		s.workspaces = append(s.workspaces, s.newWorkspaceState(workspace))
+	}
+
+	s.mux.HandleFunc("GET /v2/repos/{owner}/{name}", s.handleGetRepository)
+	s.mux.HandleFunc("GET /v2/repos/{owner}/{name}/contributors", s.handleGetRepositoryContributors)
+	s.mux.HandleFunc("GET /v2/contributors/insights/{kind}", s.handleGetContributorInsights)
+	s.mux.HandleFunc("GET /v2/contributors/search", s.handleSearchContributors)
+	s.mux.HandleFunc("GET /v2/histogram/pull-requests", s.handleGetPullRequestHistogram)
+	s.mux.HandleFunc("GET /v2/prs/search", s.handleSearchPullRequests)
+	s.mux.HandleFunc("GET /v2/issues/search", s.handleSearchIssues)
+	s.mux.HandleFunc("GET /v2/users/{username}", s.handleGetUser)
+	s.mux.HandleFunc("GET /v2/users/{username}/highlights", s.handleGetUserHighlights)
+	s.mux.HandleFunc("GET /v2/users/{username}/devstats", s.handleGetUserDevstats)
+
+	s.mux.HandleFunc("GET /v2/workspaces", s.authenticated(s.handleGetWorkspaces))
+	s.mux.HandleFunc("POST /v2/workspaces", s.authenticated(s.handleCreateWorkspace))
+	s.mux.HandleFunc("GET /v2/workspaces/{id}", s.authenticated(s.handleGetWorkspace))
+	s.mux.HandleFunc("PATCH /v2/workspaces/{id}", s.authenticated(s.handleUpdateWorkspace))
+	s.mux.HandleFunc("DELETE /v2/workspaces/{id}", s.authenticated(s.handleDeleteWorkspace))
+	s.mux.HandleFunc("GET /v2/workspaces/{id}/repos", s.authenticated(s.handleGetWorkspaceRepos))
+	s.mux.HandleFunc("POST /v2/workspaces/{id}/repos", s.authenticated(s.handleAddWorkspaceRepos))
+	s.mux.HandleFunc("DELETE /v2/workspaces/{id}/repos", s.authenticated(s.handleRemoveWorkspaceRepos))
+	s.mux.HandleFunc("POST /v2/workspaces/{id}/members", s.authenticated(s.handleAddWorkspaceMembers))
+	s.mux.HandleFunc("GET /v2/workspaces/{id}/userLists", s.authenticated(s.handleGetUserLists))
+	s.mux.HandleFunc("POST /v2/workspaces/{id}/userLists", s.authenticated(s.handleCreateUserList))
+	s.mux.HandleFunc("GET /v2/workspaces/{id}/userLists/{listID}", s.authenticated(s.handleGetUserList))
+	s.mux.HandleFunc("PATCH /v2/workspaces/{id}/userLists/{listID}", s.authenticated(s.handleUpdateUserList))
+	s.mux.HandleFunc("DELETE /v2/workspaces/{id}/userLists/{listID}", s.authenticated(s.handleDeleteUserList))
+
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Cannot "+r.Method+" "+r.URL.Path)
+	})
+
+	return s
+}
+
+// ServeHTTP fulfills the http.Handler interface
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// addUser adds a user unless there already is one with the same login,
+// assigning the next free ID when it has none
+func (s *Server) addUser(user User) {
+	key := strings.ToLower(user.Login)
+	if _, ok := s.users[key]; ok {
+		return
+	}
+
+	if user.ID == 0 {
+		user.ID = len(s.users) + 1000
+	}
+
+	s.users[key] = user
+}
+
+// userByID returns the user with the given ID
+func (s *Server) userByID(id int) (User, bool) {
+	for _, user := range s.users {
+		if user.ID == id {
+			return user, true
+		}
+	}
+
+	return User{}, false
+}
+
+// lookupUser returns the user with the given login, adding one if there's none
+func (s *Server) lookupUser(login string) User {
+	s.addUser(User{Login: login})
+	return s.users[strings.ToLower(login)]
+}
+
+// authenticated rejects requests without an access token, as the API does for
+// the endpoints of the authenticated user. Any token is accepted.
+func (s *Server) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// pageQuery parses the "page" and "limit" query parameters
+func pageQuery(r *http.Request) (int, int, bool) {
+	page, ok := intQuery(r, "page", defaultPage)
+	if !ok || page < 1 {
+		return 0, 0, false
+	}
+
+	limit, ok := intQuery(r, "limit", defaultLimit)
+	if !ok || limit < 1 || limit > maxLimit {
+		return 0, 0, false
+	}
+
+	return page, limit, true
+}
+
+// rangeQuery parses the "range" query parameter, the number of days insights
+// are computed over
+func rangeQuery(r *http.Request) (int, bool) {
+	rangeVal, ok := intQuery(r, "range", defaultRange)
+	return rangeVal, ok && rangeVal > 0
+}
+
+func intQuery(r *http.Request, name string, defaultValue int) (int, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, true
+	}
+
+	n, err := strconv.Atoi(value)
+	return n, err == nil
+}
+
+// listQuery parses a comma separated query parameter
+func listQuery(r *http.Request, name string) []string {
+	var values []string
+	for _, value := range strings.Split(r.URL.Query().Get(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// paginate returns the given page of the items, with the page's metadata
+func paginate[T any](items []T, page, limit int) ([]T, services.MetaData) {
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+	pageCount := (len(items) + limit - 1) / limit
+
+	data := items[start:end]
+	if data == nil {
+		data = []T{}
+	}
+
+	return data, services.MetaData{
+		Page:            page,
+		Limit:           limit,
+		ItemCount:       len(items),
+		PageCount:       pageCount,
+		HasPreviousPage: page > 1,
+		HasNextPage:     page < pageCount,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the shape the API does
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"statusCode": status,
+		"message":    message,
+		"error":      http.StatusText(status),
+	})
+}
+
+// readJSON decodes the request body, writing a 400 Bad Request if it's invalid
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+
+	return true
+}
+
+// newID returns a random UUID, like the API's IDs
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	id := hex.EncodeToString(b[:])
+	return id[0:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:]
+}
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/contributors"
+	"github.com/open-sauced/pizza-cli/v2/api/services/issues"
+	"github.com/open-sauced/pizza-cli/v2/api/services/pulls"
+)
+
+func logins(contribs []contributors.DbContributor) []string {
+	result := []string{}
+	for _, contrib := range contribs {
+		result = append(result, contrib.AuthorLogin)
+	}
+
+	return result
+}
+
+func TestRepository(t *testing.T) {
+	t.Parallel()
+	client := NewTestClient(t, SampleDataset(), "")
+	ctx := context.Background()
+
+	repo, _, err := client.RepositoryService.FindOneByOwnerAndRepo(ctx, "open-sauced", "Pizza-CLI")
+	require.NoError(t, err)
+	assert.Equal(t, "open-sauced/pizza-cli", repo.FullName)
+	assert.Equal(t, "https://github.com/open-sauced/pizza-cli", repo.SvnURL)
+
+	_, _, err = client.RepositoryService.FindOneByOwnerAndRepo(ctx, "open-sauced", "missing")
+	require.ErrorIs(t, err, api.ErrNotFound)
+
+	// Paginated with one contributor per page
+	var got []string
+	err = services.ForEach(ctx, services.PageOptions{Limit: 1}, func(ctx context.Context, page, limit int) ([]string, services.MetaData, error) {
+		resp, _, err := client.RepositoryService.FindContributorsByOwnerAndRepo(ctx, "open-sauced", "pizza-cli", 30, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		var pageLogins []string
+		for _, info := range resp.Data {
+			pageLogins = append(pageLogins, info.Login)
+			if info.Login == "jpmcb" {
+				assert.Equal(t, 9, info.Commits)
+				assert.Equal(t, 2, info.PRsCreated)
+				assert.Equal(t, "Seattle", info.Location)
+			}
+		}
+
+		return pageLogins, resp.Meta, nil
+	}, func(login string) error {
+		got = append(got, login)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jpmcb", "zeucapua", "nickytonline", "brandonroberts"}, got)
+}
+
+func TestContributorInsights(t *testing.T) {
+	t.Parallel()
+	client := NewTestClient(t, SampleDataset(), "")
+	ctx := context.Background()
+	repos := []string{"open-sauced/pizza-cli"}
+
+	newContribs, _, err := client.ContributorService.NewPullRequestContributors(ctx, repos, 30, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zeucapua", "brandonroberts"}, logins(newContribs.Data))
+
+	recent, _, err := client.ContributorService.RecentPullRequestContributors(ctx, repos, 30, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zeucapua", "jpmcb", "nickytonline", "brandonroberts"}, logins(recent.Data))
+
+	alumni, _, err := client.ContributorService.AlumniPullRequestContributors(ctx, repos, 30, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bdougie"}, logins(alumni.Data))
+
+	repeat, _, err := client.ContributorService.RepeatPullRequestContributors(ctx, repos, 30, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jpmcb", "nickytonline"}, logins(repeat.Data))
+
+	// A longer range reaches further back
+	recent, _, err = client.ContributorService.RecentPullRequestContributors(ctx, repos, 90, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zeucapua", "jpmcb", "nickytonline", "brandonroberts", "bdougie"}, logins(recent.Data))
+
+	search, _, err := client.ContributorService.SearchPullRequestContributors(ctx, []string{"open-sauced/pizza-cli", "open-sauced/app"}, 7, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zeucapua", "jpmcb"}, logins(search.Data))
+	assert.Equal(t, services.MetaData{Page: 1, Limit: 2, ItemCount: 5, PageCount: 3, HasNextPage: true}, search.Meta)
+}
+
+func TestPullRequestHistogram(t *testing.T) {
+	t.Parallel()
+	client := NewTestClient(t, SampleDataset(), "")
+
+	data, _, err := client.HistogramService.PrsHistogram(context.Background(), "open-sauced/pizza-cli", 30)
+	require.NoError(t, err)
+	require.Len(t, data, 6)
+	assert.Equal(t, time.Date(2024, time.August, 31, 0, 0, 0, 0, time.UTC), data[0].Bucket)
+
+	var all, accepted, spam int
+	for _, bucket := range data {
+		all += bucket.PrCount
+		accepted += bucket.AcceptedPrs
+		spam += bucket.SpamPrs
+	}
+	assert.Equal(t, 6, all)
+	assert.Equal(t, 3, accepted)
+	assert.Equal(t, 1, spam)
+}
+
+func TestSearchPullRequests(t *testing.T) {
+	t.Parallel()
+	client := NewTestClient(t, SampleDataset(), "")
+	ctx := context.Background()
+
+	resp, _, err := client.PullsService.SearchPullRequests(ctx, pulls.SearchOptions{Repos: []string{"open-sauced/pizza-cli"}, Range: 30}, 1, 100)
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 6)
+	assert.Equal(t, "zeucapua", resp.Data[0].AuthorLogin)
+	assert.Equal(t, 9, resp.Data[0].Number)
+	assert.True(t, resp.Data[0].IsDraft)
+	assert.Equal(t, "Generate insights from CODEOWNERS", resp.Data[0].Title)
+
+	resp, _, err = client.PullsService.SearchPullRequests(ctx, pulls.SearchOptions{
+		Repos:  []string{"open-sauced/pizza-cli", "open-sauced/app"},
+		Author: "JPMCB",
+		State:  pulls.StateMerged,
+		Range:  90,
+	}, 1, 100)
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 3)
+	for _, pr := range resp.Data {
+		assert.True(t, pr.IsMerged)
+		assert.Equal(t, pulls.StateClosed, pr.State)
+		assert.Equal(t, "jpmcb", pr.AuthorLogin)
+	}
+
+	resp, _, err = client.PullsService.SearchPullRequests(ctx, pulls.SearchOptions{Repos: []string{"open-sauced/app"}, State: pulls.StateClosed, Range: 30}, 1, 100)
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "nickytonline", resp.Data[0].AuthorLogin)
+	assert.False(t, resp.Data[0].IsMerged)
+}
+
+func TestSearchIssues(t *testing.T) {
+	t.Parallel()
+	client := NewTestClient(t, SampleDataset(), "")
+	ctx := context.Background()
+
+	resp, _, err := client.IssuesService.SearchIssues(ctx, issues.SearchOptions{Repos: []string{"open-sauced/pizza-cli"}, Range: 30}, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, "bdougie", resp.Data[0].AuthorLogin)
+	assert.Equal(t, 13, resp.Data[0].Number)
+	assert.Equal(t, services.MetaData{Page: 1, Limit: 2, ItemCount: 3, PageCount: 2, HasNextPage: true}, resp.Meta)
+
+	resp, _, err = client.IssuesService.SearchIssues(ctx, issues.SearchOptions{Repos: []string{"open-sauced/pizza-cli"}, State: issues.StateClosed, Range: 90}, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	assert.NotNil(t, resp.Data[0].ClosedAt)
+	assert.Equal(t, "Output insights as CSV", resp.Data[0].Title)
+}
+
+func TestUsers(t *testing.T) {
+	t.Parallel()
+	client := NewTestClient(t, SampleDataset(), "")
+	ctx := context.Background()
+
+	user, _, err := client.UsersService.FindOneByUsername(ctx, "JPMCB")
+	require.NoError(t, err)
+	assert.Equal(t, "jpmcb", user.Login)
+	assert.Equal(t, "John McBride", user.Name)
+
+	_, _, err = client.UsersService.FindOneByUsername(ctx, "missing")
+	require.ErrorIs(t, err, api.ErrNotFound)
+
+	highlights, _, err := client.UsersService.FindHighlightsByUsername(ctx, "jpmcb", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, highlights.Data, 1)
+	assert.Equal(t, "Pizza CLI v2", highlights.Data[0].Title)
+
+	devstats, _, err := client.UsersService.FindDevstatsByUsername(ctx, "jpmcb", 30)
+	require.NoError(t, err)
+	assert.Equal(t, 2, devstats.PRsCreated)
+	assert.Equal(t, 9, devstats.Commits)
+	assert.Equal(t, 2, devstats.PRsReviewed)
+	assert.Equal(t, 1, devstats.IssuesCreated)
+	assert.Equal(t, []string{"open-sauced/pizza-cli"}, devstats.Repos)
+	assert.Equal(t, time.Date(2024, time.August, 30, 12, 0, 0, 0, time.UTC), devstats.LastContributed)
+
+	devstats, _, err = client.UsersService.FindDevstatsByUsername(ctx, "nickytonline", 30)
+	require.NoError(t, err)
+	assert.Equal(t, 3, devstats.PRsReviewed)
+	assert.Equal(t, []string{"open-sauced/app", "open-sauced/pizza-cli"}, devstats.Repos)
+}
+
+func TestWorkspaces(t *testing.T) {
+	t.Parallel()
+	client := NewTestClient(t, SampleDataset(), "token")
+	ctx := context.Background()
+
+	resp, _, err := client.WorkspacesService.GetWorkspaces(ctx, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "OpenSauced", resp.Data[0].Name)
+	assert.Len(t, resp.Data[0].Members, 2)
+
+	created, _, err := client.WorkspacesService.CreateWorkspaceForUser(ctx, "Pizza CLI", "A workspace for the Pizza CLI", []string{"open-sauced/pizza-cli"})
+	require.NoError(t, err)
+	assert.Equal(t, "Pizza CLI", created.Name)
+
+	resp, _, err = client.WorkspacesService.GetWorkspaces(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.Len(t, resp.Data, 2)
+
+	lists := client.WorkspacesService.UserListService
+	createdList, _, err := lists.CreateUserListForUser(ctx, created.ID, "pizza-cli", []string{"jpmcb"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, createdList.WorkspaceID)
+
+	patched, _, err := lists.PatchUserListForUser(ctx, created.ID, createdList.UserListID, "codeowners", []string{"jpmcb", "zeucapua"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "codeowners", patched.Name)
+	assert.True(t, patched.IsPublic)
+
+	list, _, err := lists.GetUserList(ctx, created.ID, createdList.UserListID)
+	require.NoError(t, err)
+	require.Len(t, list.Contributors, 2)
+	assert.Equal(t, "zeucapua", list.Contributors[1].Username)
+	assert.Equal(t, 48687266, list.Contributors[1].UserID)
+
+	_, _, err = lists.GetUserList(ctx, created.ID, "missing")
+	require.ErrorIs(t, err, api.ErrNotFound)
+
+	_, err = lists.DeleteUserList(ctx, created.ID, createdList.UserListID)
+	require.NoError(t, err)
+
+	_, _, err = lists.GetUserList(ctx, created.ID, createdList.UserListID)
+	require.ErrorIs(t, err, api.ErrNotFound)
+}
+
+func TestWorkspacesUnauthorized(t *testing.T) {
+	t.Parallel()
+	client := NewTestClient(t, SampleDataset(), "")
+
+	_, _, err := client.WorkspacesService.GetWorkspaces(context.Background(), 1, 10)
+	require.ErrorIs(t, err, api.ErrUnauthorized)
+}
+
+func TestWorkspaceUpdateDelete(t *testing.T) {
+	t.Parallel()
+	s := New(&Dataset{Workspaces: []Workspace{{ID: "w1", Name: "Before"}}})
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	send := func(method, path, body string) int {
+		req, err := http.NewRequestWithContext(context.Background(), method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, send("PATCH", "/v2/workspaces/w1", `{"name":"After"}`))
+	assert.Equal(t, "After", s.workspaces[0].workspace.Name)
+	assert.Equal(t, http.StatusBadRequest, send("PATCH", "/v2/workspaces/w1", `{"name":""}`))
+	assert.Equal(t, http.StatusCreated, send("POST", "/v2/workspaces/w1/repos", `{"repos":[{"full_name":"open-sauced/app"}]}`))
+	assert.Equal(t, []string{"open-sauced/app"}, s.workspaces[0].repositories)
+	assert.Equal(t, http.StatusOK, send("DELETE", "/v2/workspaces/w1/repos", `{"repos":[{"full_name":"Open-Sauced/App"}]}`))
+	assert.Empty(t, s.workspaces[0].repositories)
+	assert.Equal(t, http.StatusNotFound, send("POST", "/v2/workspaces/w1/members", `{"members":[{"id":42,"role":"editor"}]}`))
+	assert.Equal(t, http.StatusBadRequest, send("POST", "/v2/workspaces/w1/members", `{"members":[{"id":1,"role":"admin"}]}`))
+	assert.Equal(t, http.StatusOK, send("DELETE", "/v2/workspaces/w1", ""))
+	assert.Equal(t, http.StatusNotFound, send("GET", "/v2/workspaces/w1", ""))
+	assert.Equal(t, http.StatusNotFound, send("GET", "/v2/unknown", ""))
+}
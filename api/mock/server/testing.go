@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+)
+
+// NewTestClient returns an API client for a mock server serving the dataset,
+// authenticated unless no token is given. Insights are computed at a fixed
+// time so they don't change as the dataset ages, and the server is closed
+// when the test ends.
+func NewTestClient(t testing.TB, dataset *Dataset, token string) *api.Client {
+	t.Helper()
+
+	s := New(dataset)
+	now := time.Date(2024, time.September, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	return api.NewClient(server.URL, api.WithTokenSource(api.StaticToken(token)), api.WithMaxRetries(0), api.WithRateLimit(1000, 0))
+}
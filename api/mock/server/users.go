@@ -0,0 +1,142 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services/users"
+)
+
+// findUser returns the user with the path's "username", writing a 404 Not
+// Found if there's none
+func (s *Server) findUser(w http.ResponseWriter, r *http.Request) (User, bool) {
+	user, ok := s.users[strings.ToLower(r.PathValue("username"))]
+	if !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+	}
+
+	return user, ok
+}
+
+func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.findUser(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, users.DbUser{
+		ID:        user.ID,
+		Login:     user.Login,
+		Name:      user.Name,
+		AvatarURL: user.AvatarURL,
+		Bio:       user.Bio,
+		Company:   user.Company,
+		Location:  user.Location,
+		OSCR:      user.OSCR,
+	})
+}
+
+func (s *Server) handleGetUserHighlights(w http.ResponseWriter, r *http.Request) {
+	page, limit, ok := pageQuery(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "page and limit must be positive integers")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.findUser(w, r)
+	if !ok {
+		return
+	}
+
+	highlights := make([]users.DbUserHighlight, 0, len(user.Highlights))
+	for i, highlight := range user.Highlights {
+		highlights = append(highlights, users.DbUserHighlight{
+			ID:          i + 1,
+			UserID:      user.ID,
+			URL:         highlight.URL,
+			Title:       highlight.Title,
+			Highlight:   highlight.Highlight,
+			Type:        "pull_request",
+			TaggedRepos: []string{},
+		})
+	}
+
+	data, meta := paginate(highlights, page, limit)
+	writeJSON(w, http.StatusOK, users.HighlightsResponse{Data: data, Meta: meta})
+}
+
+// handleGetUserDevstats computes the user's contribution stats from the pull
+// requests and issues opened within the range
+func (s *Server) handleGetUserDevstats(w http.ResponseWriter, r *http.Request) {
+	rangeVal, ok := rangeQuery(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "range must be a positive integer")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.findUser(w, r)
+	if !ok {
+		return
+	}
+
+	now := s.now()
+	start := now.AddDate(0, 0, -rangeVal)
+	devstats := users.DbUserDevstats{
+		ID:                user.ID,
+		Login:             user.Login,
+		OSCR:              user.OSCR,
+		Repos:             []string{},
+		DevstatsUpdatedAt: now,
+	}
+
+	contributed := func(repo string, at time.Time) {
+		repo = s.repoName(repo)
+		if !slices.Contains(devstats.Repos, repo) {
+			devstats.Repos = append(devstats.Repos, repo)
+		}
+		if at.After(devstats.LastContributed) {
+			devstats.LastContributed = at
+		}
+	}
+
+	for _, pr := range s.pullRequests {
+		createdAt := pr.createdAt(now)
+		if !createdAt.After(start) {
+			continue
+		}
+
+		if strings.EqualFold(pr.Author, user.Login) {
+			devstats.PRsCreated++
+			devstats.Commits += pr.Commits
+			contributed(pr.Repo, createdAt)
+		}
+		if slices.ContainsFunc(pr.Reviewers, func(login string) bool { return strings.EqualFold(login, user.Login) }) {
+			devstats.PRsReviewed++
+			contributed(pr.Repo, createdAt)
+		}
+	}
+
+	for _, issue := range s.issues {
+		createdAt := issue.createdAt(now)
+		if createdAt.After(start) && strings.EqualFold(issue.Author, user.Login) {
+			devstats.IssuesCreated++
+			contributed(issue.Repo, createdAt)
+		}
+	}
+
+	slices.Sort(devstats.Repos)
+	devstats.TotalContributions = devstats.Commits + devstats.PRsCreated + devstats.PRsReviewed + devstats.IssuesCreated
+
+	writeJSON(w, http.StatusOK, devstats)
+}
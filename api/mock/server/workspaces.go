@@ -0,0 +1,515 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services/repository"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces/userlists"
+)
+
+const (
+	roleOwner  = "owner"
+	roleEditor = "editor"
+	roleViewer = "viewer"
+)
+
+// workspaceState is a workspace, along with what it holds
+type workspaceState struct {
+	workspace    workspaces.DbWorkspace
+	repositories []string
+	userLists    []*userlists.DbUserList
+}
+
+// updateWorkspaceRequest is the body of "PATCH v2/workspaces/:id", only
+// updating the given fields
+type updateWorkspaceRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	IsPublic    *bool   `json:"is_public"`
+}
+
+func (s *Server) newWorkspaceState(workspace Workspace) *workspaceState {
+	now := s.now()
+	if workspace.ID == "" {
+		workspace.ID = newID()
+	}
+
+	state := &workspaceState{
+		workspace: workspaces.DbWorkspace{
+			ID:          workspace.ID,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			Name:        workspace.Name,
+			Description: workspace.Description,
+			IsPublic:    workspace.IsPublic,
+		},
+		repositories: workspace.Repositories,
+	}
+
+	s.addMember(state, s.user.Login, roleOwner)
+	for _, login := range workspace.Members {
+		s.addMember(state, login, roleEditor)
+	}
+
+	for _, list := range workspace.UserLists {
+		if list.ID == "" {
+			list.ID = newID()
+		}
+
+		userList := &userlists.DbUserList{
+			ID:        list.ID,
+			UserID:    s.user.ID,
+			Name:      list.Name,
+			IsPublic:  list.IsPublic,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		s.setContributors(userList, list.Contributors)
+		state.userLists = append(state.userLists, userList)
+	}
+
+	return state
+}
+
+// addMember adds the user with the given login to the workspace, unless
+// they're a member already
+func (s *Server) addMember(state *workspaceState, login, role string) {
+	user := s.lookupUser(login)
+	for _, member := range state.workspace.Members {
+		if member.UserID == user.ID {
+			return
+		}
+	}
+
+	now := s.now()
+	state.workspace.Members = append(state.workspace.Members, workspaces.DbWorkspaceMember{
+		ID:          newID(),
+		UserID:      user.ID,
+		WorkspaceID: state.workspace.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Role:        role,
+	})
+}
+
+// setContributors replaces the contributors of the list
+func (s *Server) setContributors(list *userlists.DbUserList, logins []string) {
+	list.Contributors = []userlists.DbUserListContributor{}
+	for _, login := range logins {
+		user := s.lookupUser(login)
+		list.Contributors = append(list.Contributors, userlists.DbUserListContributor{
+			ID:        newID(),
+			UserID:    user.ID,
+			ListID:    list.ID,
+			Username:  user.Login,
+			CreatedAt: s.now(),
+		})
+	}
+}
+
+// findWorkspace returns the workspace with the path's "id", writing a 404 Not
+// Found if there's none
+func (s *Server) findWorkspace(w http.ResponseWriter, r *http.Request) (*workspaceState, bool) {
+	for _, state := range s.workspaces {
+		if state.workspace.ID == r.PathValue("id") {
+			return state, true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Workspace not found")
+	return nil, false
+}
+
+// findUserList returns the workspace's list with the path's "listID", writing
+// a 404 Not Found if there's none
+func findUserList(w http.ResponseWriter, r *http.Request, state *workspaceState) (*userlists.DbUserList, bool) {
+	for _, list := range state.userLists {
+		if list.ID == r.PathValue("listID") {
+			return list, true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "User list not found")
+	return nil, false
+}
+
+func (s *Server) handleGetWorkspaces(w http.ResponseWriter, r *http.Request) {
+	page, limit, ok := pageQuery(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "page and limit must be positive integers")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := make([]workspaces.DbWorkspace, 0, len(s.workspaces))
+	for _, state := range s.workspaces {
+		all = append(all, state.workspace)
+	}
+
+	data, meta := paginate(all, page, limit)
+	writeJSON(w, http.StatusOK, workspaces.DbWorkspacesResponse{Data: data, Meta: meta})
+}
+
+func (s *Server) handleCreateWorkspace(w http.ResponseWriter, r *http.Request) {
+	var req workspaces.CreateWorkspaceRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		writeError(w, http.StatusBadRequest, "name should not be empty")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workspace := Workspace{
+		Name:        req.Name,
+		Description: req.Description,
+		Members:     req.Members,
+	}
+	for _, repo := range req.Repos {
+		workspace.Repositories = append(workspace.Repositories, repo.FullName)
+	}
+
+	state := s.newWorkspaceState(workspace)
+	s.workspaces = append(s.workspaces, state)
+
+	writeJSON(w, http.StatusCreated, state.workspace)
+}
+
+func (s *Server) handleGetWorkspace(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, state.workspace)
+}
+
+func (s *Server) handleUpdateWorkspace(w http.ResponseWriter, r *http.Request) {
+	var req updateWorkspaceRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		writeError(w, http.StatusBadRequest, "name should not be empty")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	if req.Name != nil {
+		state.workspace.Name = *req.Name
+	}
+	if req.Description != nil {
+		state.workspace.Description = *req.Description
+	}
+	if req.IsPublic != nil {
+		state.workspace.IsPublic = *req.IsPublic
+	}
+	state.workspace.UpdatedAt = s.now()
+
+	writeJSON(w, http.StatusOK, state.workspace)
+}
+
+func (s *Server) handleDeleteWorkspace(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	s.workspaces = slices.DeleteFunc(s.workspaces, func(other *workspaceState) bool { return other == state })
+
+	now := s.now()
+	state.workspace.DeletedAt = &now
+	writeJSON(w, http.StatusOK, state.workspace)
+}
+
+func (s *Server) handleGetUserLists(w http.ResponseWriter, r *http.Request) {
+	page, limit, ok := pageQuery(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "page and limit must be positive integers")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	all := make([]userlists.DbUserList, 0, len(state.userLists))
+	for _, list := range state.userLists {
+		all = append(all, *list)
+	}
+
+	data, meta := paginate(all, page, limit)
+	writeJSON(w, http.StatusOK, userlists.GetUserListsResponse{Data: data, Meta: meta})
+}
+
+func (s *Server) handleCreateUserList(w http.ResponseWriter, r *http.Request) {
+	var req userlists.CreatePatchUserListRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		writeError(w, http.StatusBadRequest, "name should not be empty")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	now := s.now()
+	list := &userlists.DbUserList{
+		ID:        newID(),
+		UserID:    s.user.ID,
+		Name:      req.Name,
+		IsPublic:  req.IsPublic,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.setContributors(list, contributorLogins(req.Contributors))
+	state.userLists = append(state.userLists, list)
+
+	writeJSON(w, http.StatusCreated, userlists.CreateUserListResponse{
+		ID:          newID(),
+		UserListID:  list.ID,
+		WorkspaceID: state.workspace.ID,
+	})
+}
+
+func (s *Server) handleGetUserList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	list, ok := findUserList(w, r, state)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleUpdateUserList(w http.ResponseWriter, r *http.Request) {
+	var req userlists.CreatePatchUserListRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		writeError(w, http.StatusBadRequest, "name should not be empty")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	list, ok := findUserList(w, r, state)
+	if !ok {
+		return
+	}
+
+	list.Name = req.Name
+	list.IsPublic = req.IsPublic
+	list.UpdatedAt = s.now()
+	s.setContributors(list, contributorLogins(req.Contributors))
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleDeleteUserList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	list, ok := findUserList(w, r, state)
+	if !ok {
+		return
+	}
+
+	state.userLists = slices.DeleteFunc(state.userLists, func(other *userlists.DbUserList) bool { return other == list })
+
+	now := s.now()
+	list.DeletedAt = &now
+	writeJSON(w, http.StatusOK, list)
+}
+
+func contributorLogins(contributors []userlists.CreateUserListRequestContributor) []string {
+	logins := make([]string, 0, len(contributors))
+	for _, contributor := range contributors {
+		logins = append(logins, contributor.Login)
+	}
+
+	return logins
+}
+
+func (s *Server) handleGetWorkspaceRepos(w http.ResponseWriter, r *http.Request) {
+	page, limit, ok := pageQuery(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "page and limit must be positive integers")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	repos := make([]workspaces.DbWorkspaceRepo, 0, len(state.repositories))
+	for _, fullName := range state.repositories {
+		repo, ok := s.findRepository(fullName)
+		if !ok {
+			repo = repository.DbRepository{FullName: fullName}
+		}
+
+		repos = append(repos, workspaces.DbWorkspaceRepo{
+			ID:          newID(),
+			WorkspaceID: state.workspace.ID,
+			RepoID:      repo.ID,
+			CreatedAt:   state.workspace.CreatedAt,
+			UpdatedAt:   state.workspace.UpdatedAt,
+			Repo:        repo,
+		})
+	}
+
+	data, meta := paginate(repos, page, limit)
+	writeJSON(w, http.StatusOK, workspaces.DbWorkspaceReposResponse{Data: data, Meta: meta})
+}
+
+func (s *Server) handleAddWorkspaceRepos(w http.ResponseWriter, r *http.Request) {
+	var req workspaces.WorkspaceReposRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	for _, repo := range req.Repos {
+		if !slices.ContainsFunc(state.repositories, func(fullName string) bool { return strings.EqualFold(fullName, repo.FullName) }) {
+			state.repositories = append(state.repositories, repo.FullName)
+		}
+	}
+	state.workspace.UpdatedAt = s.now()
+
+	writeJSON(w, http.StatusCreated, state.workspace)
+}
+
+func (s *Server) handleRemoveWorkspaceRepos(w http.ResponseWriter, r *http.Request) {
+	var req workspaces.WorkspaceReposRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	for _, repo := range req.Repos {
+		state.repositories = slices.DeleteFunc(state.repositories, func(fullName string) bool { return strings.EqualFold(fullName, repo.FullName) })
+	}
+	state.workspace.UpdatedAt = s.now()
+
+	writeJSON(w, http.StatusOK, state.workspace)
+}
+
+func (s *Server) handleAddWorkspaceMembers(w http.ResponseWriter, r *http.Request) {
+	var req workspaces.WorkspaceMembersRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	for _, member := range req.Members {
+		switch member.Role {
+		case roleOwner, roleEditor, roleViewer:
+		default:
+			writeError(w, http.StatusBadRequest, "role must be one of: owner, editor, viewer")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.findWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	logins := make([]string, 0, len(req.Members))
+	for _, member := range req.Members {
+		user, ok := s.userByID(member.ID)
+		if !ok {
+			writeError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		logins = append(logins, user.Login)
+	}
+
+	added := []workspaces.DbWorkspaceMember{}
+	for i, login := range logins {
+		s.addMember(state, login, req.Members[i].Role)
+		for _, member := range state.workspace.Members {
+			if member.UserID == req.Members[i].ID {
+				added = append(added, member)
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, added)
+}
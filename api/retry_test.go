@@ -0,0 +1,184 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRetryTestClient returns an http client retrying with short backoffs
+func newRetryTestClient() *http.Client {
+	return &http.Client{
+		Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			maxRetries: DefaultMaxRetries,
+			minBackoff: time.Millisecond,
+			maxBackoff: 10 * time.Millisecond,
+		},
+	}
+}
+
+// newFlakyServer returns a server responding with the given statuses in order,
+// then with 200 OK, echoing the request body
+func newFlakyServer(t *testing.T, requests *atomic.Int32, statuses ...int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+
+		_, _ = io.Copy(w, r.Body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	server := newFlakyServer(t, &requests, http.StatusBadGateway, http.StatusTooManyRequests, http.StatusGatewayTimeout)
+
+	req, err := http.NewRequestWithContext(context.Background(), "PUT", server.URL, bytes.NewBufferString("pizza"))
+	require.NoError(t, err)
+
+	resp, err := newRetryTestClient().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "pizza", string(body), "the body is sent again on every retry")
+	assert.Equal(t, int32(4), requests.Load())
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	server := newFlakyServer(t, &requests, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	resp, err := newRetryTestClient().Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(DefaultMaxRetries+1), requests.Load())
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		status   int
+		requests int32
+	}{
+		{name: "bad gateway is not retried", status: http.StatusBadGateway, requests: 1},
+		{name: "rate limit is retried", status: http.StatusTooManyRequests, requests: 2},
+		{name: "not found is not retried", status: http.StatusNotFound, requests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var requests atomic.Int32
+			server := newFlakyServer(t, &requests, tt.status)
+
+			resp, err := newRetryTestClient().Post(server.URL, "application/json", bytes.NewBufferString("{}"))
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.requests, requests.Load())
+		})
+	}
+}
+
+func TestRetryAfterPastDeadline(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := newRetryTestClient().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// Waiting two minutes would pass the deadline, so the response is returned as is
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryCanceled(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = newRetryTestClient().Do(req)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	d, ok := parseRetryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.InDelta(t, time.Minute, d, float64(2*time.Second))
+
+	d, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Zero(t, d)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+	transport := &retryTransport{minBackoff: 100 * time.Millisecond, maxBackoff: time.Second}
+
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		d := transport.backoff(attempt)
+		assert.GreaterOrEqual(t, d, want/2)
+		assert.LessOrEqual(t, d, want)
+	}
+
+	// Shifting past the duration's bits doesn't overflow into a negative backoff
+	d := transport.backoff(64)
+	assert.GreaterOrEqual(t, d, time.Second/2)
+	assert.LessOrEqual(t, d, time.Second)
+}
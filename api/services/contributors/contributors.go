@@ -1,12 +1,15 @@
 package contributors
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
 )
 
 // Service is the Contributors service used for accessing the "v2/contributors"
@@ -25,7 +28,7 @@ func NewContributorsService(httpClient *http.Client, endpoint string) *Service {
 }
 
 // NewPullRequestContributors calls the "v2/contributors/insights/new" API endpoint
-func (s *Service) NewPullRequestContributors(repos []string, rangeVal int) (*ContribResponse, *http.Response, error) {
+func (s *Service) NewPullRequestContributors(ctx context.Context, repos []string, rangeVal int, page, limit int) (*ContribResponse, *http.Response, error) {
 	baseURL := s.endpoint + "/v2/contributors/insights/new"
 
 	// Create URL with query parameters
@@ -37,16 +40,23 @@ func (s *Service) NewPullRequestContributors(repos []string, rangeVal int) (*Con
 	q := u.Query()
 	q.Set("range", strconv.Itoa(rangeVal))
 	q.Set("repos", strings.Join(repos, ","))
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
 	u.RawQuery = q.Encode()
 
-	resp, err := s.httpClient.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, resp, fmt.Errorf("error making request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var newContributorsResponse ContribResponse
@@ -58,7 +68,7 @@ func (s *Service) NewPullRequestContributors(repos []string, rangeVal int) (*Con
 }
 
 // RecentPullRequestContributors calls the "v2/contributors/insights/recent" API endpoint
-func (s *Service) RecentPullRequestContributors(repos []string, rangeVal int) (*ContribResponse, *http.Response, error) {
+func (s *Service) RecentPullRequestContributors(ctx context.Context, repos []string, rangeVal int, page, limit int) (*ContribResponse, *http.Response, error) {
 	baseURL := s.endpoint + "/v2/contributors/insights/recent"
 
 	// Create URL with query parameters
@@ -70,16 +80,23 @@ func (s *Service) RecentPullRequestContributors(repos []string, rangeVal int) (*
 	q := u.Query()
 	q.Set("range", strconv.Itoa(rangeVal))
 	q.Set("repos", strings.Join(repos, ","))
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
 	u.RawQuery = q.Encode()
 
-	resp, err := s.httpClient.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, resp, fmt.Errorf("error making request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var recentContributorsResponse ContribResponse
@@ -91,7 +108,7 @@ func (s *Service) RecentPullRequestContributors(repos []string, rangeVal int) (*
 }
 
 // AlumniPullRequestContributors calls the "v2/contributors/insights/alumni" API endpoint
-func (s *Service) AlumniPullRequestContributors(repos []string, rangeVal int) (*ContribResponse, *http.Response, error) {
+func (s *Service) AlumniPullRequestContributors(ctx context.Context, repos []string, rangeVal int, page, limit int) (*ContribResponse, *http.Response, error) {
 	baseURL := s.endpoint + "/v2/contributors/insights/alumni"
 
 	// Create URL with query parameters
@@ -103,16 +120,23 @@ func (s *Service) AlumniPullRequestContributors(repos []string, rangeVal int) (*
 	q := u.Query()
 	q.Set("range", strconv.Itoa(rangeVal))
 	q.Set("repos", strings.Join(repos, ","))
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
 	u.RawQuery = q.Encode()
 
-	resp, err := s.httpClient.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, resp, fmt.Errorf("error making request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var alumniContributorsResponse ContribResponse
@@ -124,7 +148,7 @@ func (s *Service) AlumniPullRequestContributors(repos []string, rangeVal int) (*
 }
 
 // RepeatPullRequestContributors calls the "v2/contributors/insights/repeat" API endpoint
-func (s *Service) RepeatPullRequestContributors(repos []string, rangeVal int) (*ContribResponse, *http.Response, error) {
+func (s *Service) RepeatPullRequestContributors(ctx context.Context, repos []string, rangeVal int, page, limit int) (*ContribResponse, *http.Response, error) {
 	baseURL := s.endpoint + "/v2/contributors/insights/repeat"
 
 	// Create URL with query parameters
@@ -136,16 +160,23 @@ func (s *Service) RepeatPullRequestContributors(repos []string, rangeVal int) (*
 	q := u.Query()
 	q.Set("range", strconv.Itoa(rangeVal))
 	q.Set("repos", strings.Join(repos, ","))
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
 	u.RawQuery = q.Encode()
 
-	resp, err := s.httpClient.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, resp, fmt.Errorf("error making request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var repeatContributorsResponse ContribResponse
@@ -157,7 +188,7 @@ func (s *Service) RepeatPullRequestContributors(repos []string, rangeVal int) (*
 }
 
 // SearchPullRequestContributors calls the "v2/contributors/search"
-func (s *Service) SearchPullRequestContributors(repos []string, rangeVal int) (*ContribResponse, *http.Response, error) {
+func (s *Service) SearchPullRequestContributors(ctx context.Context, repos []string, rangeVal int, page, limit int) (*ContribResponse, *http.Response, error) {
 	baseURL := s.endpoint + "/v2/contributors/search"
 
 	// Create URL with query parameters
@@ -169,16 +200,23 @@ func (s *Service) SearchPullRequestContributors(repos []string, rangeVal int) (*
 	q := u.Query()
 	q.Set("range", strconv.Itoa(rangeVal))
 	q.Set("repos", strings.Join(repos, ","))
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
 	u.RawQuery = q.Encode()
 
-	resp, err := s.httpClient.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, resp, fmt.Errorf("error making request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var searchContributorsResponse ContribResponse
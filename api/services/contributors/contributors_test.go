@@ -2,6 +2,7 @@ package contributors
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -18,7 +19,7 @@ func TestNewPullrequestContributors(t *testing.T) {
 	t.Parallel()
 	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
 		// Check if the URL is correct
-		assert.Equal(t, "https://api.example.com/v2/contributors/insights/new?range=30&repos=testowner%2Ftestrepo", req.URL.String())
+		assert.Equal(t, "https://api.example.com/v2/contributors/insights/new?limit=30&page=1&range=30&repos=testowner%2Ftestrepo", req.URL.String())
 
 		mockResponse := ContribResponse{
 			Data: []DbContributor{
@@ -52,7 +53,7 @@ func TestNewPullrequestContributors(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewContributorsService(client, "https://api.example.com")
 
-	newContribs, resp, err := service.NewPullRequestContributors([]string{"testowner/testrepo"}, 30)
+	newContribs, resp, err := service.NewPullRequestContributors(context.Background(), []string{"testowner/testrepo"}, 30, 1, 30)
 
 	require.NoError(t, err)
 	assert.NotNil(t, newContribs)
@@ -74,7 +75,7 @@ func TestRecentPullRequestContributors(t *testing.T) {
 	t.Parallel()
 	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
 		// Check if the URL is correct
-		assert.Equal(t, "https://api.example.com/v2/contributors/insights/recent?range=30&repos=testowner%2Ftestrepo", req.URL.String())
+		assert.Equal(t, "https://api.example.com/v2/contributors/insights/recent?limit=30&page=1&range=30&repos=testowner%2Ftestrepo", req.URL.String())
 
 		mockResponse := ContribResponse{
 			Data: []DbContributor{
@@ -108,7 +109,7 @@ func TestRecentPullRequestContributors(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewContributorsService(client, "https://api.example.com")
 
-	recentContribs, resp, err := service.RecentPullRequestContributors([]string{"testowner/testrepo"}, 30)
+	recentContribs, resp, err := service.RecentPullRequestContributors(context.Background(), []string{"testowner/testrepo"}, 30, 1, 30)
 
 	require.NoError(t, err)
 	assert.NotNil(t, recentContribs)
@@ -130,7 +131,7 @@ func TestAlumniPullRequestContributors(t *testing.T) {
 	t.Parallel()
 	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
 		// Check if the URL is correct
-		assert.Equal(t, "https://api.example.com/v2/contributors/insights/alumni?range=30&repos=testowner%2Ftestrepo", req.URL.String())
+		assert.Equal(t, "https://api.example.com/v2/contributors/insights/alumni?limit=30&page=1&range=30&repos=testowner%2Ftestrepo", req.URL.String())
 
 		mockResponse := ContribResponse{
 			Data: []DbContributor{
@@ -164,7 +165,7 @@ func TestAlumniPullRequestContributors(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewContributorsService(client, "https://api.example.com")
 
-	alumniContribs, resp, err := service.AlumniPullRequestContributors([]string{"testowner/testrepo"}, 30)
+	alumniContribs, resp, err := service.AlumniPullRequestContributors(context.Background(), []string{"testowner/testrepo"}, 30, 1, 30)
 
 	require.NoError(t, err)
 	assert.NotNil(t, alumniContribs)
@@ -186,7 +187,7 @@ func TestRepeatPullRequestContributors(t *testing.T) {
 	t.Parallel()
 	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
 		// Check if the URL is correct
-		assert.Equal(t, "https://api.example.com/v2/contributors/insights/repeat?range=30&repos=testowner%2Ftestrepo", req.URL.String())
+		assert.Equal(t, "https://api.example.com/v2/contributors/insights/repeat?limit=30&page=1&range=30&repos=testowner%2Ftestrepo", req.URL.String())
 
 		mockResponse := ContribResponse{
 			Data: []DbContributor{
@@ -220,7 +221,7 @@ func TestRepeatPullRequestContributors(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewContributorsService(client, "https://api.example.com")
 
-	repeatContribs, resp, err := service.RepeatPullRequestContributors([]string{"testowner/testrepo"}, 30)
+	repeatContribs, resp, err := service.RepeatPullRequestContributors(context.Background(), []string{"testowner/testrepo"}, 30, 1, 30)
 
 	require.NoError(t, err)
 	assert.NotNil(t, repeatContribs)
@@ -242,7 +243,7 @@ func TestSearchPullRequestContributors(t *testing.T) {
 	t.Parallel()
 	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
 		// Check if the URL is correct
-		assert.Equal(t, "https://api.example.com/v2/contributors/search?range=30&repos=testowner%2Ftestrepo", req.URL.String())
+		assert.Equal(t, "https://api.example.com/v2/contributors/search?limit=30&page=1&range=30&repos=testowner%2Ftestrepo", req.URL.String())
 
 		mockResponse := ContribResponse{
 			Data: []DbContributor{
@@ -276,7 +277,7 @@ func TestSearchPullRequestContributors(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewContributorsService(client, "https://api.example.com")
 
-	repeatContribs, resp, err := service.SearchPullRequestContributors([]string{"testowner/testrepo"}, 30)
+	repeatContribs, resp, err := service.SearchPullRequestContributors(context.Background(), []string{"testowner/testrepo"}, 30, 1, 30)
 
 	require.NoError(t, err)
 	assert.NotNil(t, repeatContribs)
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxErrorBodySize is how much of an error response's body is read
+const maxErrorBodySize = 64 * 1024
+
+// Sentinel errors matching an Error by its status code with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// Error is returned when the API responds with an unexpected status code
+type Error struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// Method and Endpoint are the HTTP method and URL path of the request.
+	// Example: GET /v2/repos/open-sauced/pizza-cli
+	Method   string
+	Endpoint string
+
+	// RequestID is the ID the API assigned to the request, if any. It helps
+	// the OpenSauced team find the request in their logs.
+	RequestID string
+
+	// Message is the error message from the API's response body, if any
+	Message string
+
+	// RetryAfter is the "Retry-After" header of rate limited responses
+	RetryAfter string
+}
+
+// apiErrorBody is the body of the API's error responses
+type apiErrorBody struct {
+	Message json.RawMessage `json:"message"`
+	Error   string          `json:"error"`
+}
+
+// NewError builds an Error from an API response with an unexpected status
+// code, decoding the API's error message from its body
+func NewError(resp *http.Response) *Error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		RetryAfter: resp.Header.Get("Retry-After"),
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+
+	if resp.Body == nil {
+		return apiErr
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return apiErr
+	}
+
+	var body apiErrorBody
+	if err := json.Unmarshal(data, &body); err != nil {
+		apiErr.Message = strings.TrimSpace(string(data))
+		return apiErr
+	}
+
+	// Validation errors have a list of messages
+	var message string
+	var messages []string
+	switch {
+	case json.Unmarshal(body.Message, &message) == nil:
+		apiErr.Message = message
+	case json.Unmarshal(body.Message, &messages) == nil:
+		apiErr.Message = strings.Join(messages, ", ")
+	default:
+		apiErr.Message = body.Error
+	}
+
+	return apiErr
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+
+	b.WriteString("API request")
+	if e.Endpoint != "" {
+		fmt.Fprintf(&b, " %s %s", e.Method, e.Endpoint)
+	}
+	fmt.Fprintf(&b, " failed with status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+
+	if e.Message != "" && e.Message != http.StatusText(e.StatusCode) {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+
+	switch {
+	case errors.Is(e, ErrUnauthorized):
+		b.WriteString(`: log in again with "pizza login"`)
+	case errors.Is(e, ErrRateLimited) && e.RetryAfter != "":
+		// "Retry-After" is either a number of seconds or a date
+		if _, err := strconv.Atoi(e.RetryAfter); err == nil {
+			fmt.Fprintf(&b, ": try again in %s seconds", e.RetryAfter)
+		} else {
+			fmt.Fprintf(&b, ": try again after %s", e.RetryAfter)
+		}
+	case errors.Is(e, ErrRateLimited):
+		b.WriteString(": try again later")
+	}
+
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID: %s)", e.RequestID)
+	}
+
+	return b.String()
+}
+
+// Is matches the sentinel errors by the status code
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	default:
+		return false
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newErrorResponse(status int, header http.Header, body string) *http.Response {
+	req, _ := http.NewRequest("GET", "https://api.example.com/v2/repos/testowner/testrepo?range=30", nil)
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func TestNewError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		body   string
+		want   string
+	}{
+		{
+			name:   "API error message",
+			status: http.StatusNotFound,
+			header: http.Header{"X-Request-Id": {"abc-123"}},
+			body:   `{"statusCode":404,"message":"Repository not found","error":"Not Found"}`,
+			want:   "API request GET /v2/repos/testowner/testrepo failed with status 404 Not Found: Repository not found (request ID: abc-123)",
+		},
+		{
+			name:   "validation messages",
+			status: http.StatusBadRequest,
+			body:   `{"statusCode":400,"message":["range must be 7, 30, or 90","limit must be positive"],"error":"Bad Request"}`,
+			want:   "API request GET /v2/repos/testowner/testrepo failed with status 400 Bad Request: range must be 7, 30, or 90, limit must be positive",
+		},
+		{
+			name:   "plain text body",
+			status: http.StatusBadGateway,
+			body:   "upstream connect error\n",
+			want:   "API request GET /v2/repos/testowner/testrepo failed with status 502 Bad Gateway: upstream connect error",
+		},
+		{
+			name:   "unauthorized",
+			status: http.StatusUnauthorized,
+			body:   `{"statusCode":401,"message":"Unauthorized"}`,
+			want:   `API request GET /v2/repos/testowner/testrepo failed with status 401 Unauthorized: log in again with "pizza login"`,
+		},
+		{
+			name:   "rate limited",
+			status: http.StatusTooManyRequests,
+			header: http.Header{"Retry-After": {"30"}},
+			want:   "API request GET /v2/repos/testowner/testrepo failed with status 429 Too Many Requests: try again in 30 seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := NewError(newErrorResponse(tt.status, tt.header, tt.body))
+			assert.Equal(t, tt.want, err.Error())
+		})
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("could not get repository: %w", NewError(newErrorResponse(http.StatusNotFound, nil, "")))
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrUnauthorized)
+	assert.NotErrorIs(t, err, ErrRateLimited)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "/v2/repos/testowner/testrepo", apiErr.Endpoint)
+
+	assert.ErrorIs(t, NewError(newErrorResponse(http.StatusUnauthorized, nil, "")), ErrUnauthorized)
+	assert.ErrorIs(t, NewError(newErrorResponse(http.StatusTooManyRequests, nil, "")), ErrRateLimited)
+	assert.NotErrorIs(t, NewError(newErrorResponse(http.StatusInternalServerError, nil, "")), ErrNotFound)
+}
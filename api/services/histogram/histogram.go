@@ -1,11 +1,14 @@
 package histogram
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
 )
 
 // Service is used to access the API "v2/histogram" endpoints and services
@@ -23,7 +26,7 @@ func NewHistogramService(httpClient *http.Client, endpoint string) *Service {
 }
 
 // PrsHistogram calls the "v2/histogram/pull-requests" endpoints
-func (s *Service) PrsHistogram(repo string, rangeVal int) ([]PrHistogramData, *http.Response, error) {
+func (s *Service) PrsHistogram(ctx context.Context, repo string, rangeVal int) ([]PrHistogramData, *http.Response, error) {
 	baseURL := s.endpoint + "/v2/histogram/pull-requests"
 
 	// Create URL with query parameters
@@ -37,14 +40,19 @@ func (s *Service) PrsHistogram(repo string, rangeVal int) ([]PrHistogramData, *h
 	q.Set("repo", repo)
 	u.RawQuery = q.Encode()
 
-	resp, err := s.httpClient.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, resp, fmt.Errorf("error making request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var prHistogramData []PrHistogramData
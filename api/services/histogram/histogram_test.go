@@ -2,6 +2,7 @@ package histogram
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -41,7 +42,7 @@ func TestPrsHistogram(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewHistogramService(client, "https://api.example.com")
 
-	prs, resp, err := service.PrsHistogram("testowner/testrepo", 30)
+	prs, resp, err := service.PrsHistogram(context.Background(), "testowner/testrepo", 30)
 
 	require.NoError(t, err)
 	assert.NotNil(t, prs)
@@ -0,0 +1,93 @@
+package issues
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/api/mock"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+)
+
+func TestSearchIssues(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		// Check if the URL is correct
+		assert.Equal(t, "https://api.example.com/v2/issues/search?contributor=jpmcb&limit=30&page=1&range=30&repos=testowner%2Ftestrepo&status=open", req.URL.String())
+
+		mockResponse := IssuesResponse{
+			Data: []DbIssue{
+				{
+					Number:      1,
+					Title:       "bug: issue 1",
+					State:       "open",
+					AuthorLogin: "jpmcb",
+					RepoName:    "testowner/testrepo",
+				},
+			},
+			Meta: services.MetaData{
+				Page:      1,
+				Limit:     30,
+				ItemCount: 1,
+				PageCount: 1,
+			},
+		}
+
+		// Convert the mock response to JSON
+		responseBody, _ := json.Marshal(mockResponse)
+
+		// Return the mock response
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBody)),
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewIssuesService(client, "https://api.example.com")
+
+	issues, resp, err := service.SearchIssues(context.Background(), SearchOptions{
+		Repos:  []string{"testowner/testrepo"},
+		Author: "jpmcb",
+		State:  StateOpen,
+		Range:  30,
+	}, 1, 30)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, issues.Data, 1)
+	assert.Equal(t, "bug: issue 1", issues.Data[0].Title)
+	assert.Equal(t, 1, issues.Meta.ItemCount)
+}
+
+func TestSearchIssuesAnyState(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		// Unset filters aren't sent
+		assert.Equal(t, "https://api.example.com/v2/issues/search?limit=10&page=2&range=7&repos=testowner%2Ftestrepo%2Ctestowner%2Fother", req.URL.String())
+
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"statusCode":400,"message":"range must be 7, 30, 90, 180, 360"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewIssuesService(client, "https://api.example.com")
+
+	_, _, err := service.SearchIssues(context.Background(), SearchOptions{
+		Repos: []string{"testowner/testrepo", "testowner/other"},
+		Range: 7,
+	}, 2, 10)
+
+	var apiErr *services.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
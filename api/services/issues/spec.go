@@ -0,0 +1,49 @@
+package issues
+
+import (
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+)
+
+// The states issues are searched by
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+)
+
+type DbIssue struct {
+	EventID           int        `json:"event_id"`
+	Number            int        `json:"issue_number"`
+	Title             string     `json:"issue_title"`
+	State             string     `json:"issue_state"`
+	AuthorLogin       string     `json:"issue_author_login"`
+	RepoName          string     `json:"repo_name"`
+	Comments          int        `json:"issue_comments"`
+	ReactionsPlusOne  int        `json:"issue_reactions_plus_one"`
+	ReactionsTotal    int        `json:"issue_reactions_total_count"`
+	AuthorAssociation string     `json:"issue_author_association"`
+	CreatedAt         time.Time  `json:"issue_created_at"`
+	UpdatedAt         time.Time  `json:"issue_updated_at"`
+	ClosedAt          *time.Time `json:"issue_closed_at"`
+}
+
+type IssuesResponse struct {
+	Data []DbIssue         `json:"data"`
+	Meta services.MetaData `json:"meta"`
+}
+
+// SearchOptions filter the issues searched for
+type SearchOptions struct {
+	// Repos are the full names of the repositories, like "open-sauced/pizza-cli"
+	Repos []string
+
+	// Author is the login of the issues' author, any author if empty
+	Author string
+
+	// State is either StateOpen or StateClosed, any state if empty
+	State string
+
+	// Range is the number of days the issues were active in
+	Range int
+}
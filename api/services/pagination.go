@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+)
+
+// DefaultPageLimit is the number of items requested per page when paginating
+const DefaultPageLimit = 100
+
+// ErrStopIteration can be returned by a ForEach callback to stop paginating
+// without an error
+var ErrStopIteration = errors.New("stop iteration")
+
+// PageOptions configures pagination through a list endpoint
+type PageOptions struct {
+	// Limit is the number of items requested per page. DefaultPageLimit if 0.
+	Limit int
+
+	// MaxItems caps how many items are visited across all pages. 0 means no cap.
+	MaxItems int
+}
+
+// PageFunc fetches the given page, starting at 1, of a list endpoint with
+// the given number of items per page
+type PageFunc[T any] func(ctx context.Context, page, limit int) ([]T, MetaData, error)
+
+// ForEach calls fn with every item of a list endpoint, fetching page after page
+// until the last page, MaxItems items, or fn returns an error. Pagination stops
+// when the context is canceled.
+func ForEach[T any](ctx context.Context, opts PageOptions, fetch PageFunc[T], fn func(item T) error) error {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+
+	visited := 0
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		items, meta, err := fetch(ctx, page, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if opts.MaxItems > 0 && visited >= opts.MaxItems {
+				return nil
+			}
+
+			if err := fn(item); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+			visited++
+		}
+
+		if !meta.HasNextPage || len(items) == 0 || (opts.MaxItems > 0 && visited >= opts.MaxItems) {
+			return nil
+		}
+	}
+}
+
+// All returns every item of a list endpoint, up to MaxItems
+func All[T any](ctx context.Context, opts PageOptions, fetch PageFunc[T]) ([]T, error) {
+	var all []T
+	err := ForEach(ctx, opts, fetch, func(item T) error {
+		all = append(all, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagesOf returns a PageFunc serving the given items in pages, recording the
+// pages fetched
+func pagesOf(items []int, fetched *[]int) PageFunc[int] {
+	return func(_ context.Context, page, limit int) ([]int, MetaData, error) {
+		*fetched = append(*fetched, page)
+
+		start := min((page-1)*limit, len(items))
+		end := min(start+limit, len(items))
+
+		return items[start:end], MetaData{
+			Page:        page,
+			Limit:       limit,
+			ItemCount:   len(items),
+			HasNextPage: end < len(items),
+		}, nil
+	}
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tests := []struct {
+		name    string
+		opts    PageOptions
+		want    []int
+		fetched []int
+	}{
+		{
+			name:    "single page",
+			opts:    PageOptions{},
+			want:    items,
+			fetched: []int{1},
+		},
+		{
+			name:    "every page",
+			opts:    PageOptions{Limit: 3},
+			want:    items,
+			fetched: []int{1, 2, 3},
+		},
+		{
+			name:    "max items",
+			opts:    PageOptions{Limit: 2, MaxItems: 4},
+			want:    []int{1, 2, 3, 4},
+			fetched: []int{1, 2},
+		},
+		{
+			name:    "max items within a page",
+			opts:    PageOptions{Limit: 5, MaxItems: 3},
+			want:    []int{1, 2, 3},
+			fetched: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var fetched []int
+
+			got, err := All(context.Background(), tt.opts, pagesOf(items, &fetched))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.fetched, fetched)
+		})
+	}
+}
+
+func TestForEachStop(t *testing.T) {
+	t.Parallel()
+	var fetched []int
+	var visited []int
+
+	err := ForEach(context.Background(), PageOptions{Limit: 2}, pagesOf([]int{1, 2, 3, 4, 5}, &fetched), func(item int) error {
+		visited = append(visited, item)
+		if item == 3 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, visited)
+	assert.Equal(t, []int{1, 2}, fetched)
+}
+
+func TestForEachErrors(t *testing.T) {
+	t.Parallel()
+	errBoom := errors.New("boom")
+
+	var fetched []int
+	err := ForEach(context.Background(), PageOptions{Limit: 2}, pagesOf([]int{1, 2, 3}, &fetched), func(int) error {
+		return errBoom
+	})
+	require.ErrorIs(t, err, errBoom)
+
+	_, err = All(context.Background(), PageOptions{}, func(context.Context, int, int) ([]int, MetaData, error) {
+		return nil, MetaData{}, errBoom
+	})
+	require.ErrorIs(t, err, errBoom)
+}
+
+func TestForEachCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var fetched []int
+	err := ForEach(ctx, PageOptions{Limit: 1}, pagesOf([]int{1, 2, 3}, &fetched), func(int) error {
+		cancel()
+		return nil
+	})
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int{1}, fetched)
+}
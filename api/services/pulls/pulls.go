@@ -0,0 +1,74 @@
+package pulls
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+)
+
+// Service is the pull requests service used for accessing the "v2/prs"
+// endpoints and API services
+type Service struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+// NewPullsService returns a new pull requests Service
+func NewPullsService(httpClient *http.Client, endpoint string) *Service {
+	return &Service{
+		httpClient: httpClient,
+		endpoint:   endpoint,
+	}
+}
+
+// SearchPullRequests calls the "v2/prs/search" API endpoint
+func (s *Service) SearchPullRequests(ctx context.Context, opts SearchOptions, page, limit int) (*PullRequestsResponse, *http.Response, error) {
+	baseURL := s.endpoint + "/v2/prs/search"
+
+	// Create URL with query parameters
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing URL: %v", err)
+	}
+
+	q := u.Query()
+	q.Set("repos", strings.Join(opts.Repos, ","))
+	q.Set("range", strconv.Itoa(opts.Range))
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
+	if opts.Author != "" {
+		q.Set("contributor", opts.Author)
+	}
+	if opts.State != "" {
+		q.Set("status", opts.State)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, resp, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp, services.NewError(resp)
+	}
+
+	var pullRequestsResponse PullRequestsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pullRequestsResponse); err != nil {
+		return nil, resp, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &pullRequestsResponse, resp, nil
+}
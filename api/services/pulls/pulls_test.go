@@ -0,0 +1,95 @@
+package pulls
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/api/mock"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+)
+
+func TestSearchPullRequests(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		// Check if the URL is correct
+		assert.Equal(t, "https://api.example.com/v2/prs/search?contributor=jpmcb&limit=30&page=1&range=30&repos=testowner%2Ftestrepo&status=merged", req.URL.String())
+
+		mockResponse := PullRequestsResponse{
+			Data: []DbPullRequest{
+				{
+					Number:      1,
+					Title:       "feat: pull request 1",
+					State:       "closed",
+					IsMerged:    true,
+					AuthorLogin: "jpmcb",
+					RepoName:    "testowner/testrepo",
+				},
+			},
+			Meta: services.MetaData{
+				Page:      1,
+				Limit:     30,
+				ItemCount: 1,
+				PageCount: 1,
+			},
+		}
+
+		// Convert the mock response to JSON
+		responseBody, _ := json.Marshal(mockResponse)
+
+		// Return the mock response
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBody)),
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewPullsService(client, "https://api.example.com")
+
+	prs, resp, err := service.SearchPullRequests(context.Background(), SearchOptions{
+		Repos:  []string{"testowner/testrepo"},
+		Author: "jpmcb",
+		State:  StateMerged,
+		Range:  30,
+	}, 1, 30)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, prs.Data, 1)
+	assert.Equal(t, "feat: pull request 1", prs.Data[0].Title)
+	assert.True(t, prs.Data[0].IsMerged)
+	assert.Equal(t, 1, prs.Meta.ItemCount)
+}
+
+func TestSearchPullRequestsAnyState(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		// Unset filters aren't sent
+		assert.Equal(t, "https://api.example.com/v2/prs/search?limit=10&page=2&range=7&repos=testowner%2Ftestrepo%2Ctestowner%2Fother", req.URL.String())
+
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"statusCode":400,"message":"range must be 7, 30, 90, 180, 360"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewPullsService(client, "https://api.example.com")
+
+	_, _, err := service.SearchPullRequests(context.Background(), SearchOptions{
+		Repos: []string{"testowner/testrepo", "testowner/other"},
+		Range: 7,
+	}, 2, 10)
+
+	var apiErr *services.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
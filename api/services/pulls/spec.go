@@ -0,0 +1,57 @@
+package pulls
+
+import (
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+)
+
+// The states pull requests are searched by
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+	StateMerged = "merged"
+)
+
+type DbPullRequest struct {
+	EventID           int        `json:"event_id"`
+	Number            int        `json:"pr_number"`
+	Title             string     `json:"pr_title"`
+	State             string     `json:"pr_state"`
+	IsDraft           bool       `json:"pr_is_draft"`
+	IsMerged          bool       `json:"pr_is_merged"`
+	AuthorLogin       string     `json:"pr_author_login"`
+	RepoName          string     `json:"repo_name"`
+	Commits           int        `json:"pr_commits"`
+	Additions         int        `json:"pr_additions"`
+	Deletions         int        `json:"pr_deletions"`
+	ChangedFiles      int        `json:"pr_changed_files"`
+	Comments          int        `json:"pr_comments"`
+	ReviewComments    int        `json:"pr_review_comments"`
+	CreatedAt         time.Time  `json:"pr_created_at"`
+	UpdatedAt         time.Time  `json:"pr_updated_at"`
+	ClosedAt          *time.Time `json:"pr_closed_at"`
+	MergedAt          *time.Time `json:"pr_merged_at"`
+	MergedByLogin     string     `json:"pr_merged_by_login"`
+	AuthorAssociation string     `json:"pr_author_association"`
+}
+
+type PullRequestsResponse struct {
+	Data []DbPullRequest   `json:"data"`
+	Meta services.MetaData `json:"meta"`
+}
+
+// SearchOptions filter the pull requests searched for
+type SearchOptions struct {
+	// Repos are the full names of the repositories, like "open-sauced/pizza-cli"
+	Repos []string
+
+	// Author is the login of the pull requests' author, any author if empty
+	Author string
+
+	// State is one of StateOpen, StateClosed, or StateMerged, any state if empty
+	State string
+
+	// Range is the number of days the pull requests were active in
+	Range int
+}
@@ -1,12 +1,15 @@
 package repository
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
 )
 
 // Service is used to access the "v2/repos" endpoints and services
@@ -24,17 +27,22 @@ func NewRepositoryService(httpClient *http.Client, endpoint string) *Service {
 }
 
 // FindOneByOwnerAndRepo calls the "v2/repos/:owner/:name" endpoint
-func (rs *Service) FindOneByOwnerAndRepo(owner string, repo string) (*DbRepository, *http.Response, error) {
+func (rs *Service) FindOneByOwnerAndRepo(ctx context.Context, owner string, repo string) (*DbRepository, *http.Response, error) {
 	url := fmt.Sprintf("%s/v2/repos/%s/%s", rs.endpoint, owner, repo)
 
-	resp, err := rs.httpClient.Get(url)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := rs.httpClient.Do(req)
 	if err != nil {
 		return nil, resp, fmt.Errorf("error making request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var repository DbRepository
@@ -46,7 +54,7 @@ func (rs *Service) FindOneByOwnerAndRepo(owner string, repo string) (*DbReposito
 }
 
 // FindContributorsByOwnerAndRepo calls the "v2/repos/:owner/:name/contributors" endpoint
-func (rs *Service) FindContributorsByOwnerAndRepo(owner string, repo string, rangeVal int) (*ContributorsResponse, *http.Response, error) {
+func (rs *Service) FindContributorsByOwnerAndRepo(ctx context.Context, owner string, repo string, rangeVal, page, limit int) (*ContributorsResponse, *http.Response, error) {
 	baseURL := fmt.Sprintf("%s/v2/repos/%s/%s/contributors", rs.endpoint, owner, repo)
 
 	// Create URL with query parameters
@@ -57,14 +65,25 @@ func (rs *Service) FindContributorsByOwnerAndRepo(owner string, repo string, ran
 
 	q := u.Query()
 	q.Set("range", strconv.Itoa(rangeVal))
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
 	u.RawQuery = q.Encode()
 
-	resp, err := rs.httpClient.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := rs.httpClient.Do(req)
 	if err != nil {
 		return nil, resp, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp, services.NewError(resp)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, resp, err
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -38,7 +39,7 @@ func TestFindOneByOwnerAndRepo(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewRepositoryService(client, "https://api.example.com")
 
-	repo, resp, err := service.FindOneByOwnerAndRepo("testowner", "testrepo")
+	repo, resp, err := service.FindOneByOwnerAndRepo(context.Background(), "testowner", "testrepo")
 
 	require.NoError(t, err)
 	assert.NotNil(t, repo)
@@ -47,10 +48,36 @@ func TestFindOneByOwnerAndRepo(t *testing.T) {
 	assert.Equal(t, "testowner/testrepo", repo.FullName)
 }
 
+func TestFindOneByOwnerAndRepoNotFound(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"X-Request-Id": {"abc-123"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`{"statusCode":404,"message":"Repository not found"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewRepositoryService(client, "https://api.example.com")
+
+	repo, _, err := service.FindOneByOwnerAndRepo(context.Background(), "testowner", "testrepo")
+
+	assert.Nil(t, repo)
+	require.ErrorIs(t, err, services.ErrNotFound)
+
+	var apiErr *services.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "/v2/repos/testowner/testrepo", apiErr.Endpoint)
+	assert.Equal(t, "abc-123", apiErr.RequestID)
+	assert.Equal(t, "Repository not found", apiErr.Message)
+}
+
 func TestFindContributorsByOwnerAndRepo(t *testing.T) {
 	t.Parallel()
 	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
-		assert.Equal(t, "https://api.example.com/v2/repos/testowner/testrepo/contributors?range=30", req.URL.String())
+		assert.Equal(t, "https://api.example.com/v2/repos/testowner/testrepo/contributors?limit=30&page=1&range=30", req.URL.String())
 
 		mockResponse := ContributorsResponse{
 			Data: []DbContributorInfo{
@@ -86,7 +113,7 @@ func TestFindContributorsByOwnerAndRepo(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewRepositoryService(client, "https://api.example.com")
 
-	contributors, resp, err := service.FindContributorsByOwnerAndRepo("testowner", "testrepo", 30)
+	contributors, resp, err := service.FindContributorsByOwnerAndRepo(context.Background(), "testowner", "testrepo", 30, 1, 30)
 
 	require.NoError(t, err)
 	assert.NotNil(t, contributors)
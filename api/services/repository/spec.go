@@ -1,6 +1,10 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+)
 
 type DbRepository struct {
 	ID                                 int       `json:"id"`
@@ -91,12 +95,5 @@ type DbContributorInfo struct {
 // ContributorsResponse represents the structure of the contributors endpoint response
 type ContributorsResponse struct {
 	Data []DbContributorInfo `json:"data"`
-	Meta struct {
-		Page            int  `json:"page"`
-		Limit           int  `json:"limit"`
-		ItemCount       int  `json:"itemCount"`
-		PageCount       int  `json:"pageCount"`
-		HasPreviousPage bool `json:"hasPreviousPage"`
-		HasNextPage     bool `json:"hasNextPage"`
-	} `json:"meta"`
+	Meta services.MetaData   `json:"meta"`
 }
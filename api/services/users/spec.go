@@ -0,0 +1,69 @@
+package users
+
+import (
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+)
+
+// DbUser is the profile of a GitHub user
+type DbUser struct {
+	ID                 int       `json:"id"`
+	Login              string    `json:"login"`
+	Name               string    `json:"name"`
+	AvatarURL          string    `json:"avatar_url"`
+	Bio                string    `json:"bio"`
+	Blog               string    `json:"blog"`
+	Company            string    `json:"company"`
+	Location           string    `json:"location"`
+	Email              string    `json:"email"`
+	TwitterUsername    string    `json:"twitter_username"`
+	OSCR               float64   `json:"oscr"`
+	Followers          int       `json:"followers"`
+	Following          int       `json:"following"`
+	PublicRepos        int       `json:"public_repos"`
+	PublicGists        int       `json:"public_gists"`
+	IsOpenSaucedMember bool      `json:"is_open_sauced_member"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// DbUserHighlight is a contribution a user highlighted on their profile
+type DbUserHighlight struct {
+	ID          int       `json:"id"`
+	UserID      int       `json:"user_id"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Highlight   string    `json:"highlight"`
+	Type        string    `json:"type"`
+	Pinned      bool      `json:"pinned"`
+	TaggedRepos []string  `json:"tagged_repos"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// HighlightsResponse represents the structure of the highlights endpoint response
+type HighlightsResponse struct {
+	Data []DbUserHighlight `json:"data"`
+	Meta services.MetaData `json:"meta"`
+}
+
+// DbUserDevstats are the contribution stats of a user over a range of days,
+// across every repository they contributed to
+type DbUserDevstats struct {
+	ID                 int       `json:"id"`
+	Login              string    `json:"login"`
+	OSCR               float64   `json:"oscr"`
+	Repos              []string  `json:"repos"`
+	Commits            int       `json:"commits"`
+	PRsCreated         int       `json:"prs_created"`
+	PRsReviewed        int       `json:"prs_reviewed"`
+	IssuesCreated      int       `json:"issues_created"`
+	CommitComments     int       `json:"commit_comments"`
+	IssueComments      int       `json:"issue_comments"`
+	PRReviewComments   int       `json:"pr_review_comments"`
+	Comments           int       `json:"comments"`
+	TotalContributions int       `json:"total_contributions"`
+	LastContributed    time.Time `json:"last_contributed"`
+	DevstatsUpdatedAt  time.Time `json:"devstats_updated_at"`
+}
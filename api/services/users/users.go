@@ -0,0 +1,106 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+)
+
+// Service is the users service used for accessing the "v2/users" endpoints
+// and API services
+type Service struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+// NewUsersService returns a new users Service
+func NewUsersService(httpClient *http.Client, endpoint string) *Service {
+	return &Service{
+		httpClient: httpClient,
+		endpoint:   endpoint,
+	}
+}
+
+// FindOneByUsername calls the "v2/users/:username" endpoint
+func (s *Service) FindOneByUsername(ctx context.Context, username string) (*DbUser, *http.Response, error) {
+	u := fmt.Sprintf("%s/v2/users/%s", s.endpoint, url.PathEscape(username))
+
+	var user DbUser
+	resp, err := s.get(ctx, u, &user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &user, resp, nil
+}
+
+// FindHighlightsByUsername calls the "v2/users/:username/highlights" endpoint
+func (s *Service) FindHighlightsByUsername(ctx context.Context, username string, page, limit int) (*HighlightsResponse, *http.Response, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/v2/users/%s/highlights", s.endpoint, url.PathEscape(username)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing URL: %v", err)
+	}
+
+	q := u.Query()
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
+	u.RawQuery = q.Encode()
+
+	var highlights HighlightsResponse
+	resp, err := s.get(ctx, u.String(), &highlights)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &highlights, resp, nil
+}
+
+// FindDevstatsByUsername calls the "v2/users/:username/devstats" endpoint,
+// returning the user's contribution stats over the range of days
+func (s *Service) FindDevstatsByUsername(ctx context.Context, username string, rangeVal int) (*DbUserDevstats, *http.Response, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/v2/users/%s/devstats", s.endpoint, url.PathEscape(username)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing URL: %v", err)
+	}
+
+	q := u.Query()
+	q.Set("range", strconv.Itoa(rangeVal))
+	u.RawQuery = q.Encode()
+
+	var devstats DbUserDevstats
+	resp, err := s.get(ctx, u.String(), &devstats)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &devstats, resp, nil
+}
+
+// get sends a GET request to the url, decoding the response into v
+func (s *Service) get(ctx context.Context, url string, v any) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return resp, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, services.NewError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,129 @@
+package users
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/api/mock"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+)
+
+// jsonResponse returns a 200 OK response with the JSON of v as its body
+func jsonResponse(v any) *http.Response {
+	responseBody, _ := json.Marshal(v)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBuffer(responseBody)),
+	}
+}
+
+func TestFindOneByUsername(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.example.com/v2/users/jpmcb", req.URL.String())
+
+		return jsonResponse(DbUser{ID: 23109390, Login: "jpmcb", OSCR: 245}), nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewUsersService(client, "https://api.example.com")
+
+	user, resp, err := service.FindOneByUsername(context.Background(), "jpmcb")
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 23109390, user.ID)
+	assert.Equal(t, "jpmcb", user.Login)
+	assert.InDelta(t, 245.0, user.OSCR, 0)
+}
+
+func TestFindOneByUsernameNotFound(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"statusCode":404,"message":"User not found"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewUsersService(client, "https://api.example.com")
+
+	user, _, err := service.FindOneByUsername(context.Background(), "missing")
+
+	assert.Nil(t, user)
+	require.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestFindOneByUsernameCanceled(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		return nil, req.Context().Err()
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewUsersService(client, "https://api.example.com")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := service.FindOneByUsername(ctx, "jpmcb")
+
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFindHighlightsByUsername(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.example.com/v2/users/jpmcb/highlights?limit=10&page=2", req.URL.String())
+
+		return jsonResponse(HighlightsResponse{
+			Data: []DbUserHighlight{
+				{ID: 1, Title: "Pizza CLI v2", TaggedRepos: []string{"open-sauced/pizza-cli"}},
+			},
+			Meta: services.MetaData{Page: 2, Limit: 10, ItemCount: 11, PageCount: 2, HasPreviousPage: true},
+		}), nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewUsersService(client, "https://api.example.com")
+
+	highlights, _, err := service.FindHighlightsByUsername(context.Background(), "jpmcb", 2, 10)
+
+	require.NoError(t, err)
+	require.Len(t, highlights.Data, 1)
+	assert.Equal(t, "Pizza CLI v2", highlights.Data[0].Title)
+	assert.Equal(t, 11, highlights.Meta.ItemCount)
+}
+
+func TestFindDevstatsByUsername(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.example.com/v2/users/jpmcb/devstats?range=90", req.URL.String())
+
+		return jsonResponse(DbUserDevstats{
+			Login:       "jpmcb",
+			Repos:       []string{"open-sauced/pizza-cli"},
+			PRsCreated:  3,
+			PRsReviewed: 7,
+		}), nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewUsersService(client, "https://api.example.com")
+
+	devstats, _, err := service.FindDevstatsByUsername(context.Background(), "jpmcb", 90)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, devstats.PRsCreated)
+	assert.Equal(t, 7, devstats.PRsReviewed)
+	assert.Equal(t, []string{"open-sauced/pizza-cli"}, devstats.Repos)
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/repository"
 )
 
 type DbWorkspace struct {
@@ -44,3 +45,49 @@ type CreateWorkspaceRequest struct {
 	Repos        []CreateWorkspaceRequestRepoInfo `json:"repos"`
 	Contributors []string                         `json:"contributors"`
 }
+
+// The roles of workspace members
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// UpdateWorkspaceRequest is the body of "PATCH v2/workspaces/:id". Only the
+// fields that aren't nil are updated.
+type UpdateWorkspaceRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	IsPublic    *bool   `json:"is_public,omitempty"`
+}
+
+type DbWorkspaceRepo struct {
+	ID          string                  `json:"id"`
+	WorkspaceID string                  `json:"workspace_id"`
+	RepoID      int                     `json:"repo_id"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+	DeletedAt   *time.Time              `json:"deleted_at"`
+	Repo        repository.DbRepository `json:"repo"`
+}
+
+type DbWorkspaceReposResponse struct {
+	Data []DbWorkspaceRepo `json:"data"`
+	Meta services.MetaData `json:"meta"`
+}
+
+// WorkspaceReposRequest is the body of the "v2/workspaces/:id/repos"
+// endpoints adding and removing repositories
+type WorkspaceReposRequest struct {
+	Repos []CreateWorkspaceRequestRepoInfo `json:"repos"`
+}
+
+type WorkspaceMemberRequestInfo struct {
+	ID   int    `json:"id"`
+	Role string `json:"role"`
+}
+
+// WorkspaceMembersRequest is the body of "POST v2/workspaces/:id/members"
+type WorkspaceMembersRequest struct {
+	Members []WorkspaceMemberRequestInfo `json:"members"`
+}
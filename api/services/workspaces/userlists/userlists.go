@@ -2,11 +2,14 @@ package userlists
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services"
 )
 
 // Service is used to access the "v2/workspaces/:workspaceId/userLists"
@@ -26,7 +29,7 @@ func NewService(httpClient *http.Client, endpoint string) *Service {
 
 // GetUserLists calls the "GET v2/workspaces/:workspaceId/userLists" endpoint
 // for the authenticated user
-func (s *Service) GetUserLists(token string, workspaceID string, page, limit int) (*GetUserListsResponse, *http.Response, error) {
+func (s *Service) GetUserLists(ctx context.Context, workspaceID string, page, limit int) (*GetUserListsResponse, *http.Response, error) {
 	baseURL := s.endpoint + "/v2/workspaces/" + workspaceID + "/userLists"
 
 	// Create URL with query parameters
@@ -40,13 +43,11 @@ func (s *Service) GetUserLists(token string, workspaceID string, page, limit int
 	q.Set("limit", strconv.Itoa(limit))
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
-
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error making request: %w", err)
@@ -54,7 +55,7 @@ func (s *Service) GetUserLists(token string, workspaceID string, page, limit int
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var userListsResp GetUserListsResponse
@@ -67,16 +68,14 @@ func (s *Service) GetUserLists(token string, workspaceID string, page, limit int
 
 // GetUserList calls the "GET v2/workspaces/:workspaceId/userLists" endpoint
 // for the authenticated user
-func (s *Service) GetUserList(token string, workspaceID string, userlistID string) (*DbUserList, *http.Response, error) {
+func (s *Service) GetUserList(ctx context.Context, workspaceID string, userlistID string) (*DbUserList, *http.Response, error) {
 	url := s.endpoint + "/v2/workspaces/" + workspaceID + "/userLists/" + userlistID
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
-
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error making request: %w", err)
@@ -84,7 +83,7 @@ func (s *Service) GetUserList(token string, workspaceID string, userlistID strin
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var userList DbUserList
@@ -97,7 +96,7 @@ func (s *Service) GetUserList(token string, workspaceID string, userlistID strin
 
 // CreateUserListForUser calls the "POST v2/workspaces/:workspaceId/userLists" endpoint
 // for the authenticated user
-func (s *Service) CreateUserListForUser(token string, workspaceID string, name string, logins []string) (*CreateUserListResponse, *http.Response, error) {
+func (s *Service) CreateUserListForUser(ctx context.Context, workspaceID string, name string, logins []string, isPublic bool) (*CreateUserListResponse, *http.Response, error) {
 	url := s.endpoint + "/v2/workspaces/" + workspaceID + "/userLists"
 
 	loginReqs := []CreateUserListRequestContributor{}
@@ -107,7 +106,7 @@ func (s *Service) CreateUserListForUser(token string, workspaceID string, name s
 
 	req := CreatePatchUserListRequest{
 		Name:         name,
-		IsPublic:     false,
+		IsPublic:     isPublic,
 		Contributors: loginReqs,
 	}
 
@@ -116,12 +115,11 @@ func (s *Service) CreateUserListForUser(token string, workspaceID string, name s
 		return nil, nil, fmt.Errorf("error marshaling request: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+token)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Accept", "application/json")
 
@@ -132,7 +130,7 @@ func (s *Service) CreateUserListForUser(token string, workspaceID string, name s
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var createdUserList CreateUserListResponse
@@ -143,9 +141,9 @@ func (s *Service) CreateUserListForUser(token string, workspaceID string, name s
 	return &createdUserList, resp, nil
 }
 
-// CreateUserListForUser calls the "PATCH v2/lists/:listId" endpoint
-// for the authenticated user
-func (s *Service) PatchUserListForUser(token string, workspaceID string, userlistID string, name string, logins []string) (*DbUserList, *http.Response, error) {
+// PatchUserListForUser calls the "PATCH v2/workspaces/:workspaceId/userLists/:userListId"
+// endpoint for the authenticated user
+func (s *Service) PatchUserListForUser(ctx context.Context, workspaceID string, userlistID string, name string, logins []string, isPublic bool) (*DbUserList, *http.Response, error) {
 	url := s.endpoint + "/v2/workspaces/" + workspaceID + "/userLists/" + userlistID
 
 	loginReqs := []CreateUserListRequestContributor{}
@@ -155,7 +153,7 @@ func (s *Service) PatchUserListForUser(token string, workspaceID string, userlis
 
 	req := CreatePatchUserListRequest{
 		Name:         name,
-		IsPublic:     false,
+		IsPublic:     isPublic,
 		Contributors: loginReqs,
 	}
 
@@ -164,12 +162,11 @@ func (s *Service) PatchUserListForUser(token string, workspaceID string, userlis
 		return nil, nil, fmt.Errorf("error marshaling request: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("PATCH", url, bytes.NewBuffer(payload))
+	httpReq, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+token)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Accept", "application/json")
 
@@ -180,7 +177,7 @@ func (s *Service) PatchUserListForUser(token string, workspaceID string, userlis
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var createdUserList DbUserList
@@ -190,3 +187,26 @@ func (s *Service) PatchUserListForUser(token string, workspaceID string, userlis
 
 	return &createdUserList, resp, nil
 }
+
+// DeleteUserList calls the "DELETE v2/workspaces/:workspaceId/userLists/:userListId"
+// endpoint for the authenticated user
+func (s *Service) DeleteUserList(ctx context.Context, workspaceID string, userlistID string) (*http.Response, error) {
+	url := s.endpoint + "/v2/workspaces/" + workspaceID + "/userLists/" + userlistID
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return resp, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, services.NewError(resp)
+	}
+
+	return resp, nil
+}
@@ -2,6 +2,7 @@ package userlists
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -54,7 +55,7 @@ func TestGetUserLists(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewService(client, "https://api.example.com")
 
-	userlists, resp, err := service.GetUserLists("token", "abc123", 1, 30)
+	userlists, resp, err := service.GetUserLists(context.Background(), "abc123", 1, 30)
 
 	require.NoError(t, err)
 	assert.NotNil(t, userlists)
@@ -102,7 +103,7 @@ func TestGetUserListForUser(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewService(client, "https://api.example.com")
 
-	userlists, resp, err := service.GetUserList("token", "abc123", "xyz")
+	userlists, resp, err := service.GetUserList(context.Background(), "abc123", "xyz")
 
 	require.NoError(t, err)
 	assert.NotNil(t, userlists)
@@ -117,6 +118,10 @@ func TestCreateUserListForUser(t *testing.T) {
 		assert.Equal(t, "https://api.example.com/v2/workspaces/abc123/userLists", req.URL.String())
 		assert.Equal(t, "POST", req.Method)
 
+		var body CreatePatchUserListRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, CreatePatchUserListRequest{Name: "userlist1", IsPublic: true, Contributors: []CreateUserListRequestContributor{}}, body)
+
 		mockResponse := CreateUserListResponse{
 			ID:         "abc",
 			UserListID: "xyz",
@@ -135,7 +140,7 @@ func TestCreateUserListForUser(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewService(client, "https://api.example.com")
 
-	userlists, resp, err := service.CreateUserListForUser("token", "abc123", "userlist1", []string{})
+	userlists, resp, err := service.CreateUserListForUser(context.Background(), "abc123", "userlist1", []string{}, true)
 
 	require.NoError(t, err)
 	assert.NotNil(t, userlists)
@@ -168,7 +173,7 @@ func TestPatchUserListForUser(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewService(client, "https://api.example.com")
 
-	userlists, resp, err := service.PatchUserListForUser("token", "abc123", "abc", "userlist1", []string{})
+	userlists, resp, err := service.PatchUserListForUser(context.Background(), "abc123", "abc", "userlist1", []string{}, false)
 
 	require.NoError(t, err)
 	assert.NotNil(t, userlists)
@@ -176,3 +181,24 @@ func TestPatchUserListForUser(t *testing.T) {
 	assert.Equal(t, "abc", userlists.ID)
 	assert.Equal(t, "userlist1", userlists.Name)
 }
+
+func TestDeleteUserList(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.example.com/v2/workspaces/abc123/userLists/abc", req.URL.String())
+		assert.Equal(t, "DELETE", req.Method)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString("{}")),
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewService(client, "https://api.example.com")
+
+	resp, err := service.DeleteUserList(context.Background(), "abc123", "abc")
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -2,12 +2,15 @@ package workspaces
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 
+	"github.com/open-sauced/pizza-cli/v2/api/services"
 	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces/userlists"
 )
 
@@ -32,7 +35,7 @@ func NewWorkspacesService(httpClient *http.Client, endpoint string) *Service {
 }
 
 // GetWorkspaces calls the "GET v2/workspaces" endpoint for the authenticated user
-func (s *Service) GetWorkspaces(token string, page, limit int) (*DbWorkspacesResponse, *http.Response, error) {
+func (s *Service) GetWorkspaces(ctx context.Context, page, limit int) (*DbWorkspacesResponse, *http.Response, error) {
 	baseURL := s.endpoint + "/v2/workspaces"
 
 	// Create URL with query parameters
@@ -46,13 +49,11 @@ func (s *Service) GetWorkspaces(token string, page, limit int) (*DbWorkspacesRes
 	q.Set("limit", strconv.Itoa(limit))
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
-
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error making request: %w", err)
@@ -60,7 +61,7 @@ func (s *Service) GetWorkspaces(token string, page, limit int) (*DbWorkspacesRes
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var workspacesResp DbWorkspacesResponse
@@ -72,7 +73,7 @@ func (s *Service) GetWorkspaces(token string, page, limit int) (*DbWorkspacesRes
 }
 
 // CreateWorkspaceForUser calls the "POST v2/workspaces" endpoint for the authenticated user
-func (s *Service) CreateWorkspaceForUser(token string, name string, description string, repos []string) (*DbWorkspace, *http.Response, error) {
+func (s *Service) CreateWorkspaceForUser(ctx context.Context, name string, description string, repos []string) (*DbWorkspace, *http.Response, error) {
 	url := s.endpoint + "/v2/workspaces"
 
 	repoReqs := []CreateWorkspaceRequestRepoInfo{}
@@ -93,12 +94,11 @@ func (s *Service) CreateWorkspaceForUser(token string, name string, description
 		return nil, nil, fmt.Errorf("error marshaling request: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+token)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Accept", "application/json")
 
@@ -109,7 +109,7 @@ func (s *Service) CreateWorkspaceForUser(token string, name string, description
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, resp, services.NewError(resp)
 	}
 
 	var createdWorkspace DbWorkspace
@@ -119,3 +119,135 @@ func (s *Service) CreateWorkspaceForUser(token string, name string, description
 
 	return &createdWorkspace, resp, nil
 }
+
+// GetWorkspace calls the "GET v2/workspaces/:id" endpoint
+func (s *Service) GetWorkspace(ctx context.Context, workspaceID string) (*DbWorkspace, *http.Response, error) {
+	var workspace DbWorkspace
+	resp, err := s.doJSON(ctx, "GET", s.workspaceURL(workspaceID), nil, http.StatusOK, &workspace)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &workspace, resp, nil
+}
+
+// UpdateWorkspace calls the "PATCH v2/workspaces/:id" endpoint, updating the
+// fields of the workspace that are set in the request
+func (s *Service) UpdateWorkspace(ctx context.Context, workspaceID string, update UpdateWorkspaceRequest) (*DbWorkspace, *http.Response, error) {
+	var workspace DbWorkspace
+	resp, err := s.doJSON(ctx, "PATCH", s.workspaceURL(workspaceID), update, http.StatusOK, &workspace)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &workspace, resp, nil
+}
+
+// DeleteWorkspace calls the "DELETE v2/workspaces/:id" endpoint
+func (s *Service) DeleteWorkspace(ctx context.Context, workspaceID string) (*http.Response, error) {
+	return s.doJSON(ctx, "DELETE", s.workspaceURL(workspaceID), nil, http.StatusOK, nil)
+}
+
+// GetWorkspaceRepos calls the "GET v2/workspaces/:id/repos" endpoint
+func (s *Service) GetWorkspaceRepos(ctx context.Context, workspaceID string, page, limit int) (*DbWorkspaceReposResponse, *http.Response, error) {
+	u, err := url.Parse(s.workspaceURL(workspaceID) + "/repos")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing URL: %v", err)
+	}
+
+	q := u.Query()
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
+	u.RawQuery = q.Encode()
+
+	var reposResp DbWorkspaceReposResponse
+	resp, err := s.doJSON(ctx, "GET", u.String(), nil, http.StatusOK, &reposResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &reposResp, resp, nil
+}
+
+// AddWorkspaceRepos calls the "POST v2/workspaces/:id/repos" endpoint,
+// adding the repositories with the given full names to the workspace
+func (s *Service) AddWorkspaceRepos(ctx context.Context, workspaceID string, repos []string) (*http.Response, error) {
+	return s.doJSON(ctx, "POST", s.workspaceURL(workspaceID)+"/repos", newWorkspaceReposRequest(repos), http.StatusCreated, nil)
+}
+
+// RemoveWorkspaceRepos calls the "DELETE v2/workspaces/:id/repos" endpoint,
+// removing the repositories with the given full names from the workspace
+func (s *Service) RemoveWorkspaceRepos(ctx context.Context, workspaceID string, repos []string) (*http.Response, error) {
+	return s.doJSON(ctx, "DELETE", s.workspaceURL(workspaceID)+"/repos", newWorkspaceReposRequest(repos), http.StatusOK, nil)
+}
+
+// AddWorkspaceMembers calls the "POST v2/workspaces/:id/members" endpoint,
+// adding the users with the given IDs to the workspace with the given role
+func (s *Service) AddWorkspaceMembers(ctx context.Context, workspaceID string, userIDs []int, role string) ([]DbWorkspaceMember, *http.Response, error) {
+	req := WorkspaceMembersRequest{Members: []WorkspaceMemberRequestInfo{}}
+	for _, id := range userIDs {
+		req.Members = append(req.Members, WorkspaceMemberRequestInfo{ID: id, Role: role})
+	}
+
+	var members []DbWorkspaceMember
+	resp, err := s.doJSON(ctx, "POST", s.workspaceURL(workspaceID)+"/members", req, http.StatusCreated, &members)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return members, resp, nil
+}
+
+func (s *Service) workspaceURL(workspaceID string) string {
+	return fmt.Sprintf("%s/v2/workspaces/%s", s.endpoint, url.PathEscape(workspaceID))
+}
+
+func newWorkspaceReposRequest(repos []string) WorkspaceReposRequest {
+	req := WorkspaceReposRequest{Repos: []CreateWorkspaceRequestRepoInfo{}}
+	for _, repo := range repos {
+		req.Repos = append(req.Repos, CreateWorkspaceRequestRepoInfo{FullName: repo})
+	}
+
+	return req
+}
+
+// doJSON sends a request with the JSON of body, if not nil, expecting the
+// given status code. The response is decoded into v, if not nil.
+func (s *Service) doJSON(ctx context.Context, method, url string, body any, wantStatus int, v any) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return resp, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != wantStatus {
+		return resp, services.NewError(resp)
+	}
+
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return resp, fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
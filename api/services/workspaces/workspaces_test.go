@@ -2,6 +2,7 @@ package workspaces
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/open-sauced/pizza-cli/v2/api/mock"
 	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/repository"
 )
 
 func TestGetWorkspaces(t *testing.T) {
@@ -54,7 +56,7 @@ func TestGetWorkspaces(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewWorkspacesService(client, "https://api.example.com")
 
-	workspaces, resp, err := service.GetWorkspaces("token", 1, 30)
+	workspaces, resp, err := service.GetWorkspaces(context.Background(), 1, 30)
 
 	require.NoError(t, err)
 	assert.NotNil(t, workspaces)
@@ -102,7 +104,7 @@ func TestCreateWorkspaceForUser(t *testing.T) {
 	client := &http.Client{Transport: m}
 	service := NewWorkspacesService(client, "https://api.example.com")
 
-	workspace, resp, err := service.CreateWorkspaceForUser("token", "test workspace", "a workspace for testing", []string{})
+	workspace, resp, err := service.CreateWorkspaceForUser(context.Background(), "test workspace", "a workspace for testing", []string{})
 
 	require.NoError(t, err)
 	assert.NotNil(t, workspace)
@@ -110,3 +112,189 @@ func TestCreateWorkspaceForUser(t *testing.T) {
 	assert.Equal(t, "abc123", workspace.ID)
 	assert.Equal(t, "workspace1", workspace.Name)
 }
+
+func TestGetWorkspace(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.example.com/v2/workspaces/abc123", req.URL.String())
+		assert.Equal(t, "GET", req.Method)
+
+		responseBody, _ := json.Marshal(DbWorkspace{ID: "abc123", Name: "workspace1"})
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBody)),
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewWorkspacesService(client, "https://api.example.com")
+
+	workspace, _, err := service.GetWorkspace(context.Background(), "abc123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "workspace1", workspace.Name)
+}
+
+func TestGetWorkspaceNotFound(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"statusCode":404,"message":"Workspace not found"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewWorkspacesService(client, "https://api.example.com")
+
+	workspace, _, err := service.GetWorkspace(context.Background(), "missing")
+
+	assert.Nil(t, workspace)
+	require.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestUpdateWorkspace(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.example.com/v2/workspaces/abc123", req.URL.String())
+		assert.Equal(t, "PATCH", req.Method)
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"renamed","is_public":true}`, string(body))
+
+		responseBody, _ := json.Marshal(DbWorkspace{ID: "abc123", Name: "renamed", IsPublic: true})
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBody)),
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewWorkspacesService(client, "https://api.example.com")
+
+	name := "renamed"
+	isPublic := true
+	workspace, _, err := service.UpdateWorkspace(context.Background(), "abc123", UpdateWorkspaceRequest{Name: &name, IsPublic: &isPublic})
+
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", workspace.Name)
+	assert.True(t, workspace.IsPublic)
+}
+
+func TestDeleteWorkspace(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.example.com/v2/workspaces/abc123", req.URL.String())
+		assert.Equal(t, "DELETE", req.Method)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString("{}")),
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewWorkspacesService(client, "https://api.example.com")
+
+	resp, err := service.DeleteWorkspace(context.Background(), "abc123")
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestGetWorkspaceRepos(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.example.com/v2/workspaces/abc123/repos?limit=30&page=1", req.URL.String())
+		assert.Equal(t, "GET", req.Method)
+
+		mockResponse := DbWorkspaceReposResponse{
+			Data: []DbWorkspaceRepo{
+				{ID: "1", WorkspaceID: "abc123", Repo: repository.DbRepository{FullName: "open-sauced/pizza-cli"}},
+			},
+			Meta: services.MetaData{Page: 1, Limit: 30, ItemCount: 1, PageCount: 1},
+		}
+		responseBody, _ := json.Marshal(mockResponse)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBody)),
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewWorkspacesService(client, "https://api.example.com")
+
+	repos, _, err := service.GetWorkspaceRepos(context.Background(), "abc123", 1, 30)
+
+	require.NoError(t, err)
+	require.Len(t, repos.Data, 1)
+	assert.Equal(t, "open-sauced/pizza-cli", repos.Data[0].Repo.FullName)
+}
+
+func TestAddRemoveWorkspaceRepos(t *testing.T) {
+	t.Parallel()
+	var methods []string
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		methods = append(methods, req.Method)
+		assert.Equal(t, "https://api.example.com/v2/workspaces/abc123/repos", req.URL.String())
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"repos":[{"full_name":"open-sauced/pizza-cli"},{"full_name":"open-sauced/app"}]}`, string(body))
+
+		status := http.StatusOK
+		if req.Method == "POST" {
+			status = http.StatusCreated
+		}
+
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(bytes.NewBufferString("{}")),
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewWorkspacesService(client, "https://api.example.com")
+	repos := []string{"open-sauced/pizza-cli", "open-sauced/app"}
+
+	_, err := service.AddWorkspaceRepos(context.Background(), "abc123", repos)
+	require.NoError(t, err)
+
+	_, err = service.RemoveWorkspaceRepos(context.Background(), "abc123", repos)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"POST", "DELETE"}, methods)
+}
+
+func TestAddWorkspaceMembers(t *testing.T) {
+	t.Parallel()
+	m := mock.NewMockRoundTripper(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.example.com/v2/workspaces/abc123/members", req.URL.String())
+		assert.Equal(t, "POST", req.Method)
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"members":[{"id":23109390,"role":"editor"}]}`, string(body))
+
+		responseBody, _ := json.Marshal([]DbWorkspaceMember{{ID: "m1", UserID: 23109390, WorkspaceID: "abc123", Role: RoleEditor}})
+
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBody)),
+		}, nil
+	})
+
+	client := &http.Client{Transport: m}
+	service := NewWorkspacesService(client, "https://api.example.com")
+
+	members, _, err := service.AddWorkspaceMembers(context.Background(), "abc123", []int{23109390}, RoleEditor)
+
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, RoleEditor, members[0].Role)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/open-sauced/pizza-cli/v2/api/auth"
+)
+
+// TokenSource provides the access token API requests are authenticated with
+type TokenSource interface {
+	// Token returns the access token, or "" to send requests unauthenticated
+	Token() (string, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource
+type TokenSourceFunc func() (string, error)
+
+// Token calls the function
+func (f TokenSourceFunc) Token() (string, error) {
+	return f()
+}
+
+// StaticToken returns a TokenSource always providing the given token
+func StaticToken(token string) TokenSource {
+	return TokenSourceFunc(func() (string, error) {
+		return token, nil
+	})
+}
+
+// EnvToken returns a TokenSource providing the token in the given environment
+// variable, read on every request
+func EnvToken(name string) TokenSource {
+	return TokenSourceFunc(func() (string, error) {
+		return os.Getenv(name), nil
+	})
+}
+
+// SessionToken returns a TokenSource providing the access token of the
+// logged in user's session, refreshing it when it's about to expire. When
+// nobody is logged in, requests are sent unauthenticated. So are they when the
+// session expired and can't be refreshed, after warning once on the given
+// writer to log in again.
+func SessionToken(authenticator *auth.Authenticator, warnings io.Writer) TokenSource {
+	var warnOnce sync.Once
+	return TokenSourceFunc(func() (string, error) {
+		token, err := authenticator.GetSessionToken()
+		if errors.Is(err, auth.ErrNoSession) {
+			return "", nil
+		}
+		if errors.Is(err, auth.ErrSessionExpired) {
+			warnOnce.Do(func() {
+				fmt.Fprintf(warnings, "Warning: %s, sending requests unauthenticated. Log in again with \"pizza login\"\n", err)
+			})
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: log in again with \"pizza login\"", err)
+		}
+
+		return token, nil
+	})
+}
+
+// FirstToken returns a TokenSource providing the token of the first of the
+// given sources that has one
+func FirstToken(sources ...TokenSource) TokenSource {
+	return TokenSourceFunc(func() (string, error) {
+		for _, source := range sources {
+			token, err := source.Token()
+			if err != nil || token != "" {
+				return token, err
+			}
+		}
+
+		return "", nil
+	})
+}
+
+// authTransport is an http.RoundTripper authenticating requests with the
+// token from a TokenSource. Requests that already have an "Authorization"
+// header are sent as is.
+type authTransport struct {
+	base   http.RoundTripper
+	source TokenSource
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	token, err := t.source.Token()
+	if err != nil {
+		closeBody(req)
+		return nil, fmt.Errorf("could not get access token: %w", err)
+	}
+
+	if token == "" {
+		return t.base.RoundTrip(req)
+	}
+
+	// RoundTrippers mustn't modify the request
+	authReq := req.Clone(req.Context())
+	authReq.Header.Set("Authorization", "Bearer "+token)
+
+	return t.base.RoundTrip(authReq)
+}
+
+// closeBody closes the request's body, as RoundTrippers must even on errors
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/api/auth"
+)
+
+// newAuthTestServer returns a server recording the "Authorization" header of
+// the last request
+func newAuthTestServer(t *testing.T, authorization *string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		*authorization = r.Header.Get("Authorization")
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestAuthTransport(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		source TokenSource
+		header string
+		want   string
+	}{
+		{
+			name:   "static token",
+			source: StaticToken("abc123"),
+			want:   "Bearer abc123",
+		},
+		{
+			name:   "no token",
+			source: StaticToken(""),
+			want:   "",
+		},
+		{
+			name:   "first token",
+			source: FirstToken(StaticToken(""), StaticToken("second"), StaticToken("third")),
+			want:   "Bearer second",
+		},
+		{
+			name:   "existing header",
+			source: StaticToken("abc123"),
+			header: "Bearer given",
+			want:   "Bearer given",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var authorization string
+			server := newAuthTestServer(t, &authorization)
+
+			client := NewClient(server.URL, WithTokenSource(tt.source))
+			req, err := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+			require.NoError(t, err)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			resp, err := client.httpClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.want, authorization)
+		})
+	}
+}
+
+func TestAuthTransportTokenError(t *testing.T) {
+	t.Parallel()
+	var authorization string
+	server := newAuthTestServer(t, &authorization)
+	errBoom := errors.New("boom")
+
+	client := NewClient(server.URL, WithTokenSource(FirstToken(
+		TokenSourceFunc(func() (string, error) { return "", errBoom }),
+		StaticToken("unused"),
+	)))
+	req, err := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.httpClient.Do(req) //nolint:bodyclose // no response on errors
+	require.ErrorIs(t, err, errBoom)
+}
+
+func TestEnvToken(t *testing.T) {
+	t.Setenv("PIZZA_TEST_TOKEN", "from-env")
+
+	token, err := EnvToken("PIZZA_TEST_TOKEN").Token()
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", token)
+}
+
+// memoryStore is a credential store keeping the session in memory
+type memoryStore struct {
+	data []byte
+}
+
+func (m *memoryStore) Name() string {
+	return "memory"
+}
+
+func (m *memoryStore) Location() string {
+	return "memory"
+}
+
+func (m *memoryStore) Load() ([]byte, error) {
+	if m.data == nil {
+		return nil, auth.ErrNoSession
+	}
+
+	return m.data, nil
+}
+
+func (m *memoryStore) Save(data []byte) error {
+	m.data = data
+	return nil
+}
+
+func (m *memoryStore) Delete() error {
+	m.data = nil
+	return nil
+}
+
+func TestSessionTokenExpired(t *testing.T) {
+	// The session lock is taken in the config directory
+	t.Setenv("HOME", t.TempDir())
+
+	// Sessions logged in with "pizza login --with-token" have no refresh token
+	data, err := json.Marshal(map[string]interface{}{
+		"access_token": "expired",
+		"expires_at":   time.Now().Add(-time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	authenticator := auth.NewAuthenticator(auth.WithCredentialStore(&memoryStore{data: data}))
+
+	var authorization string
+	server := newAuthTestServer(t, &authorization)
+	var warnings bytes.Buffer
+	client := NewClient(server.URL, WithTokenSource(SessionToken(authenticator, &warnings)))
+
+	for range 2 {
+		req, err := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := client.httpClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Empty(t, authorization)
+	}
+
+	// The warning is only written once
+	assert.Equal(t, 1, strings.Count(warnings.String(), "pizza login"))
+	assert.Contains(t, warnings.String(), "session expired")
+}
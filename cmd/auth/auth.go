@@ -1,7 +1,12 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -12,31 +17,75 @@ import (
 
 // Options are the persistent options for the login command
 type Options struct {
+	// whether to log in by pasting back the code instead of using a local browser
+	noBrowser bool
+
+	// whether to log in with an access token read from stdin or "PIZZA_TOKEN"
+	withToken bool
+
+	// the port of the local callback server, 0 picks a free port
+	callbackPort int
+
+	// how long to wait for the login to complete
+	timeout time.Duration
+
 	// telemetry for capturing CLI events via PostHog
 	telemetry *utils.PosthogCliClient
 }
 
+// tokenEnvVar is the environment variable "--with-token" reads the access token from
+// when none is piped to stdin
+const tokenEnvVar = "PIZZA_TOKEN"
+
 const (
 	loginLongDesc = `Log into the OpenSauced CLI.
 
 This command initiates the GitHub auth flow to log you into the OpenSauced CLI
-by launching your browser and logging in with GitHub.`
+by launching your browser and logging in with GitHub.
+
+On machines without a browser, like remote dev boxes, use "--no-browser" to
+open the login URL on any other machine and paste back the resulting code.
+In CI, use "--with-token" to log in with an access token read from stdin or
+the "PIZZA_TOKEN" environment variable.`
+
+	loginExamples = `  # Log in with your browser
+  $ pizza login
+
+  # Log in from a remote machine
+  $ pizza login --no-browser
+
+  # Log in with an access token
+  $ echo "$OPENSAUCED_TOKEN" | pizza login --with-token`
 )
 
 func NewLoginCommand() *cobra.Command {
 	opts := &Options{}
 
 	cmd := &cobra.Command{
-		Use:   "login",
-		Short: "Log into the CLI via GitHub",
-		Long:  loginLongDesc,
-		Args:  cobra.NoArgs,
+		Use:     "login",
+		Short:   "Log into the CLI via GitHub",
+		Long:    loginLongDesc,
+		Example: loginExamples,
+		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			disableTelem, _ := cmd.Flags().GetBool(constants.FlagNameTelemetry)
 
 			opts.telemetry = utils.NewPosthogCliClient(!disableTelem)
+			opts.noBrowser, _ = cmd.Flags().GetBool("no-browser")
+			opts.withToken, _ = cmd.Flags().GetBool("with-token")
+			opts.callbackPort, _ = cmd.Flags().GetInt("callback-port")
+			opts.timeout, _ = cmd.Flags().GetDuration("timeout")
+
+			authenticator, err := NewAuthenticator(
+				cmd,
+				auth.WithCallbackPort(opts.callbackPort),
+				auth.WithTimeout(opts.timeout),
+			)
+			if err != nil {
+				return err
+			}
 
-			username, err := run()
+			username, err := run(opts, authenticator)
 
 			if err != nil {
 				_ = opts.telemetry.CaptureFailedLogin()
@@ -50,13 +99,48 @@ func NewLoginCommand() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Bool("no-browser", false, "Log in without a local browser by pasting back the code from the login URL")
+	cmd.Flags().Bool("with-token", false, "Log in with an access token read from stdin or the PIZZA_TOKEN environment variable")
+	cmd.MarkFlagsMutuallyExclusive("no-browser", "with-token")
+	cmd.Flags().Int("callback-port", 0, "The port of the local login callback server. Defaults to a free port")
+	cmd.Flags().Duration("timeout", auth.DefaultLoginTimeout, "How long to wait for the browser login to complete")
+
 	return cmd
 }
 
-func run() (string, error) {
-	authenticator := auth.NewAuthenticator()
+// NewAuthenticator returns an Authenticator storing the session in the
+// credential store chosen with the command's "--credential-store" flag
+func NewAuthenticator(cmd *cobra.Command, opts ...auth.Option) (*auth.Authenticator, error) {
+	storeName, _ := cmd.Flags().GetString(constants.FlagNameCredentialStore)
+	keyFile, _ := cmd.Flags().GetString(constants.FlagNameCredentialKeyFile)
+
+	store, err := auth.NewCredentialStore(storeName, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewAuthenticator(append([]auth.Option{auth.WithCredentialStore(store)}, opts...)...), nil
+}
+
+func run(opts *Options, authenticator *auth.Authenticator) (string, error) {
+	var username string
+	var err error
+
+	switch {
+	case opts.withToken:
+		var token string
+		token, err = readToken(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+
+		username, err = authenticator.LoginWithToken(token)
+	case opts.noBrowser:
+		username, err = authenticator.LoginWithCode(os.Stdin, os.Stdout)
+	default:
+		username, err = authenticator.Login()
+	}
 
-	username, err := authenticator.Login()
 	if err != nil {
 		return "", fmt.Errorf("sad: %w", err)
 	}
@@ -66,3 +150,24 @@ func run() (string, error) {
 
 	return username, nil
 }
+
+// readToken reads the access token piped to stdin, falling back to the
+// "PIZZA_TOKEN" environment variable when nothing is piped
+func readToken(stdin *os.File) (string, error) {
+	if info, err := stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice == 0 {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("could not read token from stdin: %w", err)
+		}
+
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, nil
+		}
+	}
+
+	if token := os.Getenv(tokenEnvVar); token != "" {
+		return token, nil
+	}
+
+	return "", errors.New("no access token given: pipe one to stdin or set " + tokenEnvVar)
+}
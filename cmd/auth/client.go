@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+// cacheDirName is the directory in "~/.pizza-cli" API responses are cached in
+const cacheDirName = "cache"
+
+// NewAPIClient returns an API client for the command's "--endpoint",
+// "--api-timeout", and "--concurrency" flags, authenticated as the logged in
+// user. An access token in the "PIZZA_TOKEN" environment variable takes
+// precedence over the session. Requests are sent unauthenticated when neither
+// is available, or when the session expired and can't be refreshed.
+//
+// Responses are cached on disk when "--cache" is set, unless "--no-cache" is.
+func NewAPIClient(cmd *cobra.Command) (*api.Client, error) {
+	endpoint, _ := cmd.Flags().GetString(constants.FlagNameEndpoint)
+	timeout, _ := cmd.Flags().GetDuration(constants.FlagNameAPITimeout)
+	concurrency, _ := cmd.Flags().GetInt(constants.FlagNameConcurrency)
+	if concurrency < 1 {
+		return nil, fmt.Errorf("--%s must be at least 1, got %d", constants.FlagNameConcurrency, concurrency)
+	}
+
+	authenticator, err := NewAuthenticator(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenSource := api.FirstToken(
+		api.EnvToken(tokenEnvVar),
+		api.SessionToken(authenticator, cmd.ErrOrStderr()),
+	)
+
+	opts := []api.Option{api.WithTimeout(timeout), api.WithConcurrency(concurrency), api.WithTokenSource(tokenSource)}
+
+	useCache, _ := cmd.Flags().GetBool(constants.FlagNameCache)
+	noCache, _ := cmd.Flags().GetBool(constants.FlagNameNoCache)
+	if useCache && !noCache {
+		configDir, err := config.GetConfigDirectory()
+		if err != nil {
+			return nil, fmt.Errorf("could not get cache directory: %w", err)
+		}
+
+		refresh, _ := cmd.Flags().GetBool(constants.FlagNameRefresh)
+		opts = append(opts, api.WithCache(api.CacheOptions{
+			Dir:     filepath.Join(configDir, cacheDirName),
+			Refresh: refresh,
+		}))
+	}
+
+	return api.NewClient(endpoint, opts...), nil
+}
+
+// HasEnvToken reports whether an access token is set in the "PIZZA_TOKEN"
+// environment variable, which API clients authenticate with instead of the
+// session
+func HasEnvToken() bool {
+	return os.Getenv(tokenEnvVar) != ""
+}
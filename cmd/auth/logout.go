@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api/auth"
+)
+
+const logoutLongDesc = `Log out of the OpenSauced CLI.
+
+This command revokes the session with the auth service and deletes it from the
+credential store, "~/.pizza-cli/session.json" by default. The session is deleted
+even if it can't be revoked.`
+
+// NewLogoutCommand returns a new cobra command for 'pizza logout'
+func NewLogoutCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Log out of the CLI",
+		Long:  logoutLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			authenticator, err := NewAuthenticator(cmd)
+			if err != nil {
+				return err
+			}
+
+			return runLogout(authenticator)
+		},
+	}
+}
+
+func runLogout(authenticator *auth.Authenticator) error {
+	deleted, err := authenticator.Logout()
+	if errors.Is(err, auth.ErrNoSession) {
+		fmt.Println("Not logged in")
+		return nil
+	}
+
+	if !deleted {
+		return fmt.Errorf("could not log out: %w", err)
+	}
+
+	if err != nil {
+		fmt.Printf("Warning: %s\n", err)
+	}
+
+	fmt.Println("👋 Logged out")
+	return nil
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	bubblesTable "github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+// NewAuthCommand returns a new cobra command for 'pizza auth'
+func NewAuthCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth <command> [flags]",
+		Short: "Inspect the CLI's authentication",
+		Long:  "Inspect the CLI's authentication. Use \"pizza login\" and \"pizza logout\" to log in and out.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewStatusCommand())
+	return cmd
+}
+
+// statusOutput is the session status along with the API endpoint it's used with
+type statusOutput struct {
+	LoggedIn bool   `json:"logged_in" yaml:"logged_in"`
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+
+	*auth.Status `json:",omitempty" yaml:",inline,omitempty"`
+}
+
+// NewStatusCommand returns a new cobra command for 'pizza auth status'
+func NewStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status",
+		Aliases: []string{"whoami"},
+		Short:   "Show the logged in user and session",
+		Long:    "Show the logged in GitHub user, when the session expires, the API endpoint, and where the session is stored. Exits with an error when not logged in.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			endpoint, _ := cmd.Flags().GetString(constants.FlagNameEndpoint)
+			output, _ := cmd.Flags().GetString(constants.FlagNameOutput)
+
+			authenticator, err := NewAuthenticator(cmd)
+			if err != nil {
+				return err
+			}
+
+			// Not being logged in isn't a usage error
+			cmd.SilenceUsage = true
+
+			return runStatus(authenticator, endpoint, output)
+		},
+	}
+
+	cmd.Flags().StringP(constants.FlagNameOutput, "o", constants.OutputTable, "The formatting for command output. One of: (table, yaml, json)")
+	return cmd
+}
+
+func runStatus(authenticator *auth.Authenticator, endpoint, format string) error {
+	status, err := authenticator.Status()
+	if err != nil && !errors.Is(err, auth.ErrNoSession) {
+		return err
+	}
+
+	out := statusOutput{
+		LoggedIn: status != nil,
+		Endpoint: endpoint,
+		Status:   status,
+	}
+
+	var output string
+	var outputErr error
+	switch format {
+	case constants.OutputTable:
+		output = out.outputTable()
+	case constants.OutputJSON:
+		output, outputErr = utils.OutputJSON(out)
+	case constants.OutputYAML:
+		output, outputErr = utils.OutputYAML(out)
+	default:
+		return fmt.Errorf("unknown output format %s", format)
+	}
+
+	if outputErr != nil {
+		return outputErr
+	}
+
+	fmt.Println(output)
+
+	if !out.LoggedIn {
+		return auth.ErrNoSession
+	}
+
+	return nil
+}
+
+func (out statusOutput) outputTable() string {
+	rows := []bubblesTable.Row{
+		{"Endpoint", out.Endpoint},
+	}
+
+	if out.Status == nil {
+		rows = append([]bubblesTable.Row{{"Logged in", "no"}}, rows...)
+	} else {
+		expiry := out.ExpiresAt.Local().Format(time.RFC1123)
+		switch {
+		case out.Expired && out.Refreshable:
+			expiry += " (expired, refreshed on next use)"
+		case out.Expired:
+			expiry += " (expired, log in again)"
+		}
+
+		rows = append([]bubblesTable.Row{
+			{"Logged in as", out.Username},
+			{"Expires", expiry},
+		}, rows...)
+		rows = append(rows,
+			bubblesTable.Row{"Auth service", out.AuthURL},
+			bubblesTable.Row{"Credential store", out.CredentialStore},
+			bubblesTable.Row{"Stored in", out.Location},
+		)
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row[1]))
+	}
+
+	columns := []bubblesTable.Column{
+		{Title: "Session", Width: utils.GetMaxTableRowWidth(rows)},
+		{Title: "", Width: width},
+	}
+
+	return utils.OutputTable(rows, columns)
+}
@@ -0,0 +1,184 @@
+// Package config provides the "pizza config" command for managing the global
+// Pizza CLI configuration file
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+)
+
+const configLongDesc string = `Manage the global Pizza CLI configuration file, "~/.pizza-cli/config.yaml".
+
+Values in this file provide defaults for flags so they don't need to be repeated
+on every run. Keys are either a bare flag name, which applies to every command with
+that flag, or a flag name scoped to a command with dots:
+
+  log-level: debug
+  insights.contributors.range: 90
+
+Every key may also be given as a "PIZZA_" environment variable with dashes and
+dots replaced by underscores. Example: PIZZA_LOG_LEVEL, PIZZA_INSIGHTS_CONTRIBUTORS_RANGE
+
+Explicit flags take precedence over environment variables, which take precedence
+over the config file. Confirmations like "--yes" can only be given as flags.`
+
+const configExamples string = `  # Always disable telemetry
+  $ pizza config set disable-telemetry true
+
+  # Default the insights contributors range to 90 days
+  $ pizza config set insights.contributors.range 90
+
+  # Show the whole config file
+  $ pizza config get
+
+  # Remove a value
+  $ pizza config unset disable-telemetry`
+
+// NewConfigCommand returns a new cobra command for 'pizza config'
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "config <command> [flags]",
+		Short:   "Manage the global Pizza CLI configuration file",
+		Long:    configLongDesc,
+		Example: configExamples,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newGetCommand())
+	cmd.AddCommand(newSetCommand())
+	cmd.AddCommand(newUnsetCommand())
+
+	return cmd
+}
+
+func newGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [key]",
+		Short: "Print a value, or every value when no key is given, from the config file",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cliConfig, err := config.LoadCLIConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				for _, key := range cliConfig.Keys() {
+					value, _ := cliConfig.Get(key)
+					fmt.Printf("%s: %s\n", key, value)
+				}
+
+				return nil
+			}
+
+			value, ok := cliConfig.Get(args[0])
+			if !ok {
+				return fmt.Errorf("%s is not set in %s", args[0], cliConfig.Path())
+			}
+
+			fmt.Println(value)
+			return nil
+		},
+	}
+}
+
+func newSetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a value in the config file",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+
+			if err := validateKey(cmd.Root(), key); err != nil {
+				return err
+			}
+
+			cliConfig, err := config.LoadCLIConfig()
+			if err != nil {
+				return err
+			}
+
+			cliConfig.Set(key, value)
+			return cliConfig.Save()
+		},
+	}
+}
+
+func newUnsetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a value from the config file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cliConfig, err := config.LoadCLIConfig()
+			if err != nil {
+				return err
+			}
+
+			if !cliConfig.Unset(args[0]) {
+				return fmt.Errorf("%s is not set in %s", args[0], cliConfig.Path())
+			}
+
+			return cliConfig.Save()
+		},
+	}
+}
+
+// CommandPath returns the names of the given command and its parents, excluding
+// the root command. This is the scope used for command specific config keys.
+func CommandPath(cmd *cobra.Command) []string {
+	var path []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		path = append([]string{c.Name()}, path...)
+	}
+
+	return path
+}
+
+// validateKey ensures a key refers to a real flag on a real command
+func validateKey(root *cobra.Command, key string) error {
+	parts := strings.Split(key, ".")
+	flagName := parts[len(parts)-1]
+
+	if !config.HasDefault(flagName) {
+		return fmt.Errorf("--%s can't be set in the config file, it must be given on the command line", flagName)
+	}
+
+	var commands []*cobra.Command
+	if len(parts) == 1 {
+		// Bare keys apply to any command with the flag
+		commands = allCommands(root)
+	} else {
+		cmd, rest, err := root.Find(parts[:len(parts)-1])
+		if err != nil || len(rest) > 0 || strings.Join(CommandPath(cmd), ".") != strings.Join(parts[:len(parts)-1], ".") {
+			return fmt.Errorf("unknown command in key: %s", key)
+		}
+
+		commands = []*cobra.Command{cmd}
+	}
+
+	for _, cmd := range commands {
+		if cmd.Flags().Lookup(flagName) != nil || cmd.InheritedFlags().Lookup(flagName) != nil {
+			return nil
+		}
+	}
+
+	return errors.New("unknown flag in key: " + key)
+}
+
+func allCommands(cmd *cobra.Command) []*cobra.Command {
+	commands := []*cobra.Command{cmd}
+	for _, child := range cmd.Commands() {
+		commands = append(commands, allCommands(child)...)
+	}
+
+	return commands
+}
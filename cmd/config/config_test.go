@@ -0,0 +1,94 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRoot returns a command tree like "pizza insights contributors" with a
+// persistent "log-level" flag
+func newTestRoot() *cobra.Command {
+	root := &cobra.Command{Use: "pizza"}
+	root.PersistentFlags().String("log-level", "info", "")
+
+	insights := &cobra.Command{Use: "insights"}
+	contributors := &cobra.Command{Use: "contributors"}
+	contributors.Flags().Int("range", 30, "")
+
+	lists := &cobra.Command{Use: "lists"}
+	deleteCmd := &cobra.Command{Use: "delete"}
+	deleteCmd.Flags().Bool("yes", false, "")
+
+	insights.AddCommand(contributors)
+	lists.AddCommand(deleteCmd)
+	root.AddCommand(insights, lists)
+	return root
+}
+
+func TestValidateKey(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		key     string
+		wantErr string
+	}{
+		{
+			name: "global flag",
+			key:  "range",
+		},
+		{
+			name: "persistent flag",
+			key:  "log-level",
+		},
+		{
+			name: "scoped flag",
+			key:  "insights.contributors.range",
+		},
+		{
+			name: "scoped persistent flag",
+			key:  "insights.contributors.log-level",
+		},
+		{
+			name:    "unknown flag",
+			key:     "timeout",
+			wantErr: "unknown flag in key: timeout",
+		},
+		{
+			name:    "flag of another command",
+			key:     "insights.range",
+			wantErr: "unknown flag in key: insights.range",
+		},
+		{
+			name:    "unknown command",
+			key:     "insights.repositories.range",
+			wantErr: "unknown command in key: insights.repositories.range",
+		},
+		{
+			name:    "confirmation",
+			key:     "yes",
+			wantErr: "--yes can't be set in the config file, it must be given on the command line",
+		},
+		{
+			name:    "scoped confirmation",
+			key:     "lists.delete.yes",
+			wantErr: "--yes can't be set in the config file, it must be given on the command line",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateKey(newTestRoot(), tt.key)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
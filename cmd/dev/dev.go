@@ -0,0 +1,22 @@
+// Package dev provides the "pizza dev" commands, tooling for developing
+// against and demoing the Pizza CLI
+package dev
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewDevCommand returns a new cobra command for 'pizza dev'
+func NewDevCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev <command> [flags]",
+		Short: "Tooling for developing against and demoing the Pizza CLI",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newMockServerCommand())
+
+	return cmd
+}
@@ -0,0 +1,117 @@
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api/mock/server"
+)
+
+const mockServerLongDesc string = `Serve a stand-in for the OpenSauced API on localhost, for demos, integration
+tests, and air-gapped environments.
+
+The repositories, pull requests, and workspaces it serves come from a YAML or
+JSON dataset. Without "--dataset", a small sample of the OpenSauced repositories
+is served. Insights are computed from the dataset's pull requests for the range
+each request asks for, and workspaces and their contributor lists can be
+created, edited, and deleted until the server stops.
+
+Point any command at the server with "--endpoint". Workspace commands need to
+be authenticated: any access token is accepted, like PIZZA_TOKEN=dev.
+
+A dataset looks like:
+
+  user:
+    login: pizza
+  repositories:
+    - full_name: open-sauced/pizza-cli
+      svn_url: https://github.com/open-sauced/pizza-cli
+  pull_requests:
+    - {repo: open-sauced/pizza-cli, author: jpmcb, state: merged, commits: 3, days_ago: 2}
+  workspaces:
+    - name: OpenSauced
+      repositories: [open-sauced/pizza-cli]
+      user_lists:
+        - {name: Maintainers, contributors: [jpmcb]}`
+
+const mockServerExamples string = `  # Serve the sample dataset on port 8080
+  $ pizza dev mock-server
+
+  # In another terminal, gather insights from it
+  $ pizza insights contributors https://github.com/open-sauced/pizza-cli --endpoint http://localhost:8080
+
+  # Serve your own dataset
+  $ pizza dev mock-server --dataset demo.yaml --port 3000`
+
+type mockServerOptions struct {
+	// datasetPath is the path of the dataset to serve, the sample dataset if empty
+	datasetPath string
+
+	// port is the port to listen on
+	port int
+}
+
+func newMockServerCommand() *cobra.Command {
+	opts := &mockServerOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "mock-server [flags]",
+		Short:   "Serve a stand-in for the OpenSauced API from a dataset",
+		Long:    mockServerLongDesc,
+		Example: mockServerExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.datasetPath, "dataset", "d", "", "Path to a YAML or JSON dataset to serve instead of the sample one")
+	cmd.Flags().IntVarP(&opts.port, "port", "p", 8080, "Port to listen on")
+
+	return cmd
+}
+
+func (opts *mockServerOptions) run(cmd *cobra.Command) error {
+	dataset := server.SampleDataset()
+	if opts.datasetPath != "" {
+		var err error
+		dataset, err = server.LoadDataset(opts.datasetPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(opts.port)))
+	if err != nil {
+		return fmt.Errorf("could not listen on port %d, choose another one with --port: %w", opts.port, err)
+	}
+
+	httpServer := &http.Server{
+		Handler:           server.New(dataset),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Stop on Ctrl+C, letting requests in flight finish
+	go func() {
+		<-cmd.Context().Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(ctx)
+	}()
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Serving the mock OpenSauced API on http://%s\n", listener.Addr())
+	fmt.Fprintf(cmd.OutOrStdout(), "Point commands at it with: --endpoint http://%s\n", listener.Addr())
+
+	if err := httpServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	ownersfile "github.com/open-sauced/pizza-cli/v2/pkg/codeowners"
 	"github.com/open-sauced/pizza-cli/v2/pkg/config"
 )
 
@@ -78,21 +79,17 @@ func writeGitHubCodeownersChunk(authorStats AuthorStats, config *config.Spec, fi
 	topContributors := getTopContributorAttributions(authorStats, 3, config)
 
 	resultSlice := []string{}
+	owners := []string{}
 	for _, contributor := range topContributors {
 		resultSlice = append(resultSlice, contributor.GitHubAlias)
+		owners = append(owners, "@"+contributor.GitHubAlias)
 	}
 
-	if len(topContributors) > 0 {
-		_, err := fmt.Fprintf(file, "%s @%s\n", cleanFilename(srcFilename), strings.Join(resultSlice, " @"))
-		if err != nil {
-			return nil, fmt.Errorf("error writing to %s file: %w", outputPath, err)
-		}
-	} else {
-		// no code owners to attribute to file
-		_, err := fmt.Fprintf(file, "%s\n", cleanFilename(srcFilename))
-		if err != nil {
-			return nil, fmt.Errorf("error writing to %s file: %w", outputPath, err)
-		}
+	// files without any code owners to attribute are written as a bare pattern
+	rule := ownersfile.NewRule(cleanFilename(srcFilename), owners)
+	_, err := fmt.Fprintf(file, "%s\n", rule)
+	if err != nil {
+		return nil, fmt.Errorf("error writing to %s file: %w", outputPath, err)
 	}
 
 	return resultSlice, nil
@@ -129,13 +126,10 @@ func getTopContributorAttributions(authorStats AuthorStats, n int, config *confi
 
 	for i := 0; i < len(sortedAuthorStats) && i < n; i++ {
 		// get attributions for email / github handles
-		for username, emails := range config.Attributions {
-			for _, email := range emails {
-				if email == sortedAuthorStats[i].Email {
-					sortedAuthorStats[i].GitHubAlias = username
-					topContributors = append(topContributors, sortedAuthorStats[i])
-				}
-			}
+		for _, username := range config.AttributedNames(sortedAuthorStats[i].Email) {
+			attributed := *sortedAuthorStats[i]
+			attributed.GitHubAlias = username
+			topContributors = append(topContributors, &attributed)
 		}
 	}
 
@@ -5,14 +5,19 @@ import (
 	"sort"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
 )
 
 // FileStats is a mapping of filenames to author stats.
 // Example: { "path/to/file": { Author stats }}
 type FileStats map[string]AuthorStats
 
-func (fs FileStats) addStat(filestat *object.FileStat, commit *object.Commit) {
-	author := fmt.Sprintf("%s <%s>", commit.Author.Name, commit.Author.Email)
+// addStat adds the lines changed in a file to the stats of the commit author.
+// The author's name and email are first mapped through the repository's ".mailmap".
+func (fs FileStats) addStat(filestat *object.FileStat, commit *object.Commit, mailmap *config.Mailmap) {
+	name, email := mailmap.Lookup(commit.Author.Name, commit.Author.Email)
+	author := fmt.Sprintf("%s <%s>", name, email)
 	filename := filestat.Name
 
 	if _, ok := fs[filename]; !ok {
@@ -21,8 +26,8 @@ func (fs FileStats) addStat(filestat *object.FileStat, commit *object.Commit) {
 
 	if _, ok := fs[filename][author]; !ok {
 		fs[filename][author] = &CodeownerStat{
-			Name:  commit.Author.Name,
-			Email: commit.Author.Email,
+			Name:  name,
+			Email: email,
 		}
 	}
 
@@ -0,0 +1,104 @@
+package codeowners
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/jpmcb/gopherlogs"
+
+	ownersfile "github.com/open-sauced/pizza-cli/v2/pkg/codeowners"
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+)
+
+// Successors ranks the contributors to promote in place of offboarded owners
+// by the lines they changed in the git history
+type Successors struct {
+	fileStats FileStats
+	spec      *config.Spec
+	exclude   []string
+	n         int
+}
+
+// FindSuccessors traverses the git history of the repository at the given path
+// to find the successors of owners. Up to n attributed owners are returned for
+// each pattern and any excluded GitHub aliases are skipped so the next highest
+// contributors are promoted in their place.
+func FindSuccessors(repoPath string, previousDays int, spec *config.Spec, exclude []string, n int, logger gopherlogs.Logger) (*Successors, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening repo: %w", err)
+	}
+
+	processOptions := ProcessOptions{
+		repo,
+		previousDays,
+		repoPath,
+		logger,
+	}
+
+	fileStats, err := processOptions.process()
+	if err != nil {
+		return nil, fmt.Errorf("error traversing git log: %w", err)
+	}
+
+	return &Successors{
+		fileStats: fileStats,
+		spec:      spec,
+		exclude:   exclude,
+		n:         n,
+	}, nil
+}
+
+// For returns the attributed owners to promote for a CODEOWNERS pattern,
+// ranked by the lines changed across every file the pattern matches
+func (s *Successors) For(pattern string) []string {
+	match := ownersfile.Matcher(pattern)
+
+	combined := make(AuthorStats)
+	for filename, authorStats := range s.fileStats {
+		if !match(filename) {
+			continue
+		}
+
+		for author, stat := range authorStats {
+			if _, ok := combined[author]; !ok {
+				combined[author] = &CodeownerStat{
+					Name:  stat.Name,
+					Email: stat.Email,
+				}
+			}
+
+			combined[author].Lines += stat.Lines
+		}
+	}
+
+	return getTopAttributedOwners(combined, s.n, s.spec, s.exclude)
+}
+
+// getTopAttributedOwners returns up to n GitHub aliases attributed in the config,
+// ordered by lines changed, skipping any excluded aliases
+func getTopAttributedOwners(authorStats AuthorStats, n int, spec *config.Spec, exclude []string) []string {
+	var owners []string
+
+	for _, stat := range authorStats.ToSortedSlice() {
+		if len(owners) >= n {
+			break
+		}
+
+		// The author is promoted under their first attributed name that isn't excluded
+		for _, username := range spec.AttributedNames(stat.Email) {
+			if slices.Contains(exclude, username) {
+				continue
+			}
+
+			if !slices.Contains(owners, username) {
+				owners = append(owners, username)
+			}
+
+			break
+		}
+	}
+
+	return owners
+}
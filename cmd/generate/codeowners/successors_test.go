@@ -0,0 +1,77 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+)
+
+func TestGetTopAttributedOwners(t *testing.T) {
+	t.Parallel()
+
+	configSpec := config.Spec{
+		Attributions: map[string][]string{
+			"jpmcb":          {"john@opensauced.pizza"},
+			"brandonroberts": {"brandon@opensauced.pizza"},
+			"zeucapua":       {"coding@zeu.dev"},
+		},
+	}
+
+	authorStats := AuthorStats{
+		"john":    {Email: "john@opensauced.pizza", Lines: 50},
+		"unknown": {Email: "unknown@example.com", Lines: 40},
+		"brandon": {Email: "brandon@opensauced.pizza", Lines: 30},
+		"zeu":     {Email: "coding@zeu.dev", Lines: 20},
+	}
+
+	results := getTopAttributedOwners(authorStats, 2, &configSpec, []string{"jpmcb"})
+
+	assert.Equal(t, []string{"brandonroberts", "zeucapua"}, results)
+
+	// Authors attributed to several names are promoted under one that isn't excluded
+	configSpec.Attributions["johncodes"] = []string{"john@opensauced.pizza"}
+	results = getTopAttributedOwners(authorStats, 2, &configSpec, []string{"johncodes"})
+
+	assert.Equal(t, []string{"jpmcb", "brandonroberts"}, results)
+}
+
+func TestSuccessorsFor(t *testing.T) {
+	t.Parallel()
+
+	successors := &Successors{
+		fileStats: FileStats{
+			"src/a.go": {
+				"john":    {Email: "john@opensauced.pizza", Lines: 10},
+				"brandon": {Email: "brandon@opensauced.pizza", Lines: 30},
+			},
+			"src/nested/b.go": {
+				"john": {Email: "john@opensauced.pizza", Lines: 25},
+			},
+			"docs/guides/setup.md": {
+				"zeu": {Email: "coding@zeu.dev", Lines: 5},
+			},
+		},
+		spec: &config.Spec{
+			Attributions: map[string][]string{
+				"jpmcb":          {"john@opensauced.pizza"},
+				"brandonroberts": {"brandon@opensauced.pizza"},
+				"zeucapua":       {"coding@zeu.dev"},
+			},
+		},
+		exclude: []string{"zeucapua"},
+		n:       3,
+	}
+
+	// The lines changed in every matching file are combined
+	assert.Equal(t, []string{"jpmcb", "brandonroberts"}, successors.For("*.go"))
+	assert.Equal(t, []string{"jpmcb", "brandonroberts"}, successors.For("*"))
+	assert.Equal(t, []string{"brandonroberts", "jpmcb"}, successors.For("src/a.go"))
+
+	// Directories match the files in them, excluded owners are skipped
+	assert.Empty(t, successors.For("/docs/"))
+	successors.exclude = nil
+	assert.Equal(t, []string{"zeucapua"}, successors.For("/docs/"))
+	assert.Empty(t, successors.For("/lib/"))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/jpmcb/gopherlogs"
 	"github.com/jpmcb/gopherlogs/pkg/colors"
 
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
 	"github.com/open-sauced/pizza-cli/v2/pkg/logging"
 )
 
@@ -28,6 +29,11 @@ type ProcessOptions struct {
 func (po *ProcessOptions) process() (FileStats, error) {
 	fs := make(FileStats)
 
+	mailmap, err := config.LoadMailmap(po.dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load mailmap: %w", err)
+	}
+
 	// Get the HEAD reference
 	head, err := po.repo.Head()
 	if err != nil {
@@ -75,7 +81,7 @@ func (po *ProcessOptions) process() (FileStats, error) {
 				return nil
 			}
 
-			fs.addStat(&fileStat, commit)
+			fs.addStat(&fileStat, commit, mailmap)
 		}
 
 		return nil
@@ -1,21 +1,27 @@
 package insight
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/jpmcb/gopherlogs"
 	"github.com/jpmcb/gopherlogs/pkg/colors"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/open-sauced/pizza-cli/v2/api"
-	"github.com/open-sauced/pizza-cli/v2/api/auth"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
 	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces"
 	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces/userlists"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	workspacecmd "github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
 	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
 	"github.com/open-sauced/pizza-cli/v2/pkg/logging"
 	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
@@ -23,45 +29,111 @@ import (
 
 // Options for the codeowners generation command
 type Options struct {
-	// the path to the git repository on disk to generate a codeowners file for
+	// the path to the git repository on disk, or to the file of logins, to
+	// gather the GitHub logins from
 	path string
 
+	// the source of the GitHub logins, one of sources
+	from string
+
+	// glob patterns the GitHub logins must match, and must not match
+	include []string
+	exclude []string
+
+	// the number of days of commits the "git-log" source looks back
+	rangeVal int
+
+	// the path to the ".sauced.yaml" config, resolving teams and emails to
+	// GitHub logins, and the loaded config
+	configPath string
+	config     *config.Spec
+
+	// whether to answer yes to the questions instead of asking them
+	yes bool
+
+	// the ID or name of the workspace to add the Contributor Insight to
+	workspace string
+
+	// the name of the Contributor Insight. Defaults to the name of the path
+	listName string
+
+	// whether to make the Contributor Insight public, and whether that was
+	// given with --public. Existing Contributor Insights keep their visibility
+	// otherwise.
+	public    bool
+	setPublic bool
+
 	logger   gopherlogs.Logger
 	tty      bool
 	loglevel int
 
-	token string
+	// whether the questions can be asked on a terminal
+	interactive bool
+
+	// in is where answers are read from, errOut where questions are asked
+	// and logs written, and out where the Contributor Insight is written to
+	in     io.Reader
+	errOut io.Writer
+	out    io.Writer
+
+	// the API client, authenticated as the logged in user
+	apiClient *api.Client
 
 	// telemetry for capturing CLI events via PostHog
 	telemetry *utils.PosthogCliClient
 }
 
-const insightLongDesc string = `Generate an OpenSauced Contributor Insight based on GitHub logins in a CODEOWNERS file
+const insightLongDesc string = `Generate an OpenSauced Contributor Insight based on the GitHub logins owning a repository
 to get metrics and insights on those users.
 
-The provided path must be a local git repo with a valid CODEOWNERS file and GitHub "@login"
-for each codeowner.
+The logins are gathered from the source chosen with --from:
+  codeowners      the CODEOWNERS file in ".github/", the root, or "docs/" of the repository
+  owners          the approvers and reviewers in the Kubernetes style OWNERS files of the
+                  repository, with the aliases of its OWNERS_ALIASES file, or the owners'
+                  emails in OWNERS files generated with --owners-style-file. Vendored
+                  code and dependencies are skipped
+  sauced-config   the attributions in the ".sauced.yaml" config
+  git-log         the authors of the commits in the last --range days
+  file            a yaml list of logins. The path is the path to the file
+
+Teams, like "@org/team", and emails are resolved to GitHub logins with the attributions in
+the ".sauced.yaml" config: the members of a team are the people sharing an email with it.
+Owners that can't be resolved are skipped. Logins can be filtered with --include and
+--exclude glob patterns.
 
 After logging in, the generated Contributor Insight on OpenSauced will have insights on
-active contributors, contributon velocity, and more.`
+active contributors, contributon velocity, and more. The created or updated Contributor
+Insight is written as JSON.
+
+Questions are only asked on a terminal. Use --yes to run without them, as in CI, where
+the "PIZZA_TOKEN" environment variable can be used to log in.`
 
 const insightExamples string = `  # Use CODEOWNERS file in explicit directory
   $ pizza generate insight /path/to/repo
 
   # Use CODEOWNERS file in local directory
-  $ pizza generate insight .`
+  $ pizza generate insight .
+
+  # Use the authors of the last 30 days of commits, without bots
+  $ pizza generate insight . --from git-log --range 30 --exclude '*\[bot\]'
+
+  # Use a yaml list of logins
+  $ pizza generate insight ./maintainers.yaml --from file
+
+  # Update a public Contributor Insight of the "OpenSauced" workspace without any questions
+  $ pizza generate insight . --yes --workspace OpenSauced --list-name Maintainers --public`
 
 func NewGenerateInsightCommand() *cobra.Command {
 	opts := &Options{}
 
 	cmd := &cobra.Command{
-		Use:     "insight path/to/repo/with/CODEOWNERS/file [flags]",
-		Short:   "Generate an OpenSauced Contributor Insight based on GitHub logins in a CODEOWNERS file",
+		Use:     "insight path/to/repo [flags]",
+		Short:   "Generate an OpenSauced Contributor Insight based on the GitHub logins owning a repository",
 		Long:    insightLongDesc,
 		Example: insightExamples,
 		Args: func(_ *cobra.Command, args []string) error {
 			if len(args) != 1 {
-				return errors.New("you must provide exactly one argument: the path to a repository with a codeowners file")
+				return errors.New("you must provide exactly one argument: the path to a repository, or to a file with --from file")
 			}
 
 			path := args[0]
@@ -100,6 +172,16 @@ func NewGenerateInsightCommand() *cobra.Command {
 				opts.loglevel = logging.LogDebug
 			}
 
+			opts.configPath, _ = cmd.Flags().GetString("config")
+			opts.setPublic = cmd.Flags().Changed("public")
+
+			// converts the uintptr to the system file descriptor integer
+			//nolint:gosec
+			opts.interactive = term.IsTerminal(int(os.Stdin.Fd()))
+			opts.in = cmd.InOrStdin()
+			opts.errOut = cmd.ErrOrStderr()
+			opts.out = cmd.OutOrStdout()
+
 			err = run(opts, cmd)
 
 			_ = opts.telemetry.Done()
@@ -108,56 +190,75 @@ func NewGenerateInsightCommand() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVarP(&opts.yes, constants.FlagNameYes, "y", false, "Answer yes to the questions instead of asking them")
+	cmd.Flags().StringVarP(&opts.workspace, constants.FlagNameWorkspace, "w", workspacecmd.DefaultName, "The ID or name of the workspace. The \"Pizza CLI\" workspace is created when it doesn't exist")
+	cmd.Flags().StringVar(&opts.listName, "list-name", "", "The name of the Contributor Insight. Defaults to the name of the repository directory")
+	cmd.Flags().BoolVar(&opts.public, "public", false, "Make the Contributor Insight public, or private with --public=false. Existing Contributor Insights keep their visibility without it")
+	cmd.Flags().StringVar(&opts.from, "from", sourceCodeowners, fmt.Sprintf("Where to gather the GitHub logins from. One of: (%s)", strings.Join(sources, ", ")))
+	cmd.Flags().StringSliceVar(&opts.include, "include", nil, "Only add the GitHub logins matching one of these glob patterns")
+	cmd.Flags().StringSliceVar(&opts.exclude, "exclude", nil, "Don't add the GitHub logins matching one of these glob patterns, like \"*\\[bot\\]\" for bots")
+	cmd.Flags().IntVarP(&opts.rangeVal, constants.FlagNameRange, "r", 90, "The number of days of commits the \"git-log\" source looks back")
+
 	return cmd
 }
 
-func run(opts *Options, _ *cobra.Command) error {
+func run(opts *Options, cmd *cobra.Command) error {
 	var err error
 	opts.logger, err = gopherlogs.NewLogger(
 		gopherlogs.WithLogVerbosity(opts.loglevel),
 		gopherlogs.WithTty(!opts.tty),
+		gopherlogs.WithOutputWriter(opts.errOut),
 	)
 	if err != nil {
 		return fmt.Errorf("could not build logger: %w", err)
 	}
 	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Built logger with log level: %d\n", opts.loglevel)
 
-	codeowners, err := getUniqueCodeowners(filepath.Join(opts.path, "CODEOWNERS"))
+	if err := validateOptions(opts); err != nil {
+		return err
+	}
+
+	opts.config, err = loadConfig(opts)
 	if err != nil {
-		return fmt.Errorf("could not get codeowners from file: %s - %w", opts.path, err)
+		return err
 	}
 
-	// 1. Ask if they want to add users to a list
-	var input string
-	fmt.Print("Do you want to add these codeowners to an OpenSauced Contributor Insight? (y/n): ")
-	_, err = fmt.Scanln(&input)
+	logins, err := gatherLogins(opts)
 	if err != nil {
-		return fmt.Errorf("could not scan input from terminal: %w", err)
+		return fmt.Errorf("could not get GitHub logins from %s: %w", opts.path, err)
+	}
+	if len(logins) == 0 {
+		return fmt.Errorf("no GitHub logins found in %s with --from %s", opts.path, opts.from)
 	}
+	opts.logger.V(logging.LogInfo).Infof("Found GitHub logins: %v\n", logins)
 
-	switch input {
-	case "y", "Y", "yes":
-		opts.logger.V(logging.LogInfo).Style(0, colors.FgGreen).Infof("Adding codeowners to Contributor Insight\n")
-	case "n", "N", "no":
-		return nil
-	default:
-		return errors.New("invalid answer. Please enter y or n")
+	// 1. Ask if they want to add users to a list
+	ok, err := opts.confirm(fmt.Sprintf("Do you want to add these %d contributors to an OpenSauced Contributor Insight?", len(logins)))
+	if err != nil || !ok {
+		return err
 	}
+	opts.logger.V(logging.LogInfo).Style(0, colors.FgGreen).Infof("Adding contributors to Contributor Insight\n")
 
 	// 2. Check if user is logged in. Log them in if not.
-	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Initiating log in flow\n")
-	authenticator := auth.NewAuthenticator()
-	err = authenticator.CheckSession()
-	if err != nil {
-		opts.logger.V(logging.LogInfo).Style(0, colors.FgRed).Infof("Log in session invalid: %s\n", err)
-		fmt.Print("Do you want to log into OpenSauced? (y/n): ")
-		_, err := fmt.Scanln(&input)
+	if !authcmd.HasEnvToken() {
+		opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Initiating log in flow\n")
+		authenticator, err := authcmd.NewAuthenticator(cmd)
 		if err != nil {
-			return fmt.Errorf("could not scan input from terminal: %w", err)
+			return err
 		}
 
-		switch input {
-		case "y", "Y", "yes":
+		err = authenticator.CheckSession()
+		if err != nil {
+			opts.logger.V(logging.LogInfo).Style(0, colors.FgRed).Infof("Log in session invalid: %s\n", err)
+			if !opts.interactive {
+				return errors.New("not logged in: log in with \"pizza login\" or set the PIZZA_TOKEN environment variable")
+			}
+
+			ok, err := opts.confirm("Do you want to log into OpenSauced?")
+			if err != nil || !ok {
+				return err
+			}
+
 			user, err := authenticator.Login()
 			if err != nil {
 				_ = opts.telemetry.CaptureFailedCodeownersGenerateAuth()
@@ -166,114 +267,196 @@ func run(opts *Options, _ *cobra.Command) error {
 			}
 			_ = opts.telemetry.CaptureCodeownersGenerateAuth(user)
 			opts.logger.V(logging.LogInfo).Style(0, colors.FgGreen).Infof("Logged in as: %s\n", user)
-
-		case "n", "N", "no":
-			return nil
-
-		default:
-			return errors.New("invalid answer. Please enter y or n")
 		}
 	}
 
-	opts.token, err = authenticator.GetSessionToken()
+	opts.apiClient, err = authcmd.NewAPIClient(cmd)
 	if err != nil {
-		_ = opts.telemetry.CaptureFailedCodeownersGenerateContributorInsight()
-		opts.logger.V(logging.LogInfo).Style(0, colors.FgRed).Infof("Error getting session token\n")
-		return fmt.Errorf("could not get session token: %w", err)
+		return err
 	}
 
-	listName := filepath.Base(opts.path)
-
-	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Looking up OpenSauced workspace: Pizza CLI\n")
-	workspace, err := findCreatePizzaCliWorkspace(opts)
+	insight, err := generateInsight(cmd.Context(), opts, logins)
 	if err != nil {
 		_ = opts.telemetry.CaptureFailedCodeownersGenerateContributorInsight()
-		opts.logger.V(logging.LogInfo).Style(0, colors.FgRed).Infof("Error finding Workspace: Pizza CLI\n")
-		return fmt.Errorf("could not find Pizza CLI workspace: %w", err)
+		return err
 	}
-	opts.logger.V(logging.LogDebug).Style(0, colors.FgGreen).Infof("Found workspace: Pizza CLI\n")
+	opts.logger.V(logging.LogInfo).Style(0, colors.FgCyan).Infof("\nAccess Contributor Insight on OpenSauced:\n%s\n", insight.URL)
+	_ = opts.telemetry.CaptureCodeownersGenerateContributorInsight()
 
-	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Looking up Contributor Insight for local repository: %s\n", listName)
-	userList, err := updateCreateLocalWorkspaceUserList(opts, listName, workspace, codeowners)
+	output, err := utils.OutputJSON(insight)
 	if err != nil {
-		_ = opts.telemetry.CaptureFailedCodeownersGenerateContributorInsight()
-		opts.logger.V(logging.LogInfo).Style(0, colors.FgRed).Infof("Error finding Workspace Contributor Insight: %s\n", listName)
-		return fmt.Errorf("could not find Workspace Contributor Insight: %s - %w", listName, err)
+		return err
 	}
-	opts.logger.V(logging.LogDebug).Style(0, colors.FgGreen).Infof("Updated Contributor Insight for local repository: %s\n", listName)
-	opts.logger.V(logging.LogInfo).Style(0, colors.FgCyan).Infof("\nAccess Contributor Insight on OpenSauced:\n%s\n", fmt.Sprintf("https://app.opensauced.pizza/workspaces/%s/contributor-insights/%s", workspace.ID, userList.ID))
-	_ = opts.telemetry.CaptureCodeownersGenerateContributorInsight()
 
+	fmt.Fprintln(opts.out, output)
 	return nil
 }
 
-func getUniqueCodeowners(path string) ([]string, error) {
-	file, err := os.Open(path)
+// validateOptions checks the source and filters of the GitHub logins
+func validateOptions(opts *Options) error {
+	if !slices.Contains(sources, opts.from) {
+		return fmt.Errorf("unknown source %q for --from, expected one of: %s", opts.from, strings.Join(sources, ", "))
+	}
+	if opts.from == sourceGitLog && opts.rangeVal < 1 {
+		return fmt.Errorf("--%s must be at least 1, got %d", constants.FlagNameRange, opts.rangeVal)
+	}
+
+	info, err := os.Stat(opts.path)
+	if err != nil {
+		return err
+	}
+	if opts.from == sourceFile && info.IsDir() {
+		return fmt.Errorf("%s is a directory, --from %s needs the path to a yaml file of logins", opts.path, sourceFile)
+	}
+	if opts.from != sourceFile && !info.IsDir() {
+		return fmt.Errorf("%s is not a directory, use --from %s for a yaml file of logins", opts.path, sourceFile)
+	}
+
+	if err := validatePatterns(opts.include); err != nil {
+		return err
+	}
+
+	return validatePatterns(opts.exclude)
+}
+
+// loadConfig loads the ".sauced.yaml" config given with --config, or the one
+// next to the path. Only the "sauced-config" source requires one, the others
+// resolve no teams and emails without it.
+func loadConfig(opts *Options) (*config.Spec, error) {
+	configPath := opts.configPath
+	if configPath == "" {
+		dir := opts.path
+		if opts.from == sourceFile {
+			dir = filepath.Dir(opts.path)
+		}
+		configPath = filepath.Join(dir, ".sauced.yaml")
+	}
+
+	spec, loadedPath, err := config.LoadConfig(configPath)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file: %w", err)
+		if opts.from == sourceSaucedConfig {
+			return nil, err
+		}
+
+		opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("No config loaded, teams and emails won't be resolved: %s\n", err)
+		return &config.Spec{}, nil
 	}
-	defer file.Close()
 
-	// Create a map to store unique uniqueLogins
-	uniqueLogins := make(map[string]struct{})
+	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Loaded config from: %s\n", loadedPath)
+	return spec, nil
+}
 
-	// Create a regular expression to match GitHub usernames
-	re := regexp.MustCompile(`@(\w+)`)
+// confirm asks the question, unless --yes answers it. Questions can only be
+// asked on a terminal.
+func (opts *Options) confirm(question string) (bool, error) {
+	if opts.yes {
+		return true, nil
+	}
+
+	if !opts.interactive {
+		return false, fmt.Errorf("cannot ask %q as stdin is not a terminal, use --%s to answer yes", question, constants.FlagNameYes)
+	}
 
-	// Read the file line by line
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
+	return utils.Confirm(opts.in, opts.errOut, question)
+}
 
-		// Find all matches in the line
-		matches := re.FindAllStringSubmatch(line, -1)
+// insightOutput is the Contributor Insight created or updated with the codeowners
+type insightOutput struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	WorkspaceID   string   `json:"workspace_id"`
+	WorkspaceName string   `json:"workspace_name"`
+	IsPublic      bool     `json:"is_public"`
+	Created       bool     `json:"created"`
+	Contributors  []string `json:"contributors"`
+	URL           string   `json:"url"`
+}
 
-		// Add each match to the map
-		for _, match := range matches {
-			uniqueLogins[match[1]] = struct{}{}
+// generateInsight creates or updates the Contributor Insight of the workspace
+// with the codeowners
+func generateInsight(ctx context.Context, opts *Options, logins []string) (*insightOutput, error) {
+	listName := opts.listName
+	if listName == "" {
+		listName = filepath.Base(opts.path)
+		if opts.from == sourceFile {
+			listName = strings.TrimSuffix(listName, filepath.Ext(listName))
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Looking up OpenSauced workspace: %s\n", opts.workspace)
+	workspace, err := findWorkspace(ctx, opts)
+	if err != nil {
+		opts.logger.V(logging.LogInfo).Style(0, colors.FgRed).Infof("Error finding Workspace: %s\n", opts.workspace)
+		return nil, fmt.Errorf("could not find %s workspace: %w", opts.workspace, err)
+	}
+	opts.logger.V(logging.LogDebug).Style(0, colors.FgGreen).Infof("Found workspace: %s\n", workspace.Name)
+
+	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Looking up Contributor Insight: %s\n", listName)
+	userList, created, err := updateCreateLocalWorkspaceUserList(ctx, opts, listName, workspace, logins)
+	if err != nil {
+		opts.logger.V(logging.LogInfo).Style(0, colors.FgRed).Infof("Error finding Workspace Contributor Insight: %s\n", listName)
+		return nil, fmt.Errorf("could not find Workspace Contributor Insight: %s - %w", listName, err)
+	}
+	opts.logger.V(logging.LogDebug).Style(0, colors.FgGreen).Infof("Updated Contributor Insight: %s\n", listName)
+
+	contributors := make([]string, 0, len(userList.Contributors))
+	for _, contributor := range userList.Contributors {
+		contributors = append(contributors, contributor.Username)
 	}
 
-	logins := []string{}
-	for login := range uniqueLogins {
-		logins = append(logins, login)
+	return &insightOutput{
+		ID:            userList.ID,
+		Name:          userList.Name,
+		WorkspaceID:   workspace.ID,
+		WorkspaceName: workspace.Name,
+		IsPublic:      userList.IsPublic,
+		Created:       created,
+		Contributors:  contributors,
+		URL:           fmt.Sprintf("%s/workspaces/%s/contributor-insights/%s", constants.AppURL, workspace.ID, userList.ID),
+	}, nil
+}
+
+// findWorkspace returns the workspace chosen with --workspace. The "Pizza CLI"
+// workspace is created when it doesn't exist yet.
+func findWorkspace(ctx context.Context, opts *Options) (*workspaces.DbWorkspace, error) {
+	if opts.workspace != workspacecmd.DefaultName {
+		return workspacecmd.FindWorkspace(ctx, opts.apiClient, opts.workspace)
 	}
 
-	fmt.Printf("%v\n", logins)
-	return logins, nil
+	return findCreatePizzaCliWorkspace(ctx, opts)
 }
 
 // findCreatePizzaCliWorkspace finds or creates a "Pizza CLI" workspace
 // for the authenticated user
-func findCreatePizzaCliWorkspace(opts *Options) (*workspaces.DbWorkspace, error) {
-	nextPage := true
-	page := 1
-	apiClient := api.NewClient("https://api.opensauced.pizza")
-
-	for nextPage {
+func findCreatePizzaCliWorkspace(ctx context.Context, opts *Options) (*workspaces.DbWorkspace, error) {
+	var found *workspaces.DbWorkspace
+	err := services.ForEach(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]workspaces.DbWorkspace, services.MetaData, error) {
 		opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Query user workspaces page: %d\n", page)
-		workspaceResp, _, err := apiClient.WorkspacesService.GetWorkspaces(opts.token, page, 100)
+		workspaceResp, _, err := opts.apiClient.WorkspacesService.GetWorkspaces(ctx, page, limit)
 		if err != nil {
-			return nil, err
+			return nil, services.MetaData{}, err
 		}
 
-		for _, workspace := range workspaceResp.Data {
-			if workspace.Name == "Pizza CLI" {
-				opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Found existing workspace named: Pizza CLI\n")
-				return &workspace, nil
-			}
+		return workspaceResp.Data, workspaceResp.Meta, nil
+	}, func(workspace workspaces.DbWorkspace) error {
+		if workspace.Name != workspacecmd.DefaultName {
+			return nil
 		}
 
-		nextPage = workspaceResp.Meta.HasNextPage
-		page++
+		opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Found existing workspace named: %s\n", workspacecmd.DefaultName)
+		found = &workspace
+		return services.ErrStopIteration
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if found != nil {
+		return found, nil
 	}
 
-	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Creating new user workspace: Pizza CLI\n")
-	newWorkspace, _, err := apiClient.WorkspacesService.CreateWorkspaceForUser(opts.token, "Pizza CLI", "A workspace for the Pizza CLI", []string{})
+	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Creating new user workspace: %s\n", workspacecmd.DefaultName)
+	newWorkspace, _, err := opts.apiClient.WorkspacesService.CreateWorkspaceForUser(ctx, workspacecmd.DefaultName, "A workspace for the Pizza CLI", []string{})
 	if err != nil {
 		return nil, err
 	}
@@ -282,51 +465,54 @@ func findCreatePizzaCliWorkspace(opts *Options) (*workspaces.DbWorkspace, error)
 }
 
 // updateCreateLocalWorkspaceUserList updates or creates a workspace contributor list
-// for the authenticated user with the given codeowners
-func updateCreateLocalWorkspaceUserList(opts *Options, listName string, workspace *workspaces.DbWorkspace, logins []string) (*userlists.DbUserList, error) {
-	nextPage := true
-	page := 1
-	apiClient := api.NewClient("https://api.opensauced.pizza")
-
+// for the authenticated user with the given codeowners, and reports whether it
+// was created. The visibility of existing lists is only changed with --public.
+func updateCreateLocalWorkspaceUserList(ctx context.Context, opts *Options, listName string, workspace *workspaces.DbWorkspace, logins []string) (*userlists.DbUserList, bool, error) {
 	var targetUserListID string
 
-	for nextPage {
+	err := services.ForEach(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]userlists.DbUserList, services.MetaData, error) {
 		opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Query user Workspace Contributor Insight page: %d\n", page)
-		userListsResp, _, err := apiClient.WorkspacesService.UserListService.GetUserLists(opts.token, workspace.ID, page, 100)
+		userListsResp, _, err := opts.apiClient.WorkspacesService.UserListService.GetUserLists(ctx, workspace.ID, page, limit)
 		if err != nil {
-			return nil, err
+			return nil, services.MetaData{}, err
 		}
 
-		nextPage = userListsResp.Meta.HasNextPage
-		page++
-
-		for _, userList := range userListsResp.Data {
-			if userList.Name == listName {
-				opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Found existing Workspace Contributor Insight named: %s\n", listName)
-				targetUserListID = userList.ID
-				nextPage = false
-			}
+		return userListsResp.Data, userListsResp.Meta, nil
+	}, func(userList userlists.DbUserList) error {
+		if userList.Name != listName {
+			return nil
 		}
-	}
 
-	if targetUserListID == "" {
-		var err error
+		opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Found existing Workspace Contributor Insight named: %s\n", listName)
+		targetUserListID = userList.ID
+		return services.ErrStopIteration
+	})
+	if err != nil {
+		return nil, false, err
+	}
 
+	created := targetUserListID == ""
+	if created {
 		opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Creating new user Workspace Contributor List: %s\n", listName)
-		createdUserList, _, err := apiClient.WorkspacesService.UserListService.CreateUserListForUser(opts.token, workspace.ID, listName, []string{})
+		createdUserList, _, err := opts.apiClient.WorkspacesService.UserListService.CreateUserListForUser(ctx, workspace.ID, listName, []string{}, opts.public)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		targetUserListID = createdUserList.UserListID
 	}
 
-	targetUserList, _, err := apiClient.WorkspacesService.UserListService.GetUserList(opts.token, workspace.ID, targetUserListID)
+	targetUserList, _, err := opts.apiClient.WorkspacesService.UserListService.GetUserList(ctx, workspace.ID, targetUserListID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	isPublic := targetUserList.IsPublic
+	if opts.setPublic {
+		isPublic = opts.public
 	}
 
 	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Updating Contributor Insight with codeowners with GitHub aliases: %v\n", logins)
-	userlist, _, err := apiClient.WorkspacesService.UserListService.PatchUserListForUser(opts.token, workspace.ID, targetUserList.ID, targetUserList.Name, logins)
-	return userlist, err
+	userlist, _, err := opts.apiClient.WorkspacesService.UserListService.PatchUserListForUser(ctx, workspace.ID, targetUserList.ID, targetUserList.Name, logins, isPublic)
+	return userlist, created, err
 }
@@ -0,0 +1,97 @@
+package insight
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/jpmcb/gopherlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/api/mock/server"
+	workspacecmd "github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+)
+
+// newTestOptions returns options for the repository at the path, with an API
+// client for a mock server serving the sample dataset
+func newTestOptions(t *testing.T, path string) *Options {
+	logger, err := gopherlogs.NewLogger(gopherlogs.WithOutputWriter(io.Discard))
+	require.NoError(t, err)
+
+	return &Options{
+		path:      path,
+		workspace: workspacecmd.DefaultName,
+		logger:    logger,
+		apiClient: server.NewTestClient(t, server.SampleDataset(), "token"),
+	}
+}
+
+func TestGenerateInsight(t *testing.T) {
+	t.Parallel()
+	opts := newTestOptions(t, "/code/pizza-cli")
+	ctx := context.Background()
+
+	// The "Pizza CLI" workspace and a list named after the repository are created
+	insight, err := generateInsight(ctx, opts, []string{"jpmcb", "zeucapua"})
+	require.NoError(t, err)
+	assert.True(t, insight.Created)
+	assert.Equal(t, "pizza-cli", insight.Name)
+	assert.Equal(t, "Pizza CLI", insight.WorkspaceName)
+	assert.False(t, insight.IsPublic)
+	assert.Equal(t, []string{"jpmcb", "zeucapua"}, insight.Contributors)
+	assert.Equal(t, "https://app.opensauced.pizza/workspaces/"+insight.WorkspaceID+"/contributor-insights/"+insight.ID, insight.URL)
+
+	// Running again updates them
+	opts.public, opts.setPublic = true, true
+	updated, err := generateInsight(ctx, opts, []string{"jpmcb"})
+	require.NoError(t, err)
+	assert.False(t, updated.Created)
+	assert.Equal(t, insight.ID, updated.ID)
+	assert.Equal(t, insight.WorkspaceID, updated.WorkspaceID)
+	assert.True(t, updated.IsPublic)
+	assert.Equal(t, []string{"jpmcb"}, updated.Contributors)
+
+	// Lists keep their visibility without --public
+	opts.public, opts.setPublic = false, false
+	updated, err = generateInsight(ctx, opts, []string{"jpmcb"})
+	require.NoError(t, err)
+	assert.True(t, updated.IsPublic)
+
+	// and are made private with --public=false
+	opts.public, opts.setPublic = false, true
+	updated, err = generateInsight(ctx, opts, []string{"jpmcb"})
+	require.NoError(t, err)
+	assert.False(t, updated.IsPublic)
+}
+
+func TestGenerateInsightWorkspace(t *testing.T) {
+	t.Parallel()
+	opts := newTestOptions(t, "/code/pizza-cli")
+	opts.workspace = "OpenSauced"
+	opts.listName = "Maintainers"
+
+	insight, err := generateInsight(context.Background(), opts, []string{"bdougie"})
+	require.NoError(t, err)
+	assert.False(t, insight.Created)
+	assert.Equal(t, "9c2d4e6f-1a3b-4c5d-8e7f-0a1b2c3d4e5f", insight.ID)
+	assert.Equal(t, []string{"bdougie"}, insight.Contributors)
+
+	// Other workspaces than "Pizza CLI" must exist
+	opts.workspace = "Missing"
+	_, err = generateInsight(context.Background(), opts, []string{"bdougie"})
+	require.EqualError(t, err, `could not find Missing workspace: no workspace with the ID or name "Missing"`)
+}
+
+func TestConfirm(t *testing.T) {
+	t.Parallel()
+
+	opts := &Options{}
+	_, err := opts.confirm("Continue?")
+	require.EqualError(t, err, `cannot ask "Continue?" as stdin is not a terminal, use --yes to answer yes`)
+
+	opts.yes = true
+	ok, err := opts.confirm("Continue?")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
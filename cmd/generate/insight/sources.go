@@ -0,0 +1,302 @@
+package insight
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jpmcb/gopherlogs/pkg/colors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/codeowners"
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+	"github.com/open-sauced/pizza-cli/v2/pkg/logging"
+	"github.com/open-sauced/pizza-cli/v2/pkg/owners"
+)
+
+// The sources the GitHub logins of a Contributor Insight can be gathered from
+const (
+	sourceCodeowners   = "codeowners"
+	sourceOwners       = "owners"
+	sourceSaucedConfig = "sauced-config"
+	sourceGitLog       = "git-log"
+	sourceFile         = "file"
+)
+
+var sources = []string{sourceCodeowners, sourceOwners, sourceSaucedConfig, sourceGitLog, sourceFile}
+
+// codeownersPaths are where GitHub looks for a CODEOWNERS file, in order
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// skippedDirs are the directories not searched for OWNERS files: git's own and
+// those holding vendored code or dependencies
+var skippedDirs = []string{".git", "vendor", "node_modules"}
+
+// gatherLogins returns the sorted GitHub logins of the source chosen with
+// --from. Teams and emails are resolved to logins with the ".sauced.yaml"
+// attributions and the include and exclude filters are applied.
+func gatherLogins(opts *Options) ([]string, error) {
+	var entries []string
+	var err error
+
+	switch opts.from {
+	case sourceCodeowners:
+		entries, err = codeownersEntries(opts.path)
+	case sourceOwners:
+		entries, err = ownersEntries(opts.path)
+	case sourceSaucedConfig:
+		entries, err = saucedConfigEntries(opts.config)
+	case sourceGitLog:
+		entries, err = gitLogEntries(opts.path, opts.rangeVal)
+	case sourceFile:
+		entries, err = fileEntries(opts.path)
+	default:
+		err = fmt.Errorf("unknown source %q, expected one of: %s", opts.from, strings.Join(sources, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var logins []string
+	for _, entry := range entries {
+		for _, login := range resolveEntry(opts, entry) {
+			if !slices.ContainsFunc(logins, func(other string) bool { return strings.EqualFold(other, login) }) && opts.keepLogin(login) {
+				logins = append(logins, login)
+			}
+		}
+	}
+
+	slices.SortFunc(logins, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return logins, nil
+}
+
+// resolveEntry returns the GitHub logins of an owner: the members of teams
+// and the login attributed to emails. Owners that can't be resolved are
+// logged and skipped.
+func resolveEntry(opts *Options, entry string) []string {
+	switch {
+	case codeowners.IsEmail(entry):
+		if login, ok := opts.config.ResolveIdentity(entry); ok {
+			return []string{login}
+		}
+		if login := config.NoreplyLogin(entry); login != "" {
+			return []string{login}
+		}
+
+		opts.logger.V(logging.LogWarn).Style(0, colors.FgYellow).Infof("Skipping %s: no GitHub login is attributed to it\n", entry)
+		return nil
+	case config.IsTeamName(entry):
+		members := opts.config.TeamMembers(entry)
+		if len(members) == 0 {
+			opts.logger.V(logging.LogWarn).Style(0, colors.FgYellow).Infof("Skipping team %s: its members aren't attributed in the config\n", entry)
+		}
+
+		return members
+	default:
+		return []string{codeowners.Login(entry)}
+	}
+}
+
+// keepLogin applies the --include and --exclude filters to a login
+func (opts *Options) keepLogin(login string) bool {
+	if len(opts.include) > 0 && !matchesAny(opts.include, login) {
+		return false
+	}
+
+	return !matchesAny(opts.exclude, login)
+}
+
+// matchesAny checks if the login matches one of the glob patterns, case
+// insensitively
+func matchesAny(patterns []string, login string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(login)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validatePatterns checks the --include and --exclude glob patterns
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid login pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// codeownersEntries returns the owners in the repository's CODEOWNERS file
+func codeownersEntries(repoPath string) ([]string, error) {
+	for _, name := range codeownersPaths {
+		file, err := os.Open(filepath.Join(repoPath, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error opening %s: %w", name, err)
+		}
+		defer file.Close()
+
+		parsed, err := codeowners.Parse(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", name, err)
+		}
+
+		return parsed.Owners(), nil
+	}
+
+	return nil, fmt.Errorf("no CODEOWNERS file in %s, looked for %s", repoPath, strings.Join(codeownersPaths, ", "))
+}
+
+// ownersEntries returns the approvers and reviewers in every OWNERS file of
+// the repository, with the aliases of its OWNERS_ALIASES file expanded. The
+// emails of the owners are returned for OWNERS files generated with "pizza
+// generate codeowners --owners-style-file".
+func ownersEntries(repoPath string) ([]string, error) {
+	var aliases owners.Aliases
+	if file, err := os.Open(filepath.Join(repoPath, "OWNERS_ALIASES")); err == nil {
+		defer file.Close()
+
+		aliases, err = owners.ParseAliases(file)
+		if err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error opening OWNERS_ALIASES: %w", err)
+	}
+
+	var entries []string
+	found := false
+	err := filepath.WalkDir(repoPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && slices.Contains(skippedDirs, d.Name()) {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() != "OWNERS" {
+			return nil
+		}
+
+		found = true
+		contents, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("error opening %s: %w", filePath, err)
+		}
+
+		if owners.IsGenerated(string(contents)) {
+			generated, err := owners.ParseGenerated(string(contents))
+			if err != nil {
+				return fmt.Errorf("error reading %s: %w", filePath, err)
+			}
+
+			entries = append(entries, generated.Owners()...)
+			return nil
+		}
+
+		parsed, err := owners.Parse(bytes.NewReader(contents))
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filePath, err)
+		}
+
+		entries = append(entries, aliases.Expand(parsed.Owners())...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("no OWNERS file in %s", repoPath)
+	}
+
+	return entries, nil
+}
+
+// saucedConfigEntries returns the people with attributions in the config and
+// its attribution fallback
+func saucedConfigEntries(spec *config.Spec) ([]string, error) {
+	var entries []string
+	for name := range spec.Attributions {
+		if !config.IsTeamName(name) {
+			entries = append(entries, name)
+		}
+	}
+	entries = append(entries, spec.AttributionFallback...)
+
+	if len(entries) == 0 {
+		return nil, errors.New("the config has no attributions")
+	}
+
+	return entries, nil
+}
+
+// gitLogEntries returns the emails of the authors of the commits in the
+// range, mapped with the repository's ".mailmap"
+func gitLogEntries(repoPath string, rangeVal int) ([]string, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening repository from given path: %w", err)
+	}
+
+	mailmap, err := config.LoadMailmap(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load mailmap: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get repo head: %w", err)
+	}
+
+	since := time.Now().AddDate(0, 0, -rangeVal)
+	commitIter, err := repo.Log(&git.LogOptions{From: head.Hash(), Since: &since})
+	if err != nil {
+		return nil, fmt.Errorf("could not get repo log iterator: %w", err)
+	}
+	defer commitIter.Close()
+
+	var entries []string
+	err = commitIter.ForEach(func(commit *object.Commit) error {
+		_, email := mailmap.Lookup(commit.Author.Name, commit.Author.Email)
+		if !slices.Contains(entries, email) {
+			entries = append(entries, email)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not process commit iterator: %w", err)
+	}
+
+	return entries, nil
+}
+
+// fileEntries returns the owners listed in a YAML file
+func fileEntries(filePath string) ([]string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	var entries []string
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("could not read logins from %s, expected a yaml list: %w", filePath, err)
+	}
+
+	return entries, nil
+}
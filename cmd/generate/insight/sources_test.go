@@ -0,0 +1,192 @@
+package insight
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jpmcb/gopherlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSaucedConfig = `attribution:
+  jpmcb:
+    - john@opensauced.pizza
+  zeucapua:
+    - coding@zeu.dev
+  open-sauced/engineering:
+    - john@opensauced.pizza
+    - coding@zeu.dev
+attribution-fallback:
+  - bdougie
+`
+
+// writeFiles writes the files, given by their path relative to the directory
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+}
+
+// newSourceOptions returns the options gathering logins from the source of
+// the path, with the ".sauced.yaml" config next to it loaded
+func newSourceOptions(t *testing.T, path, from string) *Options {
+	logger, err := gopherlogs.NewLogger(gopherlogs.WithOutputWriter(io.Discard))
+	require.NoError(t, err)
+
+	opts := &Options{path: path, from: from, rangeVal: 90, logger: logger}
+	require.NoError(t, validateOptions(opts))
+
+	opts.config, err = loadConfig(opts)
+	require.NoError(t, err)
+
+	return opts
+}
+
+func TestGatherLoginsCodeowners(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".sauced.yaml": testSaucedConfig,
+		// The CODEOWNERS file in ".github" is the one GitHub uses
+		"CODEOWNERS":         "* @nickytonline\n",
+		".github/CODEOWNERS": "* @open-sauced/engineering\ndocs/ coding@zeu.dev @brandonroberts @open-sauced/design\n*.md unknown@example.com dependabot[bot]\n",
+	})
+
+	opts := newSourceOptions(t, dir, sourceCodeowners)
+	logins, err := gatherLogins(opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"brandonroberts", "dependabot[bot]", "jpmcb", "zeucapua"}, logins)
+
+	opts.exclude = []string{`*\[bot\]`}
+	opts.include = []string{"*a*"}
+	logins, err = gatherLogins(opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"brandonroberts", "zeucapua"}, logins)
+
+	opts = newSourceOptions(t, t.TempDir(), sourceCodeowners)
+	_, err = gatherLogins(opts)
+	require.ErrorContains(t, err, "no CODEOWNERS file in")
+}
+
+func TestGatherLoginsOwners(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"OWNERS_ALIASES": "aliases:\n  cli-leads:\n    - jpmcb\n    - zeucapua\n",
+		"OWNERS":         "approvers:\n  - cli-leads\nemeritus_approvers:\n  - bdougie\n",
+		"docs/OWNERS":    "reviewers:\n  - nickytonline\n",
+
+		// Vendored code and dependencies aren't owned by the repository
+		".git/OWNERS":                  "approvers:\n  - ignored\n",
+		"vendor/github.com/x/OWNERS":   "approvers:\n  - ignored\n",
+		"web/node_modules/left/OWNERS": "approvers:\n  - ignored\n",
+	})
+
+	logins, err := gatherLogins(newSourceOptions(t, dir, sourceOwners))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jpmcb", "nickytonline", "zeucapua"}, logins)
+}
+
+func TestGatherLoginsGeneratedOwners(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".sauced.yaml": testSaucedConfig,
+
+		// As written by "pizza generate codeowners --owners-style-file"
+		"OWNERS": `# This file is generated automatically by OpenSauced pizza-cli. DO NOT EDIT. Stay saucy!
+#
+# Generated with command:
+# $ pizza generate codeowners repo/ --owners-style-file true
+
+README.md
+  - John McBride
+    - john@opensauced.pizza
+  - Zeu Capua
+    - coding@zeu.dev
+src/main.go
+  - Brian Douglas
+    - 5713670+bdougie@users.noreply.github.com
+`,
+	})
+
+	logins, err := gatherLogins(newSourceOptions(t, dir, sourceOwners))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bdougie", "jpmcb", "zeucapua"}, logins)
+}
+
+func TestGatherLoginsSaucedConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{".sauced.yaml": testSaucedConfig})
+
+	logins, err := gatherLogins(newSourceOptions(t, dir, sourceSaucedConfig))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bdougie", "jpmcb", "zeucapua"}, logins)
+}
+
+func TestGatherLoginsGitLog(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".sauced.yaml": testSaucedConfig,
+		".mailmap":     "John McBride <john@opensauced.pizza> <john@personal.com>\n",
+	})
+
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(email string, when time.Time) {
+		_, err := worktree.Commit("commit", &git.CommitOptions{
+			Author:            &object.Signature{Name: "pizza", Email: email, When: when},
+			AllowEmptyCommits: true,
+		})
+		require.NoError(t, err)
+	}
+	commit("bdougie@example.com", time.Now().AddDate(0, 0, -100))
+	commit("john@personal.com", time.Now())
+	commit("12345+nickytonline@users.noreply.github.com", time.Now())
+	commit("unknown@example.com", time.Now())
+
+	logins, err := gatherLogins(newSourceOptions(t, dir, sourceGitLog))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jpmcb", "nickytonline"}, logins)
+}
+
+func TestGatherLoginsFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".sauced.yaml":     testSaucedConfig,
+		"maintainers.yaml": "- \"@jpmcb\"\n- open-sauced/engineering\n- JPMCB\n",
+	})
+
+	opts := newSourceOptions(t, filepath.Join(dir, "maintainers.yaml"), sourceFile)
+	logins, err := gatherLogins(opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jpmcb", "zeucapua"}, logins)
+}
+
+func TestValidateOptions(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	err := validateOptions(&Options{path: dir, from: "github"})
+	require.EqualError(t, err, `unknown source "github" for --from, expected one of: codeowners, owners, sauced-config, git-log, file`)
+
+	err = validateOptions(&Options{path: dir, from: sourceFile})
+	require.EqualError(t, err, dir+" is a directory, --from file needs the path to a yaml file of logins")
+
+	err = validateOptions(&Options{path: dir, from: sourceCodeowners, exclude: []string{"[bot"}})
+	require.EqualError(t, err, `invalid login pattern "[bot": syntax error in pattern`)
+}
@@ -2,9 +2,11 @@ package insights
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
@@ -13,8 +15,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
 	"github.com/open-sauced/pizza-cli/v2/api/services/contributors"
 	apiUtils "github.com/open-sauced/pizza-cli/v2/api/utils"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
 	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
 	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
 )
@@ -35,6 +39,9 @@ type contributorsOptions struct {
 	// Output is the formatting style for command output
 	Output string
 
+	// out is where the insights are written to
+	out io.Writer
+
 	telemetry *utils.PosthogCliClient
 }
 
@@ -47,7 +54,7 @@ func NewContributorsCommand() *cobra.Command {
 		Long:  "Gather insights about contributors of indexed git repositories. This command will show new, recent, alumni, repeat contributors for each git repository",
 		Args: func(cmd *cobra.Command, args []string) error {
 			fileFlag := cmd.Flags().Lookup(constants.FlagNameFile)
-			if !fileFlag.Changed && len(args) == 0 {
+			if fileFlag.Value.String() == "" && len(args) == 0 {
 				return fmt.Errorf("must specify git repository url argument(s) or provide %s flag", fileFlag.Name)
 			}
 			opts.Repos = append(opts.Repos, args...)
@@ -58,12 +65,16 @@ func NewContributorsCommand() *cobra.Command {
 
 			opts.telemetry = utils.NewPosthogCliClient(!disableTelem)
 
-			endpointURL, _ := cmd.Flags().GetString(constants.FlagNameEndpoint)
-			opts.APIClient = api.NewClient(endpointURL)
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
 			output, _ := cmd.Flags().GetString(constants.FlagNameOutput)
 			opts.Output = output
+			opts.out = cmd.OutOrStdout()
 
-			err := opts.run()
+			err = opts.run(cmd.Context())
 
 			if err != nil {
 				_ = opts.telemetry.CaptureInsights()
@@ -81,7 +92,7 @@ func NewContributorsCommand() *cobra.Command {
 	return cmd
 }
 
-func (opts *contributorsOptions) run() error {
+func (opts *contributorsOptions) run(ctx context.Context) error {
 	if !apiUtils.IsValidRange(opts.RangeVal) {
 		return fmt.Errorf("invalid period: %d, accepts (7,30,90)", opts.RangeVal)
 	}
@@ -104,7 +115,7 @@ func (opts *contributorsOptions) run() error {
 			waitGroup.Add(1)
 			go func() {
 				defer waitGroup.Done()
-				allData, err := findAllContributorsInsights(opts, repoURL)
+				allData, err := findAllContributorsInsights(ctx, opts, repoURL)
 				if err != nil {
 					errorChan <- err
 					return
@@ -132,7 +143,7 @@ func (opts *contributorsOptions) run() error {
 			if err != nil {
 				return err
 			}
-			fmt.Println(output)
+			fmt.Fprintln(opts.out, output)
 			return nil
 		}
 	}
@@ -227,13 +238,13 @@ func (cis contributorsInsightsSlice) OutputTable() (string, error) {
 	return strings.Join(tables, separator), nil
 }
 
-func findAllContributorsInsights(opts *contributorsOptions, repoURL string) (*contributorsInsights, error) {
+func findAllContributorsInsights(ctx context.Context, opts *contributorsOptions, repoURL string) (*contributorsInsights, error) {
 	var (
 		waitGroup = new(sync.WaitGroup)
 		errorChan = make(chan error, 4)
 	)
 
-	repo, err := findRepositoryByOwnerAndRepoName(opts.APIClient, repoURL)
+	repo, err := findRepositoryByOwnerAndRepoName(ctx, opts.APIClient, repoURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not get contributors insights for repository %s: %w", repoURL, err)
 	}
@@ -249,12 +260,12 @@ func findAllContributorsInsights(opts *contributorsOptions, repoURL string) (*co
 	waitGroup.Add(1)
 	go func() {
 		defer waitGroup.Done()
-		response, err := findNewRepositoryContributors(opts.APIClient, repo.FullName, opts.RangeVal)
+		response, err := findNewRepositoryContributors(ctx, opts.APIClient, repo.FullName, opts.RangeVal)
 		if err != nil {
 			errorChan <- err
 			return
 		}
-		for _, data := range response.Data {
+		for _, data := range response {
 			repoContributorsInsights.New = append(repoContributorsInsights.New, data.AuthorLogin)
 		}
 	}()
@@ -262,12 +273,12 @@ func findAllContributorsInsights(opts *contributorsOptions, repoURL string) (*co
 	waitGroup.Add(1)
 	go func() {
 		defer waitGroup.Done()
-		response, err := findRecentRepositoryContributors(opts.APIClient, repo.FullName, opts.RangeVal)
+		response, err := findRecentRepositoryContributors(ctx, opts.APIClient, repo.FullName, opts.RangeVal)
 		if err != nil {
 			errorChan <- err
 			return
 		}
-		for _, data := range response.Data {
+		for _, data := range response {
 			repoContributorsInsights.Recent = append(repoContributorsInsights.Recent, data.AuthorLogin)
 		}
 	}()
@@ -275,12 +286,12 @@ func findAllContributorsInsights(opts *contributorsOptions, repoURL string) (*co
 	waitGroup.Add(1)
 	go func() {
 		defer waitGroup.Done()
-		response, err := findAlumniRepositoryContributors(opts.APIClient, repo.FullName, opts.RangeVal)
+		response, err := findAlumniRepositoryContributors(ctx, opts.APIClient, repo.FullName, opts.RangeVal)
 		if err != nil {
 			errorChan <- err
 			return
 		}
-		for _, data := range response.Data {
+		for _, data := range response {
 			repoContributorsInsights.Alumni = append(repoContributorsInsights.Alumni, data.AuthorLogin)
 		}
 	}()
@@ -288,12 +299,12 @@ func findAllContributorsInsights(opts *contributorsOptions, repoURL string) (*co
 	waitGroup.Add(1)
 	go func() {
 		defer waitGroup.Done()
-		response, err := findRepeatRepositoryContributors(opts.APIClient, repo.FullName, opts.RangeVal)
+		response, err := findRepeatRepositoryContributors(ctx, opts.APIClient, repo.FullName, opts.RangeVal)
 		if err != nil {
 			errorChan <- err
 			return
 		}
-		for _, data := range response.Data {
+		for _, data := range response {
 			repoContributorsInsights.Repeat = append(repoContributorsInsights.Repeat, data.AuthorLogin)
 		}
 	}()
@@ -310,38 +321,66 @@ func findAllContributorsInsights(opts *contributorsOptions, repoURL string) (*co
 	return repoContributorsInsights, nil
 }
 
-func findNewRepositoryContributors(apiClient *api.Client, repo string, period int) (*contributors.ContribResponse, error) {
-	response, _, err := apiClient.ContributorService.NewPullRequestContributors([]string{repo}, period)
+func findNewRepositoryContributors(ctx context.Context, apiClient *api.Client, repo string, period int) ([]contributors.DbContributor, error) {
+	data, err := services.All(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]contributors.DbContributor, services.MetaData, error) {
+		response, _, err := apiClient.ContributorService.NewPullRequestContributors(ctx, []string{repo}, period, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return response.Data, response.Meta, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error while calling 'ContributorsService.NewPullRequestContributors' with repository %s': %w", repo, err)
+		return nil, fmt.Errorf("could not get new contributors of repository %s: %w", repo, err)
 	}
 
-	return response, nil
+	return data, nil
 }
 
-func findRecentRepositoryContributors(apiClient *api.Client, repo string, period int) (*contributors.ContribResponse, error) {
-	response, _, err := apiClient.ContributorService.RecentPullRequestContributors([]string{repo}, period)
+func findRecentRepositoryContributors(ctx context.Context, apiClient *api.Client, repo string, period int) ([]contributors.DbContributor, error) {
+	data, err := services.All(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]contributors.DbContributor, services.MetaData, error) {
+		response, _, err := apiClient.ContributorService.RecentPullRequestContributors(ctx, []string{repo}, period, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return response.Data, response.Meta, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error while calling 'ContributorsService.RecentPullRequestContributors' with repository %s': %w", repo, err)
+		return nil, fmt.Errorf("could not get recent contributors of repository %s: %w", repo, err)
 	}
 
-	return response, nil
+	return data, nil
 }
 
-func findAlumniRepositoryContributors(apiClient *api.Client, repo string, period int) (*contributors.ContribResponse, error) {
-	response, _, err := apiClient.ContributorService.AlumniPullRequestContributors([]string{repo}, period)
+func findAlumniRepositoryContributors(ctx context.Context, apiClient *api.Client, repo string, period int) ([]contributors.DbContributor, error) {
+	data, err := services.All(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]contributors.DbContributor, services.MetaData, error) {
+		response, _, err := apiClient.ContributorService.AlumniPullRequestContributors(ctx, []string{repo}, period, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return response.Data, response.Meta, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error while calling 'ContributorsService.AlumniPullRequestContributors' with repository %s': %w", repo, err)
+		return nil, fmt.Errorf("could not get alumni contributors of repository %s: %w", repo, err)
 	}
 
-	return response, nil
+	return data, nil
 }
 
-func findRepeatRepositoryContributors(apiClient *api.Client, repo string, period int) (*contributors.ContribResponse, error) {
-	response, _, err := apiClient.ContributorService.RepeatPullRequestContributors([]string{repo}, period)
+func findRepeatRepositoryContributors(ctx context.Context, apiClient *api.Client, repo string, period int) ([]contributors.DbContributor, error) {
+	data, err := services.All(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]contributors.DbContributor, services.MetaData, error) {
+		response, _, err := apiClient.ContributorService.RepeatPullRequestContributors(ctx, []string{repo}, period, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return response.Data, response.Meta, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error while calling 'ContributorsService.RepeatPullRequestContributors' with repository %s': %w", repo, err)
+		return nil, fmt.Errorf("could not get repeat contributors of repository %s: %w", repo, err)
 	}
 
-	return response, nil
+	return data, nil
 }
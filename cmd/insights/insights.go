@@ -20,5 +20,8 @@ func NewInsightsCommand() *cobra.Command {
 	cmd.AddCommand(NewContributorsCommand())
 	cmd.AddCommand(NewRepositoriesCommand())
 	cmd.AddCommand(NewUserContributionsCommand())
+	cmd.AddCommand(NewPullRequestsCommand())
+	cmd.AddCommand(NewIssuesCommand())
+	cmd.AddCommand(NewUserCommand())
 	return cmd
 }
@@ -0,0 +1,240 @@
+package insights
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/mock"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+// newFixtureClient returns an API client replaying the fixtures in
+// "testdata/fixtures". They are golden data written by hand, not recordings of
+// the OpenSauced API, and the assertions below depend on their exact contents.
+// They are never recorded again, even with PIZZA_RECORD=1: edit them by hand
+// along with the assertions.
+func newFixtureClient() *api.Client {
+	return api.NewClient(constants.EndpointProd, api.WithTransport(mock.NewReplayer("testdata/fixtures")), api.WithMaxRetries(0))
+}
+
+func TestContributorsCommand(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	opts := &contributorsOptions{
+		APIClient: newFixtureClient(),
+		Repos:     []string{"https://github.com/open-sauced/pizza-cli"},
+		RangeVal:  30,
+		Output:    constants.OutputJSON,
+		out:       &out,
+	}
+
+	require.NoError(t, opts.run(context.Background()))
+	assert.JSONEq(t, `[{
+		"repo_url": "https://github.com/open-sauced/pizza-cli",
+		"new": ["zeucapua"],
+		"recent": ["jpmcb", "nickytonline", "zeucapua"],
+		"alumni": ["bdougie"],
+		"repeat": ["jpmcb", "nickytonline"]
+	}]`, out.String())
+}
+
+func TestContributorsCommandNotFound(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	opts := &contributorsOptions{
+		APIClient: newFixtureClient(),
+		Repos:     []string{"https://github.com/open-sauced/missing"},
+		RangeVal:  30,
+		Output:    constants.OutputJSON,
+		out:       &out,
+	}
+
+	err := opts.run(context.Background())
+	require.EqualError(t, err, "could not get contributors insights for repository https://github.com/open-sauced/missing: repository https://github.com/open-sauced/missing is either non-existent, private, or has not been indexed yet")
+	assert.Empty(t, out.String())
+}
+
+func TestRepositoriesCommand(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	opts := &repositoriesOptions{
+		APIClient: newFixtureClient(),
+		Repos:     []string{"https://github.com/open-sauced/pizza-cli"},
+		RangeVal:  30,
+		Output:    constants.OutputJSON,
+		out:       &out,
+	}
+
+	require.NoError(t, opts.run(context.Background()))
+	assert.JSONEq(t, `[{
+		"repo_url": "https://github.com/open-sauced/pizza-cli",
+		"all_pull_requests": 20,
+		"accepted_pull_requests": 14,
+		"spam_pull_requests": 1,
+		"contributors": ["jpmcb", "nickytonline", "zeucapua", "bdougie"]
+	}]`, out.String())
+}
+
+func TestUserContributionsCommand(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	opts := &userContributionsOptions{
+		APIClient: newFixtureClient(),
+		Repos:     []string{"https://github.com/open-sauced/pizza-cli"},
+		Users:     []string{"jpmcb", "zeucapua"},
+		Period:    30,
+		Output:    constants.OuputCSV,
+		Sort:      "total",
+		out:       &out,
+	}
+
+	require.NoError(t, opts.run(context.Background()))
+	assert.Equal(t, `https://github.com/open-sauced/pizza-cli
+User,Total,Commits,PRs Created
+jpmcb,25,20,5
+zeucapua,3,1,2
+
+`, out.String())
+}
+
+func TestPullRequestsCommand(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	opts := &pullRequestsOptions{
+		APIClient: newFixtureClient(),
+		Repos:     []string{"https://github.com/open-sauced/pizza-cli", "https://github.com/open-sauced/app"},
+		RangeVal:  90,
+		Author:    "jpmcb",
+		State:     "merged",
+		Limit:     100,
+		Output:    constants.OuputCSV,
+		out:       &out,
+	}
+
+	require.NoError(t, opts.run(context.Background()))
+	assert.Equal(t, `Repository,Number,Title,Author,State,Draft,Created At,URL
+open-sauced/pizza-cli,8,Add the insights user-contributions command,jpmcb,merged,false,2026-10-17T17:56:39Z,https://github.com/open-sauced/pizza-cli/pull/8
+open-sauced/pizza-cli,5,Paginate contributor insights,jpmcb,merged,false,2026-10-08T17:56:39Z,https://github.com/open-sauced/pizza-cli/pull/5
+open-sauced/pizza-cli,1,Release with goreleaser,jpmcb,merged,false,2026-08-05T17:56:39Z,https://github.com/open-sauced/pizza-cli/pull/1
+
+`, out.String())
+}
+
+func TestPullRequestsCommandLimit(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	opts := &pullRequestsOptions{
+		APIClient: newFixtureClient(),
+		Repos:     []string{"https://github.com/open-sauced/pizza-cli"},
+		RangeVal:  30,
+		State:     "all",
+		Limit:     2,
+		Output:    constants.OutputJSON,
+		out:       &out,
+	}
+
+	require.NoError(t, opts.run(context.Background()))
+	assert.JSONEq(t, `[{
+		"repository": "open-sauced/pizza-cli",
+		"number": 9,
+		"title": "Generate insights from CODEOWNERS",
+		"author": "zeucapua",
+		"state": "open",
+		"draft": true,
+		"created_at": "2026-10-18T17:56:39Z",
+		"url": "https://github.com/open-sauced/pizza-cli/pull/9"
+	}, {
+		"repository": "open-sauced/pizza-cli",
+		"number": 8,
+		"title": "Add the insights user-contributions command",
+		"author": "jpmcb",
+		"state": "merged",
+		"draft": false,
+		"created_at": "2026-10-17T17:56:39Z",
+		"url": "https://github.com/open-sauced/pizza-cli/pull/8"
+	}]`, out.String())
+}
+
+func TestPullRequestsCommandInvalidState(t *testing.T) {
+	t.Parallel()
+
+	opts := &pullRequestsOptions{
+		APIClient: newFixtureClient(),
+		Repos:     []string{"https://github.com/open-sauced/pizza-cli"},
+		RangeVal:  30,
+		State:     "draft",
+		Output:    constants.OutputJSON,
+	}
+
+	require.EqualError(t, opts.run(context.Background()), "invalid state: draft, accepts (open, closed, merged, all)")
+}
+
+func TestIssuesCommand(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	opts := &issuesOptions{
+		APIClient: newFixtureClient(),
+		Repos:     []string{"https://github.com/open-sauced/pizza-cli"},
+		RangeVal:  30,
+		State:     "all",
+		Limit:     100,
+		Output:    constants.OuputCSV,
+		out:       &out,
+	}
+
+	require.NoError(t, opts.run(context.Background()))
+	assert.Equal(t, `Repository,Number,Title,Author,State,Comments,Created At,URL
+open-sauced/pizza-cli,13,Support GitLab repositories,bdougie,open,4,2026-10-16T17:56:39Z,https://github.com/open-sauced/pizza-cli/issues/13
+open-sauced/pizza-cli,12,Cache API responses,jpmcb,open,1,2026-10-10T17:56:39Z,https://github.com/open-sauced/pizza-cli/issues/12
+open-sauced/pizza-cli,11,Output insights as CSV,brandonroberts,closed,6,2026-10-07T17:56:39Z,https://github.com/open-sauced/pizza-cli/issues/11
+
+`, out.String())
+}
+
+func TestUserCommand(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	opts := &userOptions{
+		APIClient: newFixtureClient(),
+		Logins:    []string{"jpmcb", "nickytonline"},
+		RangeVal:  30,
+		Output:    constants.OuputCSV,
+		out:       &out,
+	}
+
+	require.NoError(t, opts.run(context.Background()))
+	assert.Equal(t, `User,Name,OSCR,PRs Created,PRs Reviewed,Issues Created,Comments,Commits,Repositories,Last Contributed
+jpmcb,John McBride,245,2,2,1,0,9,1,2026-10-17
+nickytonline,Nick Taylor,230,2,3,0,0,5,2,2026-10-17
+
+`, out.String())
+}
+
+func TestUserCommandNotFound(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	opts := &userOptions{
+		APIClient: newFixtureClient(),
+		Logins:    []string{"jpmcb", "missing"},
+		RangeVal:  30,
+		Output:    constants.OutputJSON,
+		out:       &out,
+	}
+
+	require.EqualError(t, opts.run(context.Background()), "user missing is either non-existent or has not been indexed yet")
+	assert.Empty(t, out.String())
+}
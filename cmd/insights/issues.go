@@ -0,0 +1,232 @@
+package insights
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	bubblesTable "github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/issues"
+	apiUtils "github.com/open-sauced/pizza-cli/v2/api/utils"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+type issuesOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Repos is the array of git repository urls
+	Repos []string
+
+	// FilePath is the path to yaml file containing an array of git repository urls
+	FilePath string
+
+	// RangeVal is the number of days, used for query filtering
+	RangeVal int
+
+	// Author is the GitHub login of the issues' author to filter for
+	Author string
+
+	// State is the state of the issues to filter for (open, closed, all)
+	State string
+
+	// Limit is the maximum number of issues listed, 0 for all of them
+	Limit int
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the insights are written to
+	out io.Writer
+
+	telemetry *utils.PosthogCliClient
+}
+
+// NewIssuesCommand returns a new cobra command for 'pizza insights issues'
+func NewIssuesCommand() *cobra.Command {
+	opts := &issuesOptions{}
+	cmd := &cobra.Command{
+		Use:   "issues url... [flags]",
+		Short: "List the issues of indexed git repositories",
+		Long:  "List the issues of indexed git repositories, most recent first. Issues can be filtered by author and state",
+		Args: func(cmd *cobra.Command, args []string) error {
+			fileFlag := cmd.Flags().Lookup(constants.FlagNameFile)
+			if fileFlag.Value.String() == "" && len(args) == 0 {
+				return fmt.Errorf("must specify git repository url argument(s) or provide %s flag", fileFlag.Name)
+			}
+			opts.Repos = append(opts.Repos, args...)
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			disableTelem, _ := cmd.Flags().GetBool(constants.FlagNameTelemetry)
+
+			opts.telemetry = utils.NewPosthogCliClient(!disableTelem)
+
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			output, _ := cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.Output = output
+			opts.out = cmd.OutOrStdout()
+
+			err = opts.run(cmd.Context())
+
+			if err == nil {
+				_ = opts.telemetry.CaptureInsights()
+			} else {
+				_ = opts.telemetry.CaptureFailedInsights()
+			}
+
+			_ = opts.telemetry.Done()
+
+			return err
+		},
+	}
+	cmd.Flags().StringVarP(&opts.FilePath, constants.FlagNameFile, "f", "", "Path to yaml file containing an array of git repository urls")
+	cmd.Flags().IntVarP(&opts.RangeVal, constants.FlagNameRange, "r", 30, "Number of days to look-back (7,30,90)")
+	cmd.Flags().StringVarP(&opts.Author, "author", "a", "", "Only list issues by this GitHub user")
+	cmd.Flags().StringVarP(&opts.State, "state", "s", stateAll, "Only list issues in this state. One of: (open, closed, all)")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 100, "Maximum number of issues to list. 0 lists all of them")
+	return cmd
+}
+
+func (opts *issuesOptions) run(ctx context.Context) error {
+	if !apiUtils.IsValidRange(opts.RangeVal) {
+		return fmt.Errorf("invalid period: %d, accepts (7,30,90)", opts.RangeVal)
+	}
+
+	state := opts.State
+	switch state {
+	case stateAll:
+		state = ""
+	case issues.StateOpen, issues.StateClosed:
+	default:
+		return fmt.Errorf("invalid state: %s, accepts (open, closed, all)", opts.State)
+	}
+
+	repos, err := repositoryNames(opts.Repos, opts.FilePath)
+	if err != nil {
+		return err
+	}
+
+	searchOpts := issues.SearchOptions{
+		Repos:  repos,
+		Author: opts.Author,
+		State:  state,
+		Range:  opts.RangeVal,
+	}
+
+	data, err := services.All(ctx, services.PageOptions{MaxItems: opts.Limit}, func(ctx context.Context, page, limit int) ([]issues.DbIssue, services.MetaData, error) {
+		response, _, err := opts.APIClient.IssuesService.SearchIssues(ctx, searchOpts, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return response.Data, response.Meta, nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not get issues of repositories %v: %w", repos, err)
+	}
+
+	insights := make(issueInsightsSlice, 0, len(data))
+	for _, issue := range data {
+		insights = append(insights, issueInsights{
+			Repository: issue.RepoName,
+			Number:     issue.Number,
+			Title:      issue.Title,
+			Author:     issue.AuthorLogin,
+			State:      issue.State,
+			Comments:   issue.Comments,
+			CreatedAt:  issue.CreatedAt,
+			URL:        fmt.Sprintf("https://github.com/%s/issues/%d", issue.RepoName, issue.Number),
+		})
+	}
+
+	output, err := insights.BuildOutput(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(opts.out, output)
+	return nil
+}
+
+type issueInsights struct {
+	Repository string    `json:"repository" yaml:"repository"`
+	Number     int       `json:"number" yaml:"number"`
+	Title      string    `json:"title" yaml:"title"`
+	Author     string    `json:"author" yaml:"author"`
+	State      string    `json:"state" yaml:"state"`
+	Comments   int       `json:"comments" yaml:"comments"`
+	CreatedAt  time.Time `json:"created_at" yaml:"created_at"`
+	URL        string    `json:"url" yaml:"url"`
+}
+
+type issueInsightsSlice []issueInsights
+
+func (iis issueInsightsSlice) BuildOutput(format string) (string, error) {
+	switch format {
+	case constants.OutputTable:
+		return iis.OutputTable(), nil
+	case constants.OutputJSON:
+		return utils.OutputJSON(iis)
+	case constants.OutputYAML:
+		return utils.OutputYAML(iis)
+	case constants.OuputCSV:
+		return iis.OutputCSV()
+	default:
+		return "", fmt.Errorf("unknown output format %s", format)
+	}
+}
+
+func (iis issueInsightsSlice) OutputCSV() (string, error) {
+	b := new(bytes.Buffer)
+	writer := csv.NewWriter(b)
+
+	// write headers
+	err := writer.Write([]string{"Repository", "Number", "Title", "Author", "State", "Comments", "Created At", "URL"})
+	if err != nil {
+		return "", err
+	}
+
+	// write records
+	for _, ii := range iis {
+		err := writer.Write([]string{ii.Repository, strconv.Itoa(ii.Number), ii.Title, ii.Author, ii.State,
+			strconv.Itoa(ii.Comments), ii.CreatedAt.Format(time.RFC3339), ii.URL})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return b.String(), writer.Error()
+}
+
+func (iis issueInsightsSlice) OutputTable() string {
+	rows := make([]bubblesTable.Row, 0, len(iis))
+	for _, ii := range iis {
+		rows = append(rows, bubblesTable.Row{
+			ii.Repository,
+			"#" + strconv.Itoa(ii.Number),
+			truncate(ii.Title, maxTitleWidth),
+			ii.Author,
+			ii.State,
+			strconv.Itoa(ii.Comments),
+			ii.CreatedAt.Format(time.DateOnly),
+		})
+	}
+
+	return utils.OutputTable(rows, tableColumns(rows, "Repository", "Number", "Title", "Author", "State", "Comments", "Created"))
+}
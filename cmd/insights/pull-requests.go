@@ -0,0 +1,298 @@
+package insights
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	bubblesTable "github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/pulls"
+	apiUtils "github.com/open-sauced/pizza-cli/v2/api/utils"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+// stateAll is the "--state" of commands listing items in any state
+const stateAll = "all"
+
+type pullRequestsOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Repos is the array of git repository urls
+	Repos []string
+
+	// FilePath is the path to yaml file containing an array of git repository urls
+	FilePath string
+
+	// RangeVal is the number of days, used for query filtering
+	RangeVal int
+
+	// Author is the GitHub login of the pull requests' author to filter for
+	Author string
+
+	// State is the state of the pull requests to filter for (open, closed, merged, all)
+	State string
+
+	// Limit is the maximum number of pull requests listed, 0 for all of them
+	Limit int
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the insights are written to
+	out io.Writer
+
+	telemetry *utils.PosthogCliClient
+}
+
+// NewPullRequestsCommand returns a new cobra command for 'pizza insights pull-requests'
+func NewPullRequestsCommand() *cobra.Command {
+	opts := &pullRequestsOptions{}
+	cmd := &cobra.Command{
+		Use:     "pull-requests url... [flags]",
+		Aliases: []string{"prs"},
+		Short:   "List the pull requests of indexed git repositories",
+		Long:    "List the pull requests of indexed git repositories, most recent first. Pull requests can be filtered by author and state",
+		Args: func(cmd *cobra.Command, args []string) error {
+			fileFlag := cmd.Flags().Lookup(constants.FlagNameFile)
+			if fileFlag.Value.String() == "" && len(args) == 0 {
+				return fmt.Errorf("must specify git repository url argument(s) or provide %s flag", fileFlag.Name)
+			}
+			opts.Repos = append(opts.Repos, args...)
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			disableTelem, _ := cmd.Flags().GetBool(constants.FlagNameTelemetry)
+
+			opts.telemetry = utils.NewPosthogCliClient(!disableTelem)
+
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			output, _ := cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.Output = output
+			opts.out = cmd.OutOrStdout()
+
+			err = opts.run(cmd.Context())
+
+			if err == nil {
+				_ = opts.telemetry.CaptureInsights()
+			} else {
+				_ = opts.telemetry.CaptureFailedInsights()
+			}
+
+			_ = opts.telemetry.Done()
+
+			return err
+		},
+	}
+	cmd.Flags().StringVarP(&opts.FilePath, constants.FlagNameFile, "f", "", "Path to yaml file containing an array of git repository urls")
+	cmd.Flags().IntVarP(&opts.RangeVal, constants.FlagNameRange, "r", 30, "Number of days to look-back (7,30,90)")
+	cmd.Flags().StringVarP(&opts.Author, "author", "a", "", "Only list pull requests by this GitHub user")
+	cmd.Flags().StringVarP(&opts.State, "state", "s", stateAll, "Only list pull requests in this state. One of: (open, closed, merged, all)")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 100, "Maximum number of pull requests to list. 0 lists all of them")
+	return cmd
+}
+
+func (opts *pullRequestsOptions) run(ctx context.Context) error {
+	if !apiUtils.IsValidRange(opts.RangeVal) {
+		return fmt.Errorf("invalid period: %d, accepts (7,30,90)", opts.RangeVal)
+	}
+
+	state := opts.State
+	switch state {
+	case stateAll:
+		state = ""
+	case pulls.StateOpen, pulls.StateClosed, pulls.StateMerged:
+	default:
+		return fmt.Errorf("invalid state: %s, accepts (open, closed, merged, all)", opts.State)
+	}
+
+	repos, err := repositoryNames(opts.Repos, opts.FilePath)
+	if err != nil {
+		return err
+	}
+
+	searchOpts := pulls.SearchOptions{
+		Repos:  repos,
+		Author: opts.Author,
+		State:  state,
+		Range:  opts.RangeVal,
+	}
+
+	data, err := services.All(ctx, services.PageOptions{MaxItems: opts.Limit}, func(ctx context.Context, page, limit int) ([]pulls.DbPullRequest, services.MetaData, error) {
+		response, _, err := opts.APIClient.PullsService.SearchPullRequests(ctx, searchOpts, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return response.Data, response.Meta, nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not get pull requests of repositories %v: %w", repos, err)
+	}
+
+	insights := make(pullRequestInsightsSlice, 0, len(data))
+	for _, pr := range data {
+		insights = append(insights, newPullRequestInsights(pr))
+	}
+
+	output, err := insights.BuildOutput(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(opts.out, output)
+	return nil
+}
+
+type pullRequestInsights struct {
+	Repository string    `json:"repository" yaml:"repository"`
+	Number     int       `json:"number" yaml:"number"`
+	Title      string    `json:"title" yaml:"title"`
+	Author     string    `json:"author" yaml:"author"`
+	State      string    `json:"state" yaml:"state"`
+	Draft      bool      `json:"draft" yaml:"draft"`
+	CreatedAt  time.Time `json:"created_at" yaml:"created_at"`
+	URL        string    `json:"url" yaml:"url"`
+}
+
+func newPullRequestInsights(pr pulls.DbPullRequest) pullRequestInsights {
+	state := pr.State
+	if pr.IsMerged {
+		state = pulls.StateMerged
+	}
+
+	return pullRequestInsights{
+		Repository: pr.RepoName,
+		Number:     pr.Number,
+		Title:      pr.Title,
+		Author:     pr.AuthorLogin,
+		State:      state,
+		Draft:      pr.IsDraft,
+		CreatedAt:  pr.CreatedAt,
+		URL:        fmt.Sprintf("https://github.com/%s/pull/%d", pr.RepoName, pr.Number),
+	}
+}
+
+type pullRequestInsightsSlice []pullRequestInsights
+
+func (pris pullRequestInsightsSlice) BuildOutput(format string) (string, error) {
+	switch format {
+	case constants.OutputTable:
+		return pris.OutputTable(), nil
+	case constants.OutputJSON:
+		return utils.OutputJSON(pris)
+	case constants.OutputYAML:
+		return utils.OutputYAML(pris)
+	case constants.OuputCSV:
+		return pris.OutputCSV()
+	default:
+		return "", fmt.Errorf("unknown output format %s", format)
+	}
+}
+
+func (pris pullRequestInsightsSlice) OutputCSV() (string, error) {
+	b := new(bytes.Buffer)
+	writer := csv.NewWriter(b)
+
+	// write headers
+	err := writer.Write([]string{"Repository", "Number", "Title", "Author", "State", "Draft", "Created At", "URL"})
+	if err != nil {
+		return "", err
+	}
+
+	// write records
+	for _, pri := range pris {
+		err := writer.Write([]string{pri.Repository, strconv.Itoa(pri.Number), pri.Title, pri.Author, pri.State,
+			strconv.FormatBool(pri.Draft), pri.CreatedAt.Format(time.RFC3339), pri.URL})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return b.String(), writer.Error()
+}
+
+func (pris pullRequestInsightsSlice) OutputTable() string {
+	rows := make([]bubblesTable.Row, 0, len(pris))
+	for _, pri := range pris {
+		state := pri.State
+		if pri.Draft {
+			state += " (draft)"
+		}
+
+		rows = append(rows, bubblesTable.Row{
+			pri.Repository,
+			"#" + strconv.Itoa(pri.Number),
+			truncate(pri.Title, maxTitleWidth),
+			pri.Author,
+			state,
+			pri.CreatedAt.Format(time.DateOnly),
+		})
+	}
+
+	return utils.OutputTable(rows, tableColumns(rows, "Repository", "Number", "Title", "Author", "State", "Created"))
+}
+
+// maxTitleWidth is the width titles are truncated to in tables
+const maxTitleWidth = 60
+
+// repositoryNames returns the sorted full names of the repositories at the
+// given urls, and in the given yaml file
+func repositoryNames(repoURLs []string, filePath string) ([]string, error) {
+	repositories, err := utils.HandleRepositoryValues(repoURLs, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(repositories))
+	for repoURL := range repositories {
+		owner, name, err := utils.GetOwnerAndRepoFromURL(repoURL)
+		if err != nil {
+			return nil, fmt.Errorf("could not extract owner and repo from url: %w", err)
+		}
+		names = append(names, owner+"/"+name)
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// tableColumns returns table columns with the given titles, each as wide as
+// its widest cell
+func tableColumns(rows []bubblesTable.Row, titles ...string) []bubblesTable.Column {
+	columns := make([]bubblesTable.Column, 0, len(titles))
+	for i, title := range titles {
+		columns = append(columns, bubblesTable.Column{
+			Title: title,
+			Width: utils.GetMaxTableColumnWidth(rows, i, title),
+		})
+	}
+
+	return columns
+}
+
+// truncate shortens the text to the given width, ending it with an ellipsis
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+
+	return string(runes[:width-1]) + "…"
+}
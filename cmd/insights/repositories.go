@@ -1,8 +1,10 @@
 package insights
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
@@ -11,8 +13,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
 	"github.com/open-sauced/pizza-cli/v2/api/services/contributors"
 	"github.com/open-sauced/pizza-cli/v2/api/services/histogram"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
 	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
 	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
 )
@@ -34,6 +38,9 @@ type repositoriesOptions struct {
 	// Output is the formatting style for command output
 	Output string
 
+	// out is where the insights are written to
+	out io.Writer
+
 	telemetry *utils.PosthogCliClient
 }
 
@@ -47,7 +54,7 @@ func NewRepositoriesCommand() *cobra.Command {
 		Long:    "Gather insights about indexed git repositories. This command will show info about contributors, pull requests, etc.",
 		Args: func(cmd *cobra.Command, args []string) error {
 			fileFlag := cmd.Flags().Lookup(constants.FlagNameFile)
-			if !fileFlag.Changed && len(args) == 0 {
+			if fileFlag.Value.String() == "" && len(args) == 0 {
 				return fmt.Errorf("must specify git repository url argument(s) or provide %s flag", fileFlag.Name)
 			}
 			opts.Repos = append(opts.Repos, args...)
@@ -58,12 +65,16 @@ func NewRepositoriesCommand() *cobra.Command {
 
 			opts.telemetry = utils.NewPosthogCliClient(!disableTelem)
 
-			endpointURL, _ := cmd.Flags().GetString(constants.FlagNameEndpoint)
-			opts.APIClient = api.NewClient(endpointURL)
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
 			output, _ := cmd.Flags().GetString(constants.FlagNameOutput)
 			opts.Output = output
+			opts.out = cmd.OutOrStdout()
 
-			err := opts.run()
+			err = opts.run(cmd.Context())
 
 			if err != nil {
 				_ = opts.telemetry.CaptureInsights()
@@ -81,7 +92,7 @@ func NewRepositoriesCommand() *cobra.Command {
 	return cmd
 }
 
-func (opts *repositoriesOptions) run() error {
+func (opts *repositoriesOptions) run(ctx context.Context) error {
 	repositories, err := utils.HandleRepositoryValues(opts.Repos, opts.FilePath)
 	if err != nil {
 		return err
@@ -100,7 +111,7 @@ func (opts *repositoriesOptions) run() error {
 			waitGroup.Add(1)
 			go func() {
 				defer waitGroup.Done()
-				allData, err := findAllRepositoryInsights(opts, repoURL)
+				allData, err := findAllRepositoryInsights(ctx, opts, repoURL)
 				if err != nil {
 					errorChan <- err
 					return
@@ -128,7 +139,7 @@ func (opts *repositoriesOptions) run() error {
 			if err != nil {
 				return err
 			}
-			fmt.Println(output)
+			fmt.Fprintln(opts.out, output)
 			return nil
 		}
 	}
@@ -195,8 +206,8 @@ func (ris repositoryInsightsSlice) OutputTable() (string, error) {
 	return strings.Join(tables, separator), nil
 }
 
-func findAllRepositoryInsights(opts *repositoriesOptions, repoURL string) (*repositoryInsights, error) {
-	repo, err := findRepositoryByOwnerAndRepoName(opts.APIClient, repoURL)
+func findAllRepositoryInsights(ctx context.Context, opts *repositoriesOptions, repoURL string) (*repositoryInsights, error) {
+	repo, err := findRepositoryByOwnerAndRepoName(ctx, opts.APIClient, repoURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not get repository insights for repository %s: %w", repoURL, err)
 	}
@@ -216,7 +227,7 @@ func findAllRepositoryInsights(opts *repositoriesOptions, repoURL string) (*repo
 	waitGroup.Add(1)
 	go func() {
 		defer waitGroup.Done()
-		response, err := getPullRequestInsights(opts.APIClient, repo.FullName, opts.RangeVal)
+		response, err := getPullRequestInsights(ctx, opts.APIClient, repo.FullName, opts.RangeVal)
 		if err != nil {
 			errorChan <- err
 			return
@@ -232,13 +243,13 @@ func findAllRepositoryInsights(opts *repositoriesOptions, repoURL string) (*repo
 	waitGroup.Add(1)
 	go func() {
 		defer waitGroup.Done()
-		response, err := searchAllPullRequestContributors(opts.APIClient, []string{repo.FullName}, opts.RangeVal)
+		response, err := searchAllPullRequestContributors(ctx, opts.APIClient, []string{repo.FullName}, opts.RangeVal)
 		if err != nil {
 			errorChan <- err
 			return
 		}
 		var contributors []string
-		for _, contributor := range response.Data {
+		for _, contributor := range response {
 			contributors = append(contributors, contributor.AuthorLogin)
 		}
 		repoInsights.Contributors = contributors
@@ -256,10 +267,10 @@ func findAllRepositoryInsights(opts *repositoriesOptions, repoURL string) (*repo
 	return repoInsights, nil
 }
 
-func getPullRequestInsights(apiClient *api.Client, repo string, rangeVal int) ([]histogram.PrHistogramData, error) {
-	data, _, err := apiClient.HistogramService.PrsHistogram(repo, rangeVal)
+func getPullRequestInsights(ctx context.Context, apiClient *api.Client, repo string, rangeVal int) ([]histogram.PrHistogramData, error) {
+	data, _, err := apiClient.HistogramService.PrsHistogram(ctx, repo, rangeVal)
 	if err != nil {
-		return nil, fmt.Errorf("error while calling 'PullRequestsServiceAPI.GetPullRequestInsights' with repository %s': %w", repo, err)
+		return nil, fmt.Errorf("could not get pull request insights of repository %s: %w", repo, err)
 	}
 
 	if len(data) == 0 {
@@ -269,10 +280,17 @@ func getPullRequestInsights(apiClient *api.Client, repo string, rangeVal int) ([
 	return data, nil
 }
 
-func searchAllPullRequestContributors(apiClient *api.Client, repos []string, rangeVal int) (*contributors.ContribResponse, error) {
-	data, _, err := apiClient.ContributorService.SearchPullRequestContributors(repos, rangeVal)
+func searchAllPullRequestContributors(ctx context.Context, apiClient *api.Client, repos []string, rangeVal int) ([]contributors.DbContributor, error) {
+	data, err := services.All(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]contributors.DbContributor, services.MetaData, error) {
+		response, _, err := apiClient.ContributorService.SearchPullRequestContributors(ctx, repos, rangeVal, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return response.Data, response.Meta, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error while calling 'ContributorService.SearchPullRequestContributors' with repository %v': %w", repos, err)
+		return nil, fmt.Errorf("could not get contributors of repositories %v: %w", repos, err)
 	}
 
 	return data, nil
@@ -2,9 +2,11 @@ package insights
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strconv"
 	"sync"
@@ -13,6 +15,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/repository"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
 	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
 	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
 )
@@ -39,6 +44,9 @@ type userContributionsOptions struct {
 	// Output is the formatting style for command output
 	Output string
 
+	// out is where the insights are written to
+	out io.Writer
+
 	// Sort is the column to be used to sort user contributions (total, commits, pr, none)
 	Sort string
 }
@@ -53,18 +61,22 @@ func NewUserContributionsCommand() *cobra.Command {
 		Long:  "Gather insights on individual contributors given a list of repository URLs",
 		Args: func(cmd *cobra.Command, args []string) error {
 			fileFlag := cmd.Flags().Lookup(constants.FlagNameFile)
-			if !fileFlag.Changed && len(args) == 0 {
+			if fileFlag.Value.String() == "" && len(args) == 0 {
 				return fmt.Errorf("must specify git repository url argument(s) or provide %s flag", fileFlag.Name)
 			}
 			opts.Repos = append(opts.Repos, args...)
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			endpointURL, _ := cmd.Flags().GetString(constants.FlagNameEndpoint)
-			opts.APIClient = api.NewClient(endpointURL)
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
 			output, _ := cmd.Flags().GetString(constants.FlagNameOutput)
 			opts.Output = output
-			return opts.run()
+			opts.out = cmd.OutOrStdout()
+			return opts.run(cmd.Context())
 		},
 	}
 
@@ -76,7 +88,7 @@ func NewUserContributionsCommand() *cobra.Command {
 	return cmd
 }
 
-func (opts *userContributionsOptions) run() error {
+func (opts *userContributionsOptions) run(ctx context.Context) error {
 	repositories, err := utils.HandleRepositoryValues(opts.Repos, opts.FilePath)
 	if err != nil {
 		return err
@@ -105,7 +117,7 @@ func (opts *userContributionsOptions) run() error {
 			go func() {
 				defer waitGroup.Done()
 
-				data, err := findAllUserContributionsInsights(opts, repoURL)
+				data, err := findAllUserContributionsInsights(ctx, opts, repoURL)
 				if err != nil {
 					errorChan <- err
 					return
@@ -144,7 +156,7 @@ func (opts *userContributionsOptions) run() error {
 					return err
 				}
 
-				fmt.Println(output)
+				fmt.Fprintln(opts.out, output)
 			}
 
 			return nil
@@ -256,7 +268,7 @@ func (ucig userContributionsInsightGroup) OutputTable() (string, error) {
 	return fmt.Sprintf("%s\n%s\n", ucig.RepoURL, utils.OutputTable(rows, columns)), nil
 }
 
-func findAllUserContributionsInsights(opts *userContributionsOptions, repoURL string) (*userContributionsInsightGroup, error) {
+func findAllUserContributionsInsights(ctx context.Context, opts *userContributionsOptions, repoURL string) (*userContributionsInsightGroup, error) {
 	owner, name, err := utils.GetOwnerAndRepoFromURL(repoURL)
 	if err != nil {
 		return nil, err
@@ -266,13 +278,19 @@ func findAllUserContributionsInsights(opts *userContributionsOptions, repoURL st
 		RepoURL: repoURL,
 	}
 
-	dataPoints, _, err := opts.APIClient.RepositoryService.FindContributorsByOwnerAndRepo(owner, name, 30)
+	dataPoints, err := services.All(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]repository.DbContributorInfo, services.MetaData, error) {
+		response, _, err := opts.APIClient.RepositoryService.FindContributorsByOwnerAndRepo(ctx, owner, name, int(opts.Period), page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
 
+		return response.Data, response.Meta, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error while calling API RepositoryService.FindContributorsByOwnerAndRepo with repository %s/%s': %w", owner, name, err)
+		return nil, fmt.Errorf("could not get contributors of repository %s/%s: %w", owner, name, err)
 	}
 
-	for _, data := range dataPoints.Data {
+	for _, data := range dataPoints {
 		_, ok := opts.usersMap[data.Login]
 		if len(opts.usersMap) == 0 || ok {
 			repoUserContributionsInsightGroup.Insights = append(repoUserContributionsInsightGroup.Insights, userContributionsInsights{
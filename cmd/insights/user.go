@@ -0,0 +1,250 @@
+package insights
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	bubblesTable "github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	apiUtils "github.com/open-sauced/pizza-cli/v2/api/utils"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+type userOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Logins are the GitHub logins of the users
+	Logins []string
+
+	// RangeVal is the number of days, used for query filtering
+	RangeVal int
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the insights are written to
+	out io.Writer
+
+	telemetry *utils.PosthogCliClient
+}
+
+// NewUserCommand returns a new cobra command for 'pizza insights user'
+func NewUserCommand() *cobra.Command {
+	opts := &userOptions{}
+	cmd := &cobra.Command{
+		Use:   "user login... [flags]",
+		Short: "Gather insights on GitHub users",
+		Long: `Gather insights on GitHub users across every repository they contributed to:
+their OSCR, pull requests created and reviewed, issues, comments, and repositories.
+Given several users, their insights are compared side by side`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			disableTelem, _ := cmd.Flags().GetBool(constants.FlagNameTelemetry)
+
+			opts.telemetry = utils.NewPosthogCliClient(!disableTelem)
+
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Logins = args
+			output, _ := cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.Output = output
+			opts.out = cmd.OutOrStdout()
+
+			err = opts.run(cmd.Context())
+
+			if err == nil {
+				_ = opts.telemetry.CaptureInsights()
+			} else {
+				_ = opts.telemetry.CaptureFailedInsights()
+			}
+
+			_ = opts.telemetry.Done()
+
+			return err
+		},
+	}
+	cmd.Flags().IntVarP(&opts.RangeVal, constants.FlagNameRange, "r", 30, "Number of days to look-back (7,30,90)")
+	return cmd
+}
+
+func (opts *userOptions) run(ctx context.Context) error {
+	if !apiUtils.IsValidRange(opts.RangeVal) {
+		return fmt.Errorf("invalid period: %d, accepts (7,30,90)", opts.RangeVal)
+	}
+
+	var (
+		waitGroup = new(sync.WaitGroup)
+		insights  = make(userInsightsSlice, len(opts.Logins))
+		errs      = make([]error, len(opts.Logins))
+	)
+	for i, login := range opts.Logins {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			insights[i], errs[i] = findUserInsights(ctx, opts, login)
+		}()
+	}
+	waitGroup.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	output, err := insights.BuildOutput(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(opts.out, output)
+	return nil
+}
+
+// findUserInsights gets the profile and contribution stats of the user
+func findUserInsights(ctx context.Context, opts *userOptions, login string) (userInsights, error) {
+	user, _, err := opts.APIClient.UsersService.FindOneByUsername(ctx, login)
+	if errors.Is(err, services.ErrNotFound) {
+		return userInsights{}, fmt.Errorf("user %s is either non-existent or has not been indexed yet", login)
+	}
+	if err != nil {
+		return userInsights{}, fmt.Errorf("could not get user %s: %w", login, err)
+	}
+
+	devstats, _, err := opts.APIClient.UsersService.FindDevstatsByUsername(ctx, login, opts.RangeVal)
+	if err != nil {
+		return userInsights{}, fmt.Errorf("could not get contribution stats of user %s: %w", login, err)
+	}
+
+	repos := devstats.Repos
+	if repos == nil {
+		repos = []string{}
+	}
+
+	return userInsights{
+		Login:           user.Login,
+		Name:            user.Name,
+		OSCR:            user.OSCR,
+		PRsCreated:      devstats.PRsCreated,
+		PRsReviewed:     devstats.PRsReviewed,
+		IssuesCreated:   devstats.IssuesCreated,
+		Comments:        devstats.Comments,
+		Commits:         devstats.Commits,
+		Repos:           repos,
+		LastContributed: devstats.LastContributed,
+	}, nil
+}
+
+type userInsights struct {
+	Login           string    `json:"login" yaml:"login"`
+	Name            string    `json:"name" yaml:"name"`
+	OSCR            float64   `json:"oscr" yaml:"oscr"`
+	PRsCreated      int       `json:"prs_created" yaml:"prs_created"`
+	PRsReviewed     int       `json:"prs_reviewed" yaml:"prs_reviewed"`
+	IssuesCreated   int       `json:"issues_created" yaml:"issues_created"`
+	Comments        int       `json:"comments" yaml:"comments"`
+	Commits         int       `json:"commits" yaml:"commits"`
+	Repos           []string  `json:"repos" yaml:"repos"`
+	LastContributed time.Time `json:"last_contributed" yaml:"last_contributed"`
+}
+
+// stats returns the names and values of the user's insights, as shown in
+// tables and CSV
+func (ui userInsights) stats() [][2]string {
+	lastContributed := ""
+	if !ui.LastContributed.IsZero() {
+		lastContributed = ui.LastContributed.Format(time.DateOnly)
+	}
+
+	return [][2]string{
+		{"Name", ui.Name},
+		{"OSCR", strconv.FormatFloat(ui.OSCR, 'f', -1, 64)},
+		{"PRs Created", strconv.Itoa(ui.PRsCreated)},
+		{"PRs Reviewed", strconv.Itoa(ui.PRsReviewed)},
+		{"Issues Created", strconv.Itoa(ui.IssuesCreated)},
+		{"Comments", strconv.Itoa(ui.Comments)},
+		{"Commits", strconv.Itoa(ui.Commits)},
+		{"Repositories", strconv.Itoa(len(ui.Repos))},
+		{"Last Contributed", lastContributed},
+	}
+}
+
+type userInsightsSlice []userInsights
+
+func (uis userInsightsSlice) BuildOutput(format string) (string, error) {
+	switch format {
+	case constants.OutputTable:
+		return uis.OutputTable(), nil
+	case constants.OutputJSON:
+		return utils.OutputJSON(uis)
+	case constants.OutputYAML:
+		return utils.OutputYAML(uis)
+	case constants.OuputCSV:
+		return uis.OutputCSV()
+	default:
+		return "", fmt.Errorf("unknown output format %s", format)
+	}
+}
+
+func (uis userInsightsSlice) OutputCSV() (string, error) {
+	if len(uis) == 0 {
+		return "", errors.New("user insights are empty")
+	}
+
+	b := new(bytes.Buffer)
+	writer := csv.NewWriter(b)
+
+	// write headers
+	headers := []string{"User"}
+	for _, stat := range uis[0].stats() {
+		headers = append(headers, stat[0])
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	// write records
+	for _, ui := range uis {
+		record := []string{ui.Login}
+		for _, stat := range ui.stats() {
+			record = append(record, stat[1])
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return b.String(), writer.Error()
+}
+
+// OutputTable compares the users side by side, with a column per user
+func (uis userInsightsSlice) OutputTable() string {
+	var rows []bubblesTable.Row
+	titles := []string{"User"}
+	for i, ui := range uis {
+		titles = append(titles, ui.Login)
+		for j, stat := range ui.stats() {
+			if i == 0 {
+				rows = append(rows, bubblesTable.Row{stat[0]})
+			}
+			rows[j] = append(rows[j], stat[1])
+		}
+	}
+
+	return utils.OutputTable(rows, tableColumns(rows, titles...))
+}
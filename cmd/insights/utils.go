@@ -1,8 +1,9 @@
 package insights
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
 
 	"github.com/open-sauced/pizza-cli/v2/api"
 	"github.com/open-sauced/pizza-cli/v2/api/services/repository"
@@ -11,18 +12,18 @@ import (
 
 // findRepositoryByOwnerAndRepoName returns an API client Db Repo
 // based on the given repository URL
-func findRepositoryByOwnerAndRepoName(apiClient *api.Client, repoURL string) (*repository.DbRepository, error) {
+func findRepositoryByOwnerAndRepoName(ctx context.Context, apiClient *api.Client, repoURL string) (*repository.DbRepository, error) {
 	owner, repoName, err := utils.GetOwnerAndRepoFromURL(repoURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not extract owner and repo from url: %w", err)
 	}
 
-	repo, response, err := apiClient.RepositoryService.FindOneByOwnerAndRepo(owner, repoName)
+	repo, _, err := apiClient.RepositoryService.FindOneByOwnerAndRepo(ctx, owner, repoName)
+	if errors.Is(err, api.ErrNotFound) {
+		return nil, fmt.Errorf("repository %s is either non-existent, private, or has not been indexed yet", repoURL)
+	}
 	if err != nil {
-		if response != nil && response.StatusCode == http.StatusNotFound {
-			return nil, fmt.Errorf("repository %s is either non-existent, private, or has not been indexed yet", repoURL)
-		}
-		return nil, fmt.Errorf("error while calling 'RepositoryServiceAPI.FindOneByOwnerAndRepo' with owner %q and repo %q: %w", owner, repoName, err)
+		return nil, fmt.Errorf("could not get repository %s/%s: %w", owner, repoName, err)
 	}
 
 	return repo, nil
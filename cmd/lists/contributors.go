@@ -0,0 +1,121 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type contributorsOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// List is the ID or name of the Contributor Insight
+	List string
+
+	// Logins are the GitHub logins of the contributors
+	Logins []string
+
+	// Remove removes the contributors instead of adding them
+	Remove bool
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the updated Contributor Insight is written to
+	out io.Writer
+}
+
+// NewAddCommand returns a new cobra command for 'pizza lists add'
+func NewAddCommand() *cobra.Command {
+	return newContributorsCommand(&cobra.Command{
+		Use:   "add list login...",
+		Short: "Add contributors to a Contributor Insight",
+		Example: `  # Add contributors by their GitHub login
+  $ pizza lists add Maintainers jpmcb @zeucapua`,
+	}, false)
+}
+
+// NewRemoveCommand returns a new cobra command for 'pizza lists remove'
+func NewRemoveCommand() *cobra.Command {
+	return newContributorsCommand(&cobra.Command{
+		Use:     "remove list login...",
+		Aliases: []string{"rm"},
+		Short:   "Remove contributors from a Contributor Insight",
+	}, true)
+}
+
+func newContributorsCommand(cmd *cobra.Command, remove bool) *cobra.Command {
+	opts := &contributorsOptions{Remove: remove}
+	cmd.Args = cobra.MinimumNArgs(2)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		apiClient, err := authcmd.NewAPIClient(cmd)
+		if err != nil {
+			return err
+		}
+		opts.APIClient = apiClient
+		opts.Workspace, _ = cmd.Flags().GetString(constants.FlagNameWorkspace)
+		opts.List = args[0]
+		opts.Logins = args[1:]
+		opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+		opts.out = cmd.OutOrStdout()
+
+		return opts.run(cmd.Context())
+	}
+
+	return cmd
+}
+
+func (opts *contributorsOptions) run(ctx context.Context) error {
+	logins, err := readLogins(opts.Logins, "")
+	if err != nil {
+		return err
+	}
+
+	ws, err := workspace.FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	list, err := findList(ctx, opts.APIClient, ws.ID, opts.List)
+	if err != nil {
+		return err
+	}
+
+	contributors := contributorLogins(list)
+	if opts.Remove {
+		for _, login := range logins {
+			if !containsLogin(contributors, login) {
+				return fmt.Errorf("%s is not a contributor of contributor insight %s", login, list.Name)
+			}
+		}
+
+		contributors = slices.DeleteFunc(contributors, func(contributor string) bool {
+			return containsLogin(logins, contributor)
+		})
+	} else {
+		for _, login := range logins {
+			if !containsLogin(contributors, login) {
+				contributors = append(contributors, login)
+			}
+		}
+	}
+
+	updated, _, err := opts.APIClient.WorkspacesService.UserListService.PatchUserListForUser(ctx, ws.ID, list.ID, list.Name, contributors, list.IsPublic)
+	if err != nil {
+		return fmt.Errorf("could not update contributor insight %s: %w", list.Name, err)
+	}
+
+	return writeList(ws.ID, updated, opts.Output, opts.out)
+}
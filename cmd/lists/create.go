@@ -0,0 +1,93 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type createOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// Name is the name of the new Contributor Insight
+	Name string
+
+	// Logins are the GitHub logins of its contributors
+	Logins []string
+
+	// FilePath is the path to a yaml file with more GitHub logins
+	FilePath string
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the created Contributor Insight is written to
+	out io.Writer
+}
+
+// NewCreateCommand returns a new cobra command for 'pizza lists create'
+func NewCreateCommand() *cobra.Command {
+	opts := &createOptions{}
+	cmd := &cobra.Command{
+		Use:   "create name [login...] [flags]",
+		Short: "Create a Contributor Insight",
+		Example: `  # Create a Contributor Insight in the "OpenSauced" workspace
+  $ pizza lists create Maintainers jpmcb zeucapua --workspace OpenSauced
+
+  # Create a Contributor Insight with the logins listed in a yaml file
+  $ pizza lists create Maintainers --file ./maintainers.yaml`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Workspace, _ = cmd.Flags().GetString(constants.FlagNameWorkspace)
+			opts.Name = args[0]
+			opts.Logins = args[1:]
+			opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.FilePath, constants.FlagNameFile, "f", "", "Path to yaml file containing a list of GitHub logins")
+	return cmd
+}
+
+func (opts *createOptions) run(ctx context.Context) error {
+	logins, err := readLogins(opts.Logins, opts.FilePath)
+	if err != nil {
+		return err
+	}
+
+	ws, err := workspace.FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	created, _, err := opts.APIClient.WorkspacesService.UserListService.CreateUserListForUser(ctx, ws.ID, opts.Name, logins, false)
+	if err != nil {
+		return fmt.Errorf("could not create contributor insight %s: %w", opts.Name, err)
+	}
+
+	list, _, err := opts.APIClient.WorkspacesService.UserListService.GetUserList(ctx, ws.ID, created.UserListID)
+	if err != nil {
+		return fmt.Errorf("could not get contributor insight %s: %w", opts.Name, err)
+	}
+
+	return writeList(ws.ID, list, opts.Output, opts.out)
+}
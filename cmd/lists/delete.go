@@ -0,0 +1,86 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+type deleteOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// List is the ID or name of the Contributor Insight
+	List string
+
+	// Yes skips asking for confirmation
+	Yes bool
+
+	// in is where the confirmation is read from, out where it's asked on
+	in  io.Reader
+	out io.Writer
+}
+
+// NewDeleteCommand returns a new cobra command for 'pizza lists delete'
+func NewDeleteCommand() *cobra.Command {
+	opts := &deleteOptions{}
+	cmd := &cobra.Command{
+		Use:   "delete list [flags]",
+		Short: "Delete a Contributor Insight",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Workspace, _ = cmd.Flags().GetString(constants.FlagNameWorkspace)
+			opts.List = args[0]
+			opts.in = cmd.InOrStdin()
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Yes, constants.FlagNameYes, "y", false, "Delete the Contributor Insight without asking for confirmation")
+	return cmd
+}
+
+func (opts *deleteOptions) run(ctx context.Context) error {
+	ws, err := workspace.FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	list, err := findList(ctx, opts.APIClient, ws.ID, opts.List)
+	if err != nil {
+		return err
+	}
+
+	if !opts.Yes {
+		ok, err := utils.Confirm(opts.in, opts.out, fmt.Sprintf("Delete contributor insight %s (%s) of workspace %s?", list.Name, list.ID, ws.Name))
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	_, err = opts.APIClient.WorkspacesService.UserListService.DeleteUserList(ctx, ws.ID, list.ID)
+	if err != nil {
+		return fmt.Errorf("could not delete contributor insight %s: %w", list.Name, err)
+	}
+
+	fmt.Fprintf(opts.out, "Deleted contributor insight %s (%s)\n", list.Name, list.ID)
+	return nil
+}
@@ -0,0 +1,82 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces/userlists"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type listOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the Contributor Insights are written to
+	out io.Writer
+}
+
+// NewListCommand returns a new cobra command for 'pizza lists list'
+func NewListCommand() *cobra.Command {
+	opts := &listOptions{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the Contributor Insights of a workspace",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Workspace, _ = cmd.Flags().GetString(constants.FlagNameWorkspace)
+			opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+func (opts *listOptions) run(ctx context.Context) error {
+	ws, err := workspace.FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	data, err := services.All(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]userlists.DbUserList, services.MetaData, error) {
+		resp, _, err := opts.APIClient.WorkspacesService.UserListService.GetUserLists(ctx, ws.ID, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return resp.Data, resp.Meta, nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not list contributor insights of workspace %s: %w", ws.Name, err)
+	}
+
+	output, err := newListSummaries(data).BuildOutput(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(opts.out, output)
+	return nil
+}
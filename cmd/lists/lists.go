@@ -0,0 +1,142 @@
+// Package lists contains the commands managing the Contributor Insights, the
+// lists of contributors, in the OpenSauced workspaces of the logged in user
+package lists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces/userlists"
+	"github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+// NewListsCommand returns a new cobra command for 'pizza lists'
+func NewListsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lists <command> [flags]",
+		Short: "Manage the Contributor Insights of your OpenSauced workspaces",
+		Long: `Manage the Contributor Insights, the lists of contributors, of a workspace of the
+logged in user: list, create, and delete them, and add, remove, or sync their contributors.
+
+The workspace is chosen with --workspace, by its ID or its name, and defaults to the
+"Pizza CLI" workspace "pizza generate insight" uses. Contributor Insights are referred
+to by their ID or their name.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().StringP(constants.FlagNameOutput, "o", constants.OutputTable, "The formatting for command output. One of: (table, yaml, json)")
+	cmd.PersistentFlags().StringP(constants.FlagNameWorkspace, "w", workspace.DefaultName, "The ID or name of the workspace of the Contributor Insights")
+	cmd.AddCommand(NewListCommand())
+	cmd.AddCommand(NewShowCommand())
+	cmd.AddCommand(NewCreateCommand())
+	cmd.AddCommand(NewAddCommand())
+	cmd.AddCommand(NewRemoveCommand())
+	cmd.AddCommand(NewSyncCommand())
+	cmd.AddCommand(NewDeleteCommand())
+	return cmd
+}
+
+// findList returns the Contributor Insight of the workspace with the given ID
+// or name. Names must be unambiguous.
+func findList(ctx context.Context, client *api.Client, workspaceID, ref string) (*userlists.DbUserList, error) {
+	var matches []userlists.DbUserList
+	err := services.ForEach(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]userlists.DbUserList, services.MetaData, error) {
+		resp, _, err := client.WorkspacesService.UserListService.GetUserLists(ctx, workspaceID, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return resp.Data, resp.Meta, nil
+	}, func(list userlists.DbUserList) error {
+		if list.ID == ref {
+			matches = []userlists.DbUserList{list}
+			return services.ErrStopIteration
+		}
+
+		if list.Name == ref {
+			matches = append(matches, list)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no contributor insight with the ID or name %q", ref)
+	case 1:
+		// The lists of a workspace may come without their contributors
+		list, _, err := client.WorkspacesService.UserListService.GetUserList(ctx, workspaceID, matches[0].ID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get contributor insight %s: %w", matches[0].Name, err)
+		}
+
+		return list, nil
+	default:
+		return nil, fmt.Errorf("%d contributor insights are named %q, use the ID of one of them instead", len(matches), ref)
+	}
+}
+
+// readLogins returns the GitHub logins given as arguments and in the YAML
+// list of the file, if any. A leading "@" is dropped and duplicates, which
+// differ in case at most, are removed.
+func readLogins(args []string, filePath string) ([]string, error) {
+	logins := slices.Clone(args)
+	if filePath != "" {
+		file, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		var fromFile []string
+		if err := yaml.Unmarshal(file, &fromFile); err != nil {
+			return nil, fmt.Errorf("could not read logins from %s: %w", filePath, err)
+		}
+		logins = append(logins, fromFile...)
+	}
+
+	unique := make([]string, 0, len(logins))
+	for _, login := range logins {
+		login = strings.TrimPrefix(strings.TrimSpace(login), "@")
+		if login == "" {
+			return nil, errors.New("GitHub logins must not be empty")
+		}
+
+		if !containsLogin(unique, login) {
+			unique = append(unique, login)
+		}
+	}
+
+	return unique, nil
+}
+
+// containsLogin reports whether the GitHub login is one of the logins, which
+// are case insensitive
+func containsLogin(logins []string, login string) bool {
+	return slices.ContainsFunc(logins, func(other string) bool {
+		return strings.EqualFold(other, login)
+	})
+}
+
+// contributorLogins returns the GitHub logins of the list's contributors
+func contributorLogins(list *userlists.DbUserList) []string {
+	logins := make([]string, 0, len(list.Contributors))
+	for _, contributor := range list.Contributors {
+		logins = append(logins, contributor.Username)
+	}
+
+	return logins
+}
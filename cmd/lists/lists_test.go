@@ -0,0 +1,147 @@
+package lists
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/mock/server"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+const (
+	workspaceID     = "4b3e6d0a-6f1c-4d8e-9a5b-2f7c1e0d9a11"
+	maintainersID   = "9c2d4e6f-1a3b-4c5d-8e7f-0a1b2c3d4e5f"
+	sampleWorkspace = "OpenSauced"
+)
+
+func decodeDetails(t *testing.T, out *bytes.Buffer) listDetails {
+	var details listDetails
+	require.NoError(t, json.Unmarshal(out.Bytes(), &details))
+	out.Reset()
+
+	return details
+}
+
+func TestListCommands(t *testing.T) {
+	t.Parallel()
+	client := server.NewTestClient(t, server.SampleDataset(), "token")
+	ctx := context.Background()
+	var out bytes.Buffer
+
+	list := &listOptions{APIClient: client, Workspace: sampleWorkspace, Output: constants.OutputTable, out: &out}
+	require.NoError(t, list.run(ctx))
+	assert.Contains(t, out.String(), maintainersID+" Maintainers")
+	out.Reset()
+
+	show := &showOptions{APIClient: client, Workspace: workspaceID, List: "Maintainers", Output: constants.OutputJSON, out: &out}
+	require.NoError(t, show.run(ctx))
+	maintainers := decodeDetails(t, &out)
+	assert.Equal(t, []string{"jpmcb", "nickytonline", "zeucapua"}, maintainers.Contributors)
+	assert.Equal(t, "https://app.opensauced.pizza/workspaces/"+workspaceID+"/contributor-insights/"+maintainersID, maintainers.URL)
+
+	create := &createOptions{APIClient: client, Workspace: sampleWorkspace, Name: "Reviewers", Logins: []string{"@jpmcb"}, Output: constants.OutputJSON, out: &out}
+	require.NoError(t, create.run(ctx))
+	created := decodeDetails(t, &out)
+	assert.Equal(t, "Reviewers", created.Name)
+	assert.Equal(t, []string{"jpmcb"}, created.Contributors)
+
+	add := &contributorsOptions{APIClient: client, Workspace: sampleWorkspace, List: "Reviewers", Logins: []string{"JPMCB", "zeucapua"}, Output: constants.OutputJSON, out: &out}
+	require.NoError(t, add.run(ctx))
+	assert.Equal(t, []string{"jpmcb", "zeucapua"}, decodeDetails(t, &out).Contributors)
+
+	remove := &contributorsOptions{APIClient: client, Workspace: sampleWorkspace, List: created.ID, Logins: []string{"jpmcb"}, Remove: true, Output: constants.OutputJSON, out: &out}
+	require.NoError(t, remove.run(ctx))
+	assert.Equal(t, []string{"zeucapua"}, decodeDetails(t, &out).Contributors)
+
+	remove.Logins = []string{"nickytonline"}
+	require.EqualError(t, remove.run(ctx), "nickytonline is not a contributor of contributor insight Reviewers")
+
+	// Declining leaves the Contributor Insight be
+	del := &deleteOptions{APIClient: client, Workspace: sampleWorkspace, List: "Reviewers", in: strings.NewReader("n\n"), out: &out}
+	require.NoError(t, del.run(ctx))
+	_, err := findList(ctx, client, workspaceID, "Reviewers")
+	require.NoError(t, err)
+	out.Reset()
+
+	del = &deleteOptions{APIClient: client, Workspace: sampleWorkspace, List: "Reviewers", in: strings.NewReader("y\n"), out: &out}
+	require.NoError(t, del.run(ctx))
+	assert.Contains(t, out.String(), "Deleted contributor insight Reviewers ("+created.ID+")")
+
+	_, err = findList(ctx, client, workspaceID, "Reviewers")
+	require.EqualError(t, err, `no contributor insight with the ID or name "Reviewers"`)
+}
+
+func TestSyncCommand(t *testing.T) {
+	t.Parallel()
+	client := server.NewTestClient(t, server.SampleDataset(), "token")
+	ctx := context.Background()
+	var out, errOut bytes.Buffer
+
+	file := filepath.Join(t.TempDir(), "maintainers.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("- Zeucapua\n- bdougie\n"), 0o600))
+
+	// Declining shows the changes without applying them
+	sync := &syncOptions{
+		APIClient: client,
+		Workspace: sampleWorkspace,
+		List:      "Maintainers",
+		Logins:    []string{"jpmcb"},
+		FilePath:  file,
+		Output:    constants.OutputJSON,
+		in:        strings.NewReader("n\n"),
+		errOut:    &errOut,
+		out:       &out,
+	}
+	require.NoError(t, sync.run(ctx))
+	assert.Equal(t, `--- Maintainers
++++ Maintainers
+@@ -1,3 +1,3 @@
++bdougie
+ jpmcb
+-nickytonline
+ zeucapua
+Apply these changes to contributor insight Maintainers? (y/n): `, errOut.String())
+	assert.Empty(t, out.String())
+
+	list, err := findList(ctx, client, workspaceID, "Maintainers")
+	require.NoError(t, err)
+	assert.Len(t, list.Contributors, 3)
+	errOut.Reset()
+
+	sync.Yes = true
+	require.NoError(t, sync.run(ctx))
+	assert.Equal(t, []string{"jpmcb", "zeucapua", "bdougie"}, decodeDetails(t, &out).Contributors)
+	errOut.Reset()
+
+	require.NoError(t, sync.run(ctx))
+	assert.Equal(t, "Contributor insight Maintainers is already in sync\n", errOut.String())
+}
+
+func TestListCommandsLoggedOut(t *testing.T) {
+	t.Parallel()
+	client := server.NewTestClient(t, server.SampleDataset(), "")
+
+	_, err := findList(context.Background(), client, workspaceID, "Maintainers")
+	require.ErrorIs(t, err, api.ErrUnauthorized)
+	assert.Equal(t, 1, strings.Count(err.Error(), `"pizza login"`), "the login hint is given once")
+}
+
+func TestReadLogins(t *testing.T) {
+	t.Parallel()
+
+	logins, err := readLogins([]string{"@jpmcb", "zeucapua", "JPMCB"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jpmcb", "zeucapua"}, logins)
+
+	_, err = readLogins([]string{"@"}, "")
+	require.EqualError(t, err, "GitHub logins must not be empty")
+}
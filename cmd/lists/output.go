@@ -0,0 +1,155 @@
+package lists
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	bubblesTable "github.com/charmbracelet/bubbles/table"
+
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces/userlists"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+// listSummary is a Contributor Insight as listed by "pizza lists list"
+type listSummary struct {
+	ID        string    `json:"id" yaml:"id"`
+	Name      string    `json:"name" yaml:"name"`
+	IsPublic  bool      `json:"is_public" yaml:"is_public"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+type listSummaries []listSummary
+
+func newListSummaries(data []userlists.DbUserList) listSummaries {
+	summaries := make(listSummaries, 0, len(data))
+	for _, list := range data {
+		summaries = append(summaries, listSummary{
+			ID:        list.ID,
+			Name:      list.Name,
+			IsPublic:  list.IsPublic,
+			UpdatedAt: list.UpdatedAt,
+		})
+	}
+
+	return summaries
+}
+
+func (ls listSummaries) BuildOutput(format string) (string, error) {
+	switch format {
+	case constants.OutputTable:
+		return ls.OutputTable(), nil
+	case constants.OutputJSON:
+		return utils.OutputJSON(ls)
+	case constants.OutputYAML:
+		return utils.OutputYAML(ls)
+	default:
+		return "", fmt.Errorf("unknown output format %s", format)
+	}
+}
+
+func (ls listSummaries) OutputTable() string {
+	rows := make([]bubblesTable.Row, 0, len(ls))
+	for _, l := range ls {
+		rows = append(rows, bubblesTable.Row{
+			l.ID,
+			l.Name,
+			strconv.FormatBool(l.IsPublic),
+			l.UpdatedAt.Format(time.DateOnly),
+		})
+	}
+
+	titles := []string{"ID", "Name", "Public", "Updated"}
+	columns := make([]bubblesTable.Column, 0, len(titles))
+	for i, title := range titles {
+		columns = append(columns, bubblesTable.Column{Title: title, Width: utils.GetMaxTableColumnWidth(rows, i, title)})
+	}
+
+	return utils.OutputTable(rows, columns)
+}
+
+// listDetails is a Contributor Insight as shown by "pizza lists show" and the
+// commands changing it
+type listDetails struct {
+	ID           string    `json:"id" yaml:"id"`
+	Name         string    `json:"name" yaml:"name"`
+	WorkspaceID  string    `json:"workspace_id" yaml:"workspace_id"`
+	IsPublic     bool      `json:"is_public" yaml:"is_public"`
+	Contributors []string  `json:"contributors" yaml:"contributors"`
+	URL          string    `json:"url" yaml:"url"`
+	CreatedAt    time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+func newListDetails(workspaceID string, list *userlists.DbUserList) *listDetails {
+	return &listDetails{
+		ID:           list.ID,
+		Name:         list.Name,
+		WorkspaceID:  workspaceID,
+		IsPublic:     list.IsPublic,
+		Contributors: contributorLogins(list),
+		URL:          fmt.Sprintf("%s/workspaces/%s/contributor-insights/%s", constants.AppURL, workspaceID, list.ID),
+		CreatedAt:    list.CreatedAt,
+		UpdatedAt:    list.UpdatedAt,
+	}
+}
+
+func (ld *listDetails) BuildOutput(format string) (string, error) {
+	switch format {
+	case constants.OutputTable:
+		return ld.OutputTable(), nil
+	case constants.OutputJSON:
+		return utils.OutputJSON(ld)
+	case constants.OutputYAML:
+		return utils.OutputYAML(ld)
+	default:
+		return "", fmt.Errorf("unknown output format %s", format)
+	}
+}
+
+func (ld *listDetails) OutputTable() string {
+	rows := []bubblesTable.Row{
+		{"ID", ld.ID},
+		{"Public", strconv.FormatBool(ld.IsPublic)},
+	}
+
+	// One contributor per row, the first next to the title
+	for i, login := range ld.Contributors {
+		title := ""
+		if i == 0 {
+			title = "Contributors"
+		}
+		rows = append(rows, bubblesTable.Row{title, login})
+	}
+	if len(ld.Contributors) == 0 {
+		rows = append(rows, bubblesTable.Row{"Contributors", "none"})
+	}
+
+	rows = append(rows, bubblesTable.Row{"URL", ld.URL})
+
+	columns := []bubblesTable.Column{
+		{
+			Title: "Contributor Insight",
+			Width: utils.GetMaxTableColumnWidth(rows, 0, "Contributor Insight"),
+		},
+		{
+			Title: ld.Name,
+			Width: utils.GetMaxTableColumnWidth(rows, 1, ld.Name),
+		},
+	}
+
+	return utils.OutputTable(rows, columns)
+}
+
+// writeList writes the Contributor Insight of the workspace
+func writeList(workspaceID string, list *userlists.DbUserList, format string, out io.Writer) error {
+	output, err := newListDetails(workspaceID, list).BuildOutput(format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, output)
+	return nil
+}
@@ -0,0 +1,69 @@
+package lists
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type showOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// List is the ID or name of the Contributor Insight
+	List string
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the Contributor Insight is written to
+	out io.Writer
+}
+
+// NewShowCommand returns a new cobra command for 'pizza lists show'
+func NewShowCommand() *cobra.Command {
+	opts := &showOptions{}
+	cmd := &cobra.Command{
+		Use:   "show list",
+		Short: "Show a Contributor Insight along with its contributors",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Workspace, _ = cmd.Flags().GetString(constants.FlagNameWorkspace)
+			opts.List = args[0]
+			opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+func (opts *showOptions) run(ctx context.Context) error {
+	ws, err := workspace.FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	list, err := findList(ctx, opts.APIClient, ws.ID, opts.List)
+	if err != nil {
+		return err
+	}
+
+	return writeList(ws.ID, list, opts.Output, opts.out)
+}
@@ -0,0 +1,152 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+type syncOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// List is the ID or name of the Contributor Insight
+	List string
+
+	// Logins are the GitHub logins the contributors should be
+	Logins []string
+
+	// FilePath is the path to a yaml file with more GitHub logins
+	FilePath string
+
+	// Yes applies the changes without asking for confirmation
+	Yes bool
+
+	// Output is the formatting style for command output
+	Output string
+
+	// in is where the confirmation is read from, errOut where the changes are
+	// shown and the confirmation asked on, and out where the synced
+	// Contributor Insight is written to
+	in     io.Reader
+	errOut io.Writer
+	out    io.Writer
+}
+
+// NewSyncCommand returns a new cobra command for 'pizza lists sync'
+func NewSyncCommand() *cobra.Command {
+	opts := &syncOptions{}
+	cmd := &cobra.Command{
+		Use:   "sync list [login...] [flags]",
+		Short: "Make the contributors of a Contributor Insight exactly the given logins",
+		Long: `Make the contributors of a Contributor Insight exactly the GitHub logins given as
+arguments and in the yaml file: the missing ones are added and the others removed.
+
+The changes are shown as a diff and applied once confirmed.`,
+		Example: `  # Sync a Contributor Insight with the logins listed in a yaml file
+  $ pizza lists sync Maintainers --file ./maintainers.yaml
+
+  # Sync without asking for confirmation, as in CI
+  $ pizza lists sync Maintainers jpmcb zeucapua --yes`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Workspace, _ = cmd.Flags().GetString(constants.FlagNameWorkspace)
+			opts.List = args[0]
+			opts.Logins = args[1:]
+			opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.in = cmd.InOrStdin()
+			opts.errOut = cmd.ErrOrStderr()
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.FilePath, constants.FlagNameFile, "f", "", "Path to yaml file containing a list of GitHub logins")
+	cmd.Flags().BoolVarP(&opts.Yes, constants.FlagNameYes, "y", false, "Apply the changes without asking for confirmation")
+	return cmd
+}
+
+func (opts *syncOptions) run(ctx context.Context) error {
+	logins, err := readLogins(opts.Logins, opts.FilePath)
+	if err != nil {
+		return err
+	}
+	if len(logins) == 0 {
+		return fmt.Errorf("no GitHub logins to sync with, give them as arguments or with --%s", constants.FlagNameFile)
+	}
+
+	ws, err := workspace.FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	list, err := findList(ctx, opts.APIClient, ws.ID, opts.List)
+	if err != nil {
+		return err
+	}
+
+	// Logins differing in case only are the same contributor, keep them as
+	// they are in the list
+	contributors := contributorLogins(list)
+	for i, login := range logins {
+		if j := slices.IndexFunc(contributors, func(contributor string) bool { return strings.EqualFold(contributor, login) }); j >= 0 {
+			logins[i] = contributors[j]
+		}
+	}
+
+	diff := utils.UnifiedDiff(list.Name, list.Name, loginLines(contributors), loginLines(logins))
+	if diff == "" {
+		fmt.Fprintf(opts.errOut, "Contributor insight %s is already in sync\n", list.Name)
+		return writeList(ws.ID, list, opts.Output, opts.out)
+	}
+	fmt.Fprint(opts.errOut, diff)
+
+	if !opts.Yes {
+		ok, err := utils.Confirm(opts.in, opts.errOut, fmt.Sprintf("Apply these changes to contributor insight %s?", list.Name))
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	synced, _, err := opts.APIClient.WorkspacesService.UserListService.PatchUserListForUser(ctx, ws.ID, list.ID, list.Name, logins, list.IsPublic)
+	if err != nil {
+		return fmt.Errorf("could not sync contributor insight %s: %w", list.Name, err)
+	}
+
+	return writeList(ws.ID, synced, opts.Output, opts.out)
+}
+
+// loginLines returns the logins sorted case insensitively, one per line, for
+// diffing
+func loginLines(logins []string) string {
+	sorted := slices.Clone(logins)
+	slices.SortFunc(sorted, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+
+	var b strings.Builder
+	for _, login := range sorted {
+		b.WriteString(login + "\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package offboard
+
+import (
+	"slices"
+	"strings"
+
+	ownersfile "github.com/open-sauced/pizza-cli/v2/pkg/codeowners"
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+)
+
+// identity is an offboarding user resolved against the config and ".mailmap"
+type identity struct {
+	// the user as given on the command line
+	user string
+
+	// the attribution name of the user, empty if the user isn't attributed in the config
+	name string
+
+	// every email known for the user, normalized
+	emails []string
+
+	// the "@login" and emails the user may be listed as in owners files
+	owners []string
+}
+
+// displayName returns the attribution name, or the user as given if there is none
+func (id *identity) displayName() string {
+	if id.name != "" {
+		return id.name
+	}
+
+	return id.user
+}
+
+// resolveIdentities resolves the offboarding users, given as GitHub logins or
+// emails, using the same normalization as codeowners generation: emails are
+// case folded and mapped through the ".mailmap", and GitHub noreply emails
+// resolve to their login.
+func resolveIdentities(spec *config.Spec, mailmap *config.Mailmap, users []string) []*identity {
+	identities := make([]*identity, 0, len(users))
+
+	for _, user := range users {
+		id := &identity{user: user}
+
+		if ownersfile.IsEmail(user) {
+			_, canonical := mailmap.Lookup("", user)
+			id.addEmail(user)
+			id.addEmail(canonical)
+
+			if name, ok := spec.ResolveIdentity(canonical); ok {
+				id.name = name
+			} else if name, ok := spec.ResolveIdentity(user); ok {
+				id.name = name
+			}
+
+			if login := config.NoreplyLogin(canonical); login != "" {
+				id.addOwner("@" + login)
+			}
+		} else {
+			login := strings.TrimPrefix(user, "@")
+			id.addOwner("@" + login)
+
+			if name, ok := spec.ResolveIdentity(login); ok {
+				id.name = name
+			}
+		}
+
+		if id.name != "" {
+			id.addOwner("@" + id.name)
+			for _, email := range spec.Attributions[id.name] {
+				id.addEmail(email)
+			}
+		}
+
+		identities = append(identities, id)
+	}
+
+	return identities
+}
+
+func (id *identity) addEmail(email string) {
+	email = config.NormalizeEmail(email)
+	if !slices.Contains(id.emails, email) {
+		id.emails = append(id.emails, email)
+	}
+
+	id.addOwner(email)
+}
+
+func (id *identity) addOwner(owner string) {
+	if !slices.ContainsFunc(id.owners, func(o string) bool { return ownersfile.OwnerEqual(o, owner) }) {
+		id.owners = append(id.owners, owner)
+	}
+}
+
+// removeIdentities removes the identities from the config: their attributions
+// are deleted, their emails are removed from any team attributions, and they
+// are removed from the attribution fallback. The names of the teams the
+// identities were removed from are returned.
+func removeIdentities(spec *config.Spec, identities []*identity) []string {
+	var teams []string
+
+	for _, id := range identities {
+		if id.name != "" {
+			delete(spec.Attributions, id.name)
+		}
+
+		for name, emails := range spec.Attributions {
+			if !config.IsTeamName(name) {
+				continue
+			}
+
+			kept := slices.DeleteFunc(slices.Clone(emails), func(email string) bool {
+				return slices.Contains(id.emails, config.NormalizeEmail(email))
+			})
+
+			if len(kept) != len(emails) {
+				spec.Attributions[name] = kept
+				if !slices.Contains(teams, name) {
+					teams = append(teams, name)
+				}
+			}
+		}
+
+		spec.AttributionFallback = slices.DeleteFunc(spec.AttributionFallback, func(fallback string) bool {
+			return slices.ContainsFunc(id.owners, func(owner string) bool {
+				return ownersfile.OwnerEqual(owner, fallback)
+			})
+		})
+	}
+
+	slices.Sort(teams)
+	return teams
+}
+
+// matchesAnything checks if the identity is attributed in the config, is a
+// member of a team attribution, or is one of the owners of the owners file
+func matchesAnything(spec *config.Spec, id *identity, owners []string) bool {
+	if id.name != "" {
+		return true
+	}
+
+	for name, emails := range spec.Attributions {
+		if config.IsTeamName(name) && slices.ContainsFunc(emails, func(email string) bool {
+			return slices.Contains(id.emails, config.NormalizeEmail(email))
+		}) {
+			return true
+		}
+	}
+
+	return slices.ContainsFunc(owners, func(owner string) bool {
+		return slices.ContainsFunc(id.owners, func(o string) bool {
+			return ownersfile.OwnerEqual(o, owner)
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package offboard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+)
+
+func TestResolveAndRemoveIdentities(t *testing.T) {
+	t.Parallel()
+
+	spec := &config.Spec{
+		Attributions: map[string][]string{
+			"jpmcb":                   {"john@opensauced.pizza"},
+			"zeucapua":                {"coding@zeu.dev"},
+			"open-sauced/engineering": {"John@OpenSauced.pizza", "coding@zeu.dev"},
+		},
+		AttributionFallback: []string{"jpmcb", "open-sauced/engineering"},
+	}
+
+	mailmap, err := config.ParseMailmap(strings.NewReader("<john@opensauced.pizza> <john@personal.com>\n"))
+	require.NoError(t, err)
+
+	identities := resolveIdentities(spec, mailmap, []string{"John@Personal.com", "nobody"})
+	require.Len(t, identities, 2)
+
+	assert.Equal(t, "jpmcb", identities[0].name)
+	assert.ElementsMatch(t, []string{"john@personal.com", "john@opensauced.pizza", "@jpmcb"}, identities[0].owners)
+	assert.True(t, matchesAnything(spec, identities[0], nil))
+
+	assert.Equal(t, "", identities[1].name)
+	assert.Equal(t, "nobody", identities[1].displayName())
+	assert.False(t, matchesAnything(spec, identities[1], ownersIn(CodeownersFormat, "a.go @jpmcb\n")))
+	assert.True(t, matchesAnything(spec, identities[1], ownersIn(CodeownersFormat, "a.go @Nobody\n")))
+
+	teams := removeIdentities(spec, identities)
+	assert.Equal(t, []string{"open-sauced/engineering"}, teams)
+	assert.Equal(t, map[string][]string{
+		"zeucapua":                {"coding@zeu.dev"},
+		"open-sauced/engineering": {"coding@zeu.dev"},
+	}, spec.Attributions)
+	assert.Equal(t, []string{"open-sauced/engineering"}, spec.AttributionFallback)
+}
@@ -3,13 +3,22 @@ package offboard
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
+	"sort"
 	"strings"
 
+	"github.com/jpmcb/gopherlogs"
+	"github.com/jpmcb/gopherlogs/pkg/colors"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
+	"github.com/open-sauced/pizza-cli/v2/cmd/generate/codeowners"
+	"github.com/open-sauced/pizza-cli/v2/pkg/backup"
 	"github.com/open-sauced/pizza-cli/v2/pkg/config"
 	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/logging"
 	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
 )
 
@@ -19,26 +28,101 @@ type Options struct {
 	// config file path
 	configPath string
 
-	// repository path
+	// repository path, or a glob of repository paths
 	path string
 
+	// a YAML file listing repository paths
+	reposFile string
+
+	// the branch to create and commit the changes to in each repository.
+	// When empty, the changes are left uncommitted.
+	branch string
+
+	// the commit message used with branch
+	commitMessage string
+
+	// the login or team promoted in place of the offboarded users.
+	// When empty, the next highest contributors are promoted.
+	successor string
+
+	// the number of days of git history to look back when promoting contributors
+	previousDays int
+
+	// whether to only print the changes instead of writing them
+	dryRun bool
+
+	// whether to restore the files changed by the previous offboard
+	undo bool
+
 	// from global config
 	ttyDisabled bool
+	loglevel    int
+	logger      gopherlogs.Logger
 
 	// telemetry for capturing CLI events via PostHog
 	telemetry *utils.PosthogCliClient
 }
 
 const offboardLongDesc string = `CAUTION: Experimental Command. Removes users from the \".sauced.yaml\" config and \"CODEOWNERS\" files.
-Requires the users' name OR email.`
+Requires the users' name OR email.
+
+Users are resolved the same way as during codeowners generation: logins and
+emails are compared case insensitively, emails are mapped through the
+repository's ".mailmap", and GitHub noreply emails resolve to their login.
+The users' emails are also removed from any team attributions. A warning is
+logged for any user that matches nothing.
+
+Many repositories can be offboarded at once by passing a glob of local checkouts
+to "--path" and/or a YAML list of repository paths with "--repos-file".
+With "--branch", the changes in each repository are committed to a new branch,
+or to that branch if it's already checked out. A git author must be configured
+and nothing else may be staged.
+
+Paths in CODEOWNERS that lose an owner have the vacated slot filled: by the
+"--successor" login or team if given, otherwise by the next highest contributor
+to the files matching that path in the git history. Paths that would be left
+without owners are reported. OWNERS files generated with "pizza generate
+codeowners --owners-style-file" are edited the same way, other OWNERS files
+are left unchanged.
+
+The previous versions of both files are backed up in "~/.pizza-cli/backups"
+and can be restored with "--undo".`
+
+const offboardExamples string = `  # Offboard a user from the repository in the current directory
+  $ pizza offboard jpmcb --path .
+
+  # Preview the changes without writing them
+  $ pizza offboard jpmcb --path . --dry-run
+
+  # Hand over ownership to a team
+  $ pizza offboard jpmcb --path . --successor @open-sauced/engineering
+
+  # Offboard a user from every checkout in a directory, committing to a branch
+  $ pizza offboard jpmcb --path "~/src/open-sauced/*" --branch offboard-jpmcb
+
+  # Offboard a user from the repositories listed in a file
+  $ pizza offboard jpmcb --repos-file repos.yaml
+
+  # Restore the files changed by the previous offboard
+  $ pizza offboard --undo --path .`
 
 func NewConfigCommand() *cobra.Command {
 	opts := &Options{}
 	cmd := &cobra.Command{
-		Use:   "offboard <username/email> [flags]",
-		Short: "CAUTION: Experimental Command. Removes users from the \".sauced.yaml\" config and \"CODEOWNERS\" files.",
-		Long:  offboardLongDesc,
-		Args: func(_ *cobra.Command, args []string) error {
+		Use:     "offboard <username/email> [flags]",
+		Short:   "CAUTION: Experimental Command. Removes users from the \".sauced.yaml\" config and \"CODEOWNERS\" files.",
+		Long:    offboardLongDesc,
+		Example: offboardExamples,
+		Args: func(cmd *cobra.Command, args []string) error {
+			undo, _ := cmd.Flags().GetBool("undo")
+			if undo {
+				if len(args) != 0 {
+					return errors.New("no arguments may be given with --undo")
+				}
+
+				return nil
+			}
+
 			if len(args) == 0 {
 				return errors.New("you must provide at least one argument: the offboarding user's email/username")
 			}
@@ -55,6 +139,27 @@ func NewConfigCommand() *cobra.Command {
 			opts.telemetry = utils.NewPosthogCliClient(!disableTelem)
 
 			opts.path, _ = cmd.Flags().GetString("path")
+			opts.reposFile, _ = cmd.Flags().GetString("repos-file")
+			opts.branch, _ = cmd.Flags().GetString("branch")
+			opts.commitMessage, _ = cmd.Flags().GetString("commit-message")
+			opts.successor, _ = cmd.Flags().GetString("successor")
+			opts.previousDays, _ = cmd.Flags().GetInt("range")
+			opts.dryRun, _ = cmd.Flags().GetBool("dry-run")
+			opts.undo, _ = cmd.Flags().GetBool("undo")
+
+			loglevelS, _ := cmd.Flags().GetString("log-level")
+
+			switch loglevelS {
+			case "error":
+				opts.loglevel = logging.LogError
+			case "warn":
+				opts.loglevel = logging.LogWarn
+			case "info":
+				opts.loglevel = logging.LogInfo
+			case "debug":
+				opts.loglevel = logging.LogDebug
+			}
+
 			err := run(opts)
 			_ = opts.telemetry.Done()
 
@@ -62,78 +167,367 @@ func NewConfigCommand() *cobra.Command {
 		},
 	}
 
-	cmd.PersistentFlags().StringP("path", "p", "", "the path to the repository (required)")
-	if err := cmd.MarkPersistentFlagRequired("path"); err != nil {
-		fmt.Printf("error MarkPersistentFlagRequired: %v", err)
-	}
+	cmd.PersistentFlags().StringP("path", "p", "", "the path to the repository, or a glob of repository paths")
+	cmd.Flags().String("repos-file", "", "The path to a YAML file listing the paths of repositories to offboard from")
+	cmd.MarkFlagsOneRequired("path", "repos-file")
+
+	cmd.Flags().String("successor", "", "The GitHub login or team to promote in place of the offboarded users. Defaults to the next highest contributor")
+	cmd.Flags().IntP("range", "r", 90, "The number of days of git history to analyze when promoting the next highest contributor")
+	cmd.Flags().Bool("dry-run", false, "Print a diff of the changes instead of writing them")
+	cmd.Flags().Bool("undo", false, "Restore the files changed by the previous offboard of the repositories at the given paths")
+	cmd.Flags().String("branch", "", "Create this branch in each repository and commit the changes to it")
+	cmd.Flags().String("commit-message", "", "The commit message used with --branch. Defaults to \"Offboard <users>\"")
 	return cmd
 }
 
 func run(opts *Options) error {
-	var spec *config.Spec
 	var err error
-	if len(opts.configPath) != 0 {
-		spec, _, err = config.LoadConfig(opts.configPath)
-	} else {
-		var configPath string
-		if strings.Compare(string(opts.path[len(opts.path)-1]), "/") == 0 {
-			configPath = opts.path + ".sauced.yaml"
+	opts.logger, err = gopherlogs.NewLogger(
+		gopherlogs.WithLogVerbosity(opts.loglevel),
+		gopherlogs.WithTty(!opts.ttyDisabled),
+	)
+	if err != nil {
+		return fmt.Errorf("could not build logger: %w", err)
+	}
+
+	repoPaths, err := resolveRepositoryPaths(opts.path, opts.reposFile)
+	if err != nil {
+		_ = opts.telemetry.CaptureFailedOffboard()
+		return err
+	}
+
+	if opts.undo {
+		return undo(opts, repoPaths)
+	}
+
+	results := make([]*repositoryResult, 0, len(repoPaths))
+	for _, repoPath := range repoPaths {
+		result, err := offboardRepository(opts, repoPath)
+		if err != nil {
+			_ = opts.telemetry.CaptureFailedOffboard()
+			result.err = err
 		} else {
-			configPath = opts.path + "/.sauced.yaml"
+			_ = opts.telemetry.CaptureOffboard()
+		}
+
+		results = append(results, result)
+	}
+
+	// A single repository keeps its original error so the failure is clear
+	if len(results) == 1 && results[0].err != nil {
+		return results[0].err
+	}
+
+	printReport(opts, results)
+
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
 		}
-		spec, _, err = config.LoadConfig(configPath)
 	}
 
+	if failed > 0 {
+		return fmt.Errorf("could not offboard %d of %d repositories", failed, len(results))
+	}
+
+	return nil
+}
+
+// offboardRepository removes the offboarding users from the config and owners
+// files of a single repository. The returned result is never nil.
+func offboardRepository(opts *Options, repoPath string) (*repositoryResult, error) {
+	result := &repositoryResult{
+		path:   repoPath,
+		report: &ownersReport{},
+	}
+
+	configPath := opts.configPath
+	if len(configPath) == 0 {
+		configPath = filepath.Join(repoPath, ".sauced.yaml")
+	}
+
+	configPath, err := filepath.Abs(configPath)
 	if err != nil {
-		_ = opts.telemetry.CaptureFailedOffboard()
-		return fmt.Errorf("error loading config: %v", err)
+		return result, fmt.Errorf("error resolving absolute path: %v", err)
+	}
+
+	spec, loadedPath, err := config.LoadConfig(configPath)
+	if err != nil {
+		return result, fmt.Errorf("error loading config: %v", err)
 	}
 
-	var offboardingNames []string
-	attributions := spec.Attributions
-	for _, user := range opts.offboardingUsers {
-		added := false
+	// LoadConfig falls back to "~/.sauced.yaml", which mustn't be copied into the repository
+	if loadedPath != configPath {
+		return result, fmt.Errorf("no config at %s to offboard from, only %s was found", configPath, loadedPath)
+	}
 
-		// deletes if the user is a name (key)
-		delete(attributions, user)
+	// The loaded spec has any includes merged in: edit only the local file's contents
+	originalConfig, err := os.ReadFile(configPath)
+	if err != nil {
+		return result, fmt.Errorf("error reading config: %v", err)
+	}
 
-		// delete if the user is an email (value)
-		for k, v := range attributions {
-			if slices.Contains(v, user) {
-				offboardingNames = append(offboardingNames, k)
-				delete(attributions, k)
-				added = true
-			}
+	localSpec := &config.Spec{}
+	if err := yaml.Unmarshal(originalConfig, localSpec); err != nil {
+		return result, fmt.Errorf("error loading config: %v", err)
+	}
+
+	mailmap, err := config.LoadMailmap(repoPath)
+	if err != nil {
+		return result, fmt.Errorf("error loading mailmap: %v", err)
+	}
+
+	// The config is only rewritten if the users are actually removed from it
+	localYAML, err := utils.OutputYAML(localSpec)
+	if err != nil {
+		return result, fmt.Errorf("error generating config file: %v", err)
+	}
+
+	identities := resolveIdentities(spec, mailmap, opts.offboardingUsers)
+	result.teams = removeIdentities(localSpec, identities)
+
+	var names, owners []string
+	for _, id := range identities {
+		result.offboarded = append(result.offboarded, id.displayName())
+		owners = append(owners, id.owners...)
+		if id.name != "" {
+			names = append(names, id.name)
+		}
+	}
+
+	newConfig, err := GenerateConfigFile(string(originalConfig), localSpec)
+	if err != nil {
+		return result, fmt.Errorf("error generating config file: %v", err)
+	}
+
+	if updatedYAML, _ := utils.OutputYAML(localSpec); updatedYAML == localYAML {
+		newConfig = string(originalConfig)
+	}
+
+	ownersPath, originalOwners, err := FindOwnersFile(repoPath)
+	if err != nil {
+		return result, fmt.Errorf("error generating owners file: %v", err)
+	}
+
+	format := DetectOwnersFormat(ownersPath, originalOwners)
+	successors := opts.getSuccessorsFunc(repoPath, spec, names)
+
+	newOwners := originalOwners
+	switch {
+	case ownersPath == "":
+		opts.logger.V(logging.LogWarn).Style(0, colors.FgYellow).Infof("No CODEOWNERS or OWNERS file found in: %s\n", repoPath)
+	case format == UnsupportedOwnersFormat:
+		opts.logger.V(logging.LogWarn).Style(0, colors.FgYellow).Infof("Leaving %s unchanged: only OWNERS files generated with \"--owners-style-file\" can be offboarded from\n", ownersPath)
+		ownersPath = ""
+	case format == OwnersStyleFormat:
+		newOwners, result.report, err = generateOwnersStyleFile(originalOwners, owners, spec, successors)
+	default:
+		newOwners, result.report, err = generateOwnersFile(originalOwners, owners, successors)
+	}
+	if err != nil {
+		return result, fmt.Errorf("error generating owners file: %v", err)
+	}
+
+	for _, id := range identities {
+		if !matchesAnything(spec, id, ownersIn(format, originalOwners)) {
+			opts.logger.V(logging.LogWarn).Style(0, colors.FgYellow).Infof("%s did not match any attribution, team, or owner in: %s\n", id.user, repoPath)
 		}
+	}
 
-		if !added {
-			offboardingNames = append(offboardingNames, user)
+	configChanged := newConfig != string(originalConfig)
+	ownersChanged := ownersPath != "" && newOwners != originalOwners
+
+	if opts.dryRun {
+		fmt.Print(utils.UnifiedDiff(configPath, configPath, string(originalConfig), newConfig))
+		if ownersPath != "" {
+			fmt.Print(utils.UnifiedDiff(ownersPath, ownersPath, originalOwners, newOwners))
 		}
+
+		return result, nil
 	}
 
-	if len(opts.configPath) != 0 {
-		err = generateConfigFile(opts.configPath, attributions)
-	} else {
-		var configPath string
-		if strings.Compare(string(opts.path[len(opts.path)-1]), "/") == 0 {
-			configPath = opts.path + ".sauced.yaml"
-		} else {
-			configPath = opts.path + "/.sauced.yaml"
+	if !configChanged && !ownersChanged {
+		return result, nil
+	}
+
+	backupPaths := []string{configPath}
+	if ownersPath != "" {
+		backupPaths = append(backupPaths, ownersPath)
+	}
+
+	// Nothing is changed unless the changes can be committed
+	var commit *branchCommit
+	if opts.branch != "" {
+		commit, err = prepareCommit(repoPath, opts.branch, backupPaths)
+		if err != nil {
+			return result, fmt.Errorf("error committing changes: %v", err)
 		}
-		err = generateConfigFile(configPath, attributions)
 	}
 
+	manifest, err := backup.Create("offboard", repoPath, backupPaths)
 	if err != nil {
-		_ = opts.telemetry.CaptureFailedOffboard()
-		return fmt.Errorf("error generating config file: %v", err)
+		return result, fmt.Errorf("error backing up files: %v", err)
 	}
+	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Backed up files to: %s\n", manifest.Dir())
 
-	err = generateOwnersFile(opts.path, offboardingNames)
-	if err != nil {
-		_ = opts.telemetry.CaptureFailedOffboard()
-		return fmt.Errorf("error generating owners file: %v", err)
+	if configChanged {
+		if err := os.WriteFile(configPath, []byte(newConfig), 0600); err != nil {
+			return result, fmt.Errorf("error generating config file: %v", err)
+		}
+
+		result.changed = append(result.changed, configPath)
 	}
 
-	_ = opts.telemetry.CaptureOffboard()
-	return nil
+	if ownersChanged {
+		if err := os.WriteFile(ownersPath, []byte(newOwners), 0600); err != nil {
+			return result, fmt.Errorf("error generating owners file: %v", err)
+		}
+
+		result.changed = append(result.changed, ownersPath)
+	}
+
+	if commit != nil && len(result.changed) > 0 {
+		message := opts.commitMessage
+		if message == "" {
+			message = "Offboard " + strings.Join(result.offboarded, ", ")
+		}
+
+		result.commit, err = commit.commit(message, result.changed)
+		if err != nil {
+			return result, fmt.Errorf("error committing changes: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+// getSuccessorsFunc returns the function used to find the owners promoted in
+// place of the offboarded users. The git history is only traversed once and
+// only if a pattern actually needs a successor.
+func (opts *Options) getSuccessorsFunc(repoPath string, spec *config.Spec, offboardingNames []string) successorsFunc {
+	if opts.successor != "" {
+		return func(string) ([]string, error) {
+			return []string{opts.successor}, nil
+		}
+	}
+
+	var successors *codeowners.Successors
+	return func(pattern string) ([]string, error) {
+		if successors == nil {
+			var err error
+			successors, err = codeowners.FindSuccessors(repoPath, opts.previousDays, spec, offboardingNames, 3, opts.logger)
+			if err != nil {
+				return nil, fmt.Errorf("could not find successors: %w", err)
+			}
+		}
+
+		return successors.For(pattern), nil
+	}
+}
+
+// printReport prints the consolidated report of the changes made to each repository
+func printReport(opts *Options, results []*repositoryResult) {
+	for _, result := range results {
+		opts.logger.V(logging.LogInfo).Style(0, colors.FgCyan).Infof("%s:\n", result.path)
+
+		if result.err != nil {
+			opts.logger.V(logging.LogError).Style(0, colors.FgRed).Infof("  Error: %v\n", result.err)
+			continue
+		}
+
+		printOwnersReport(opts, result.report)
+
+		if opts.dryRun {
+			continue
+		}
+
+		if len(result.changed) == 0 {
+			opts.logger.V(logging.LogInfo).Style(0, colors.Reset).Infof("  No changes\n")
+			continue
+		}
+
+		for _, team := range result.teams {
+			opts.logger.V(logging.LogInfo).Style(0, colors.Reset).Infof("  Removed from team: %s\n", team)
+		}
+
+		for _, path := range result.changed {
+			opts.logger.V(logging.LogInfo).Style(0, colors.Reset).Infof("  Changed: %s\n", path)
+		}
+
+		if result.commit != "" {
+			opts.logger.V(logging.LogInfo).Style(0, colors.Reset).Infof("  Committed %s to branch: %s\n", result.commit, opts.branch)
+		}
+
+		opts.logger.V(logging.LogInfo).Style(0, colors.FgGreen).Infof("  Offboarded: %s\n", strings.Join(result.offboarded, ", "))
+	}
+
+	changed := slices.ContainsFunc(results, func(result *repositoryResult) bool {
+		return len(result.changed) > 0
+	})
+
+	if !opts.dryRun && changed {
+		opts.logger.V(logging.LogInfo).Style(0, colors.FgGreen).Infof("Undo with: pizza offboard --undo %s\n", undoArgs(opts))
+	}
+}
+
+func printOwnersReport(opts *Options, report *ownersReport) {
+	patterns := make([]string, 0, len(report.reassigned))
+	for pattern := range report.reassigned {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	if len(patterns) > 0 {
+		opts.logger.V(logging.LogInfo).Style(0, colors.FgCyan).Infof("  Reassigned ownership:\n")
+		for _, pattern := range patterns {
+			opts.logger.V(logging.LogInfo).Style(0, colors.Reset).Infof("    %s -> %s\n", pattern, strings.Join(report.reassigned[pattern], " "))
+		}
+	}
+
+	if len(report.unowned) > 0 {
+		opts.logger.V(logging.LogWarn).Style(0, colors.FgYellow).Infof("  Paths left without owners:\n")
+		for _, pattern := range report.unowned {
+			opts.logger.V(logging.LogWarn).Style(0, colors.Reset).Infof("    %s\n", pattern)
+		}
+	}
+}
+
+// undoArgs returns the flags selecting the repositories for the undo hint
+func undoArgs(opts *Options) string {
+	var args []string
+	if opts.path != "" {
+		args = append(args, fmt.Sprintf("--path %q", opts.path))
+	}
+
+	if opts.reposFile != "" {
+		args = append(args, fmt.Sprintf("--repos-file %q", opts.reposFile))
+	}
+
+	return strings.Join(args, " ")
+}
+
+// undo restores the files changed by the previous offboard of each repository.
+// Repositories are restored in reverse so files shared between them, like a
+// "--config" file, end up in their original state.
+func undo(opts *Options, repoPaths []string) error {
+	var errs []error
+	for i := len(repoPaths) - 1; i >= 0; i-- {
+		manifest, err := backup.Latest("offboard", repoPaths[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not undo offboard for %s: %w", repoPaths[i], err))
+			continue
+		}
+
+		if err := manifest.Restore(); err != nil {
+			errs = append(errs, fmt.Errorf("could not restore backup %s: %w", manifest.ID, err))
+			continue
+		}
+
+		for _, file := range manifest.Files {
+			opts.logger.V(logging.LogInfo).Style(0, colors.FgGreen).Infof("Restored: %s\n", file.Path)
+		}
+	}
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,97 @@
+package offboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jpmcb/gopherlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/logging"
+)
+
+func TestOffboardOwnersStyleFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	repoPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, ".sauced.yaml"), []byte(`attribution:
+  jpmcb:
+    - john@opensauced.pizza
+  zeucapua:
+    - coding@zeu.dev
+`), 0600))
+
+	// As written by "pizza generate codeowners --owners-style-file"
+	ownersPath := filepath.Join(repoPath, "OWNERS")
+	require.NoError(t, os.WriteFile(ownersPath, []byte(`# This file is generated automatically by OpenSauced pizza-cli. DO NOT EDIT. Stay saucy!
+#
+# Generated with command:
+# $ pizza generate codeowners pizza-cli/ --owners-style-file true
+
+README.md
+  - John McBride
+    - john@opensauced.pizza
+  - Zeu Capua
+    - coding@zeu.dev
+src/main.go
+  - John McBride
+    - john@opensauced.pizza
+`), 0600))
+
+	logger, err := gopherlogs.NewLogger(gopherlogs.WithLogVerbosity(logging.LogError))
+	require.NoError(t, err)
+
+	opts := &Options{
+		offboardingUsers: []string{"jpmcb"},
+		successor:        "@zeucapua",
+		logger:           logger,
+	}
+
+	result, err := offboardRepository(opts, repoPath)
+	require.NoError(t, err)
+	assert.Contains(t, result.changed, ownersPath)
+	assert.Equal(t, map[string][]string{"src/main.go": {"@zeucapua"}}, result.report.reassigned)
+	assert.Empty(t, result.report.unowned)
+
+	owners, err := os.ReadFile(ownersPath)
+	require.NoError(t, err)
+
+	expected := `# This file is generated automatically by OpenSauced pizza-cli. DO NOT EDIT. Stay saucy!
+#
+# Generated with command:
+# $ pizza generate codeowners pizza-cli/ --owners-style-file true
+
+README.md
+  - Zeu Capua
+    - coding@zeu.dev
+src/main.go
+  - zeucapua
+    - coding@zeu.dev
+`
+	assert.Equal(t, expected, string(owners))
+}
+
+func TestOffboardKubernetesOwnersFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	repoPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, ".sauced.yaml"), []byte("attribution:\n  jpmcb:\n    - john@opensauced.pizza\n"), 0600))
+
+	ownersPath := filepath.Join(repoPath, "OWNERS")
+	original := "approvers:\n  - jpmcb\n"
+	require.NoError(t, os.WriteFile(ownersPath, []byte(original), 0600))
+
+	logger, err := gopherlogs.NewLogger(gopherlogs.WithLogVerbosity(logging.LogError))
+	require.NoError(t, err)
+
+	result, err := offboardRepository(&Options{offboardingUsers: []string{"jpmcb"}, logger: logger}, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(repoPath, ".sauced.yaml")}, result.changed)
+
+	// Only OWNERS files written by pizza are edited
+	owners, err := os.ReadFile(ownersPath)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(owners))
+}
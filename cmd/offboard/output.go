@@ -5,83 +5,242 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	ownersfile "github.com/open-sauced/pizza-cli/v2/pkg/codeowners"
 	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+	"github.com/open-sauced/pizza-cli/v2/pkg/owners"
 	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
 )
 
-func generateConfigFile(outputPath string, attributionMap map[string][]string) error {
-	file, err := os.Create(outputPath)
+// OwnersFormat is the format of an owners file found by FindOwnersFile
+type OwnersFormat int
+
+const (
+	// CodeownersFormat is a GitHub style CODEOWNERS file
+	CodeownersFormat OwnersFormat = iota
+
+	// OwnersStyleFormat is an OWNERS file generated by
+	// "pizza generate codeowners --owners-style-file"
+	OwnersStyleFormat
+
+	// UnsupportedOwnersFormat is any other OWNERS file, like a Kubernetes
+	// style one, which is left unchanged
+	UnsupportedOwnersFormat
+)
+
+// ownersReport describes the ownership changes made to an owners file
+type ownersReport struct {
+	// reassigned maps patterns to the owners promoted in place of the offboarded users
+	reassigned map[string][]string
+
+	// unowned are the patterns left without any owners
+	unowned []string
+}
+
+// successorsFunc returns the candidate owners, in order of preference, to
+// promote for a CODEOWNERS pattern whose owners are being offboarded. The
+// files of an OWNERS style file are given as patterns anchored to the root.
+type successorsFunc func(pattern string) ([]string, error)
+
+// GenerateConfigFile returns the contents of the ".sauced.yaml" file with the
+// given attributions. The leading comment header of the original file is kept.
+func GenerateConfigFile(original string, spec *config.Spec) (string, error) {
+	yaml, err := utils.OutputYAML(spec)
 	if err != nil {
-		return fmt.Errorf("error creating %s file: %w", outputPath, err)
+		return "", fmt.Errorf("failed to turn into YAML: %w", err)
 	}
-	defer file.Close()
 
-	var config config.Spec
-	config.Attributions = attributionMap
+	return getCommentHeader(original) + yaml + "\n", nil
+}
 
-	// for pretty print test
-	yaml, err := utils.OutputYAML(config)
+// getCommentHeader returns the leading comment and blank lines of a file
+func getCommentHeader(contents string) string {
+	var header strings.Builder
 
-	if err != nil {
-		return fmt.Errorf("failed to turn into YAML: %w", err)
+	for _, line := range strings.SplitAfter(contents, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+
+		header.WriteString(line)
 	}
 
-	_, err = file.WriteString(yaml)
+	return header.String()
+}
 
-	if err != nil {
-		return fmt.Errorf("failed to turn into YAML: %w", err)
+// FindOwnersFile returns the path and contents of the CODEOWNERS or OWNERS file
+// in the given repository path. An empty path is returned if there is neither.
+func FindOwnersFile(path string) (string, string, error) {
+	for _, name := range []string{"CODEOWNERS", "OWNERS"} {
+		ownersPath := filepath.Join(path, name)
+
+		owners, err := os.ReadFile(ownersPath)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("error reading %s file: %w", ownersPath, err)
+		}
+
+		return ownersPath, string(owners), nil
 	}
 
-	return nil
+	return "", "", nil
 }
 
-func generateOwnersFile(path string, offboardingUsers []string) error {
-	outputType := "/CODEOWNERS"
-	var owners []byte
-	var err error
-
-	var ownersPath string
-
-	if _, err = os.Stat(filepath.Join(path, "/CODEOWNERS")); !errors.Is(err, os.ErrNotExist) {
-		outputType = "CODEOWNERS"
-		ownersPath = filepath.Join(path, "/CODEOWNERS")
-		owners, err = os.ReadFile(ownersPath)
-	} else if _, err = os.Stat(filepath.Join(path, "OWNERS")); !errors.Is(err, os.ErrNotExist) {
-		outputType = "OWNERS"
-		ownersPath = filepath.Join(path, "/OWNERS")
-		owners, err = os.ReadFile(ownersPath)
+// DetectOwnersFormat returns the format of the owners file at the path with the
+// given contents
+func DetectOwnersFormat(ownersPath string, contents string) OwnersFormat {
+	if filepath.Base(ownersPath) == "CODEOWNERS" {
+		return CodeownersFormat
 	}
 
-	if err != nil {
-		fmt.Printf("will create a new %s file in the path %s", outputType, path)
+	if owners.IsGenerated(contents) {
+		return OwnersStyleFormat
+	}
+
+	return UnsupportedOwnersFormat
+}
+
+// ownersIn returns the owners listed in the contents of an owners file: the
+// "@login", team, and email owners of a CODEOWNERS file, or the emails of an
+// OWNERS style file
+func ownersIn(format OwnersFormat, contents string) []string {
+	switch format {
+	case CodeownersFormat:
+		return ownersfile.ParseString(contents).Owners()
+	case OwnersStyleFormat:
+		if file, err := owners.ParseGenerated(contents); err == nil {
+			return file.Owners()
+		}
+	}
+
+	return nil
+}
+
+// generateOwnersFile returns the contents of the CODEOWNERS file with the offboarding
+// users removed. Patterns that lose an owner have the vacated slots filled by
+// the candidates returned from successors. Lines that aren't changed are kept as is.
+func generateOwnersFile(owners string, offboardingUsers []string, successors successorsFunc) (string, *ownersReport, error) {
+	report := &ownersReport{
+		reassigned: make(map[string][]string),
 	}
 
-	lines := strings.Split(string(owners), "\n")
-	var newLines []string
-	for _, line := range lines {
-		newLine := line
-		for _, name := range offboardingUsers {
-			result, _, found := strings.Cut(newLine, "@"+name)
-			if found {
-				newLine = result
+	file := ownersfile.ParseString(owners)
+
+	for _, rule := range file.Rules() {
+		removed := 0
+		for _, user := range offboardingUsers {
+			if rule.RemoveOwner(user) {
+				removed++
 			}
 		}
-		newLines = append(newLines, newLine)
+
+		if removed == 0 {
+			continue
+		}
+
+		candidates, err := successors(rule.Pattern)
+		if err != nil {
+			return "", nil, err
+		}
+
+		var promoted []string
+		for _, candidate := range candidates {
+			if len(promoted) == removed {
+				break
+			}
+
+			candidate = "@" + strings.TrimPrefix(candidate, "@")
+			if !isOffboardingOwner(candidate, offboardingUsers) && rule.AddOwner(candidate) {
+				promoted = append(promoted, candidate)
+			}
+		}
+
+		if len(promoted) > 0 {
+			report.reassigned[rule.Pattern] = promoted
+		}
+
+		if len(rule.Owners) == 0 {
+			report.unowned = append(report.unowned, rule.Pattern)
+		}
 	}
 
-	output := strings.Join(newLines, "\n")
-	file, err := os.Create(ownersPath)
-	if err != nil {
-		return fmt.Errorf("error creating %s file: %w", outputType, err)
+	return file.String(), report, nil
+}
+
+// generateOwnersStyleFile returns the contents of the OWNERS style file with
+// the offboarding users removed. Owners are matched by email and the vacated
+// slots are filled like in generateOwnersFile, with the candidates listed under
+// their login and the first email attributed to them in the config.
+func generateOwnersStyleFile(contents string, offboardingUsers []string, spec *config.Spec, successors successorsFunc) (string, *ownersReport, error) {
+	report := &ownersReport{
+		reassigned: make(map[string][]string),
 	}
-	defer file.Close()
 
-	_, err = file.WriteString(output)
+	file, err := owners.ParseGenerated(contents)
 	if err != nil {
-		return fmt.Errorf("failed writing file %s: %w", path+outputType, err)
+		return "", nil, err
 	}
 
-	return nil
+	for _, entry := range file.Entries {
+		removed := entry.RemoveOwners(func(owner owners.Owner) bool {
+			return owner.Email != "" && isOffboardingOwner(owner.Email, offboardingUsers)
+		})
+
+		if len(removed) == 0 {
+			continue
+		}
+
+		// Authors attributed to several logins are listed once for each of them
+		var vacated []string
+		for _, owner := range removed {
+			if !isOffboardingOwner(owner.Email, vacated) {
+				vacated = append(vacated, owner.Email)
+			}
+		}
+
+		candidates, err := successors("/" + entry.Path)
+		if err != nil {
+			return "", nil, err
+		}
+
+		var promoted []string
+		for _, candidate := range candidates {
+			if len(promoted) == len(vacated) {
+				break
+			}
+
+			login := ownersfile.Login(candidate)
+			owner := owners.Owner{Name: login}
+			if emails := spec.Attributions[login]; len(emails) > 0 && !config.IsTeamName(login) {
+				owner.Email = emails[0]
+			}
+
+			if !isOffboardingOwner("@"+login, offboardingUsers) && entry.AddOwner(owner) {
+				promoted = append(promoted, "@"+login)
+			}
+		}
+
+		if len(promoted) > 0 {
+			report.reassigned[entry.Path] = promoted
+		}
+
+		if len(entry.Owners) == 0 {
+			report.unowned = append(report.unowned, entry.Path)
+		}
+	}
+
+	return file.String(), report, nil
+}
+
+// isOffboardingOwner checks if a CODEOWNERS owner, either an "@login" or an
+// email, is one of the offboarding users
+func isOffboardingOwner(owner string, offboardingUsers []string) bool {
+	return slices.ContainsFunc(offboardingUsers, func(user string) bool {
+		return ownersfile.OwnerEqual(owner, user)
+	})
 }
@@ -0,0 +1,114 @@
+package offboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+)
+
+func TestGenerateOwnersFile(t *testing.T) {
+	t.Parallel()
+
+	owners := `# This file is generated automatically by OpenSauced pizza-cli. DO NOT EDIT. Stay saucy!
+
+a.go @jpmcb @brandonroberts @zeucapua
+b.go @brandonroberts # hand written comment
+c.go @bobby @zeucapua
+d.go @brandonroberts`
+
+	successors := map[string][]string{
+		"a.go": {"jpmcb", "nickytonline"},
+		"b.go": {"zeucapua"},
+	}
+
+	result, report, err := generateOwnersFile(owners, []string{"brandonroberts", "bob"}, func(pattern string) ([]string, error) {
+		return successors[pattern], nil
+	})
+	require.NoError(t, err)
+
+	expected := `# This file is generated automatically by OpenSauced pizza-cli. DO NOT EDIT. Stay saucy!
+
+a.go @jpmcb @zeucapua @nickytonline
+b.go @zeucapua # hand written comment
+c.go @bobby @zeucapua
+d.go`
+
+	assert.Equal(t, expected, result)
+	assert.Equal(t, map[string][]string{
+		"a.go": {"@nickytonline"},
+		"b.go": {"@zeucapua"},
+	}, report.reassigned)
+	assert.Equal(t, []string{"d.go"}, report.unowned)
+}
+
+func TestGetCommentHeader(t *testing.T) {
+	t.Parallel()
+
+	contents := `# Configuration for attributing commits with emails to GitHub user profiles
+# Used during codeowners generation.
+
+attribution:
+  # not part of the header
+  jpmcb:
+    - john@opensauced.pizza`
+
+	expected := `# Configuration for attributing commits with emails to GitHub user profiles
+# Used during codeowners generation.
+
+`
+
+	assert.Equal(t, expected, getCommentHeader(contents))
+	assert.Equal(t, "", getCommentHeader("attribution: {}"))
+}
+
+func TestGenerateOwnersStyleFile(t *testing.T) {
+	t.Parallel()
+
+	owners := `README.md
+  - John McBride
+    - john@opensauced.pizza
+  - Brandon Roberts
+    - brandon@opensauced.pizza
+docs/setup.md
+  - Brandon Roberts
+    - brandon@opensauced.pizza
+src/main.go
+  - Zeu Capua
+    - coding@zeu.dev
+`
+
+	spec := &config.Spec{
+		Attributions: map[string][]string{
+			"jpmcb":          {"john@opensauced.pizza"},
+			"brandonroberts": {"brandon@opensauced.pizza"},
+			"nickytonline":   {"nick@opensauced.pizza"},
+		},
+	}
+
+	successors := map[string][]string{
+		"/README.md": {"jpmcb", "nickytonline"},
+	}
+
+	result, report, err := generateOwnersStyleFile(owners, []string{"@brandonroberts", "brandon@opensauced.pizza"}, spec, func(pattern string) ([]string, error) {
+		return successors[pattern], nil
+	})
+	require.NoError(t, err)
+
+	expected := `README.md
+  - John McBride
+    - john@opensauced.pizza
+  - nickytonline
+    - nick@opensauced.pizza
+docs/setup.md
+src/main.go
+  - Zeu Capua
+    - coding@zeu.dev
+`
+
+	assert.Equal(t, expected, result)
+	assert.Equal(t, map[string][]string{"README.md": {"@nickytonline"}}, report.reassigned)
+	assert.Equal(t, []string{"docs/setup.md"}, report.unowned)
+}
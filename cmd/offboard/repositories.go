@@ -0,0 +1,293 @@
+package offboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+// repositoryResult is the outcome of offboarding users from a single repository
+type repositoryResult struct {
+	// the absolute path to the repository
+	path string
+
+	// the attribution names removed from the repository
+	offboarded []string
+
+	// the team attributions the users were removed from
+	teams []string
+
+	// the files that were written
+	changed []string
+
+	// the ownership changes made to the owners file
+	report *ownersReport
+
+	// the short hash of the commit made on the "--branch" branch
+	commit string
+
+	// the error that stopped the repository from being offboarded
+	err error
+}
+
+// resolveRepositoryPaths returns the sorted, absolute paths of the repositories
+// to offboard. The path may be a glob of local checkouts and the repos file is
+// a YAML list of paths, the same format used by the insights commands. Relative
+// paths in the repos file are resolved against the file's directory.
+func resolveRepositoryPaths(path string, reposFile string) ([]string, error) {
+	var patterns []string
+	if path != "" {
+		patterns = append(patterns, path)
+	}
+
+	if reposFile != "" {
+		repos, err := utils.HandleRepositoryValues(nil, reposFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading repos file %s: %w", reposFile, err)
+		}
+
+		for repo := range repos {
+			if !filepath.IsAbs(repo) {
+				repo = filepath.Join(filepath.Dir(reposFile), repo)
+			}
+
+			patterns = append(patterns, repo)
+		}
+	}
+
+	unique := make(map[string]struct{})
+	for _, pattern := range patterns {
+		matches, err := expandRepositoryPath(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range matches {
+			abs, err := filepath.Abs(match)
+			if err != nil {
+				return nil, fmt.Errorf("error resolving absolute path: %w", err)
+			}
+
+			unique[abs] = struct{}{}
+		}
+	}
+
+	if len(unique) == 0 {
+		return nil, errors.New("no repositories found to offboard")
+	}
+
+	repoPaths := make([]string, 0, len(unique))
+	for repoPath := range unique {
+		repoPaths = append(repoPaths, repoPath)
+	}
+	sort.Strings(repoPaths)
+
+	return repoPaths, nil
+}
+
+// expandRepositoryPath expands a glob to the directories it matches. Paths
+// without glob characters are returned as is.
+func expandRepositoryPath(pattern string) ([]string, error) {
+	if strings.HasPrefix(pattern, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("could not get user home directory: %w", err)
+		}
+
+		pattern = filepath.Join(home, pattern[2:])
+	}
+
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}, nil
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository glob %s: %w", pattern, err)
+	}
+
+	var dirs []string
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && info.IsDir() {
+			dirs = append(dirs, match)
+		}
+	}
+
+	return dirs, nil
+}
+
+// branchCommit commits the files changed by offboarding a repository to a branch
+type branchCommit struct {
+	repoPath string
+	worktree *git.Worktree
+	branch   plumbing.ReferenceName
+
+	// whether the branch has to be created, or only checked out
+	create   bool
+	checkout bool
+
+	author    *object.Signature
+	committer *object.Signature
+}
+
+// prepareCommit checks that the changes to the files can be committed to the
+// branch before any of them is changed. A git author must be configured, and
+// no other changes may be staged since they would be committed too. The branch
+// is reused if it's checked out or points at HEAD, so offboarding can be run
+// again, and it's created from HEAD if it doesn't exist.
+func prepareCommit(repoPath string, branch string, files []string) (*branchCommit, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening repo: %w", err)
+	}
+
+	c := &branchCommit{
+		repoPath: repoPath,
+		branch:   plumbing.NewBranchReferenceName(branch),
+	}
+
+	c.worktree, err = repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("error opening worktree: %w", err)
+	}
+
+	cfg, err := repo.ConfigScoped(gitconfig.SystemScope)
+	if err != nil {
+		return nil, fmt.Errorf("error reading git config: %w", err)
+	}
+
+	c.author = signature(cfg.Author.Name, cfg.Author.Email)
+	if c.author == nil {
+		c.author = signature(cfg.User.Name, cfg.User.Email)
+	}
+	if c.author == nil {
+		return nil, errors.New("no git author is configured, set one with \"git config user.name\" and \"git config user.email\"")
+	}
+
+	c.committer = signature(cfg.Committer.Name, cfg.Committer.Email)
+	if c.committer == nil {
+		c.committer = c.author
+	}
+
+	status, err := c.worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("error reading worktree status: %w", err)
+	}
+
+	paths := relativePaths(repoPath, files)
+
+	var staged []string
+	for path, fileStatus := range status {
+		if fileStatus.Staging != git.Unmodified && fileStatus.Staging != git.Untracked && !slices.Contains(paths, path) {
+			staged = append(staged, path)
+		}
+	}
+
+	if len(staged) > 0 {
+		sort.Strings(staged)
+		return nil, fmt.Errorf("changes to %s are staged, commit or unstage them first so they aren't committed with the offboard", strings.Join(staged, ", "))
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("error reading HEAD: %w", err)
+	}
+
+	ref, err := repo.Reference(c.branch, true)
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		c.create = true
+	case err != nil:
+		return nil, fmt.Errorf("error reading branch %s: %w", branch, err)
+	case head.Name() == c.branch:
+		// The branch is already checked out, like when offboarding again
+	case ref.Hash() == head.Hash():
+		c.checkout = true
+	default:
+		return nil, fmt.Errorf("branch %s already exists and doesn't point at HEAD, check it out first or choose another --branch", branch)
+	}
+
+	return c, nil
+}
+
+// commit checks out the branch, keeping the working tree, and commits the
+// files to it. Files outside of the repository, like a shared "--config", are
+// skipped. The short hash of the new commit is returned, or an empty string if
+// there was nothing to commit.
+func (c *branchCommit) commit(message string, files []string) (string, error) {
+	paths := relativePaths(c.repoPath, files)
+	if len(paths) == 0 {
+		return "", nil
+	}
+
+	if c.create || c.checkout {
+		err := c.worktree.Checkout(&git.CheckoutOptions{
+			Branch: c.branch,
+			Create: c.create,
+			Keep:   true,
+		})
+		if err != nil {
+			return "", fmt.Errorf("error checking out branch %s: %w", c.branch.Short(), err)
+		}
+	}
+
+	for _, path := range paths {
+		if _, err := c.worktree.Add(path); err != nil {
+			return "", fmt.Errorf("error staging %s: %w", path, err)
+		}
+	}
+
+	now := time.Now()
+	author, committer := *c.author, *c.committer
+	author.When, committer.When = now, now
+
+	hash, err := c.worktree.Commit(message, &git.CommitOptions{
+		Author:    &author,
+		Committer: &committer,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating commit: %w", err)
+	}
+
+	return hash.String()[:7], nil
+}
+
+// signature returns the git signature of the name and email, or nil if either is missing
+func signature(name string, email string) *object.Signature {
+	if name == "" || email == "" {
+		return nil
+	}
+
+	return &object.Signature{
+		Name:  name,
+		Email: email,
+	}
+}
+
+// relativePaths returns the paths of the files in the repository, relative to
+// its root and with forward slashes as git expects
+func relativePaths(repoPath string, files []string) []string {
+	var paths []string
+	for _, file := range files {
+		rel, err := filepath.Rel(repoPath, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+
+	return paths
+}
@@ -0,0 +1,159 @@
+package offboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRepositoryPaths(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"pizza-cli", "pizza", "app"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, name), 0755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pizza.txt"), []byte{}, 0600))
+
+	reposFile := filepath.Join(dir, "repos.yaml")
+	require.NoError(t, os.WriteFile(reposFile, []byte("- app\n- pizza\n"), 0600))
+
+	t.Run("glob", func(t *testing.T) {
+		t.Parallel()
+
+		paths, err := resolveRepositoryPaths(filepath.Join(dir, "pizza*"), "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(dir, "pizza"), filepath.Join(dir, "pizza-cli")}, paths)
+	})
+
+	t.Run("repos file and path", func(t *testing.T) {
+		t.Parallel()
+
+		paths, err := resolveRepositoryPaths(filepath.Join(dir, "pizza"), reposFile)
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(dir, "app"), filepath.Join(dir, "pizza")}, paths)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := resolveRepositoryPaths(filepath.Join(dir, "missing-*"), "")
+		require.Error(t, err)
+	})
+}
+
+// newTestRepo returns a repository with a committed CODEOWNERS file. The git
+// author is configured in the repository if a name is given.
+func newTestRepo(t *testing.T, name string) (string, *git.Repository) {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	if name != "" {
+		cfg, err := repo.Config()
+		require.NoError(t, err)
+		cfg.User.Name = name
+		cfg.User.Email = name + "@opensauced.pizza"
+		require.NoError(t, repo.SetConfig(cfg))
+	}
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "CODEOWNERS"), []byte("a.go @jpmcb\n"), 0600))
+	_, err = worktree.Add("CODEOWNERS")
+	require.NoError(t, err)
+	_, err = worktree.Commit("initial", &git.CommitOptions{
+		Author: &object.Signature{Name: "pizza", Email: "pizza@opensauced.pizza", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	return dir, repo
+}
+
+func TestCommitChanges(t *testing.T) {
+	t.Parallel()
+
+	dir, repo := newTestRepo(t, "pizza")
+	ownersPath := filepath.Join(dir, "CODEOWNERS")
+	files := []string{ownersPath, "/outside/.sauced.yaml"}
+
+	// Offboarding twice commits to the same branch
+	for _, owner := range []string{"@zeucapua", "@bdougie"} {
+		commit, err := prepareCommit(dir, "offboard-jpmcb", files)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(ownersPath, []byte("a.go "+owner+"\n"), 0600))
+
+		hash, err := commit.commit("Offboard jpmcb", files)
+		require.NoError(t, err)
+		assert.Len(t, hash, 7)
+	}
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("offboard-jpmcb"), head.Name())
+
+	headCommit, err := repo.CommitObject(head.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, "pizza@opensauced.pizza", headCommit.Author.Email)
+
+	parent, err := headCommit.Parent(0)
+	require.NoError(t, err)
+	assert.Equal(t, "Offboard jpmcb", parent.Message)
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	status, err := worktree.Status()
+	require.NoError(t, err)
+	assert.True(t, status.IsClean())
+}
+
+func TestPrepareCommitRefusals(t *testing.T) {
+	t.Parallel()
+
+	dir, repo := newTestRepo(t, "pizza")
+	files := []string{filepath.Join(dir, "CODEOWNERS")}
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	// Staged changes to other files would be committed with the offboard
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "wip.go"), []byte("package wip\n"), 0600))
+	_, err = worktree.Add("wip.go")
+	require.NoError(t, err)
+
+	_, err = prepareCommit(dir, "offboard-jpmcb", files)
+	require.ErrorContains(t, err, "changes to wip.go are staged")
+
+	// Branches pointing elsewhere would lose their commits
+	head, err := repo.Head()
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("offboard-jpmcb"), head.Hash())))
+
+	_, err = worktree.Commit("wip", &git.CommitOptions{})
+	require.NoError(t, err)
+
+	_, err = prepareCommit(dir, "offboard-jpmcb", files)
+	require.ErrorContains(t, err, "branch offboard-jpmcb already exists")
+}
+
+func TestPrepareCommitWithoutAuthor(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	dir, _ := newTestRepo(t, "")
+	ownersPath := filepath.Join(dir, "CODEOWNERS")
+
+	_, err := prepareCommit(dir, "offboard-jpmcb", []string{ownersPath})
+	require.ErrorContains(t, err, "no git author is configured")
+}
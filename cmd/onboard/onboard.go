@@ -0,0 +1,315 @@
+package onboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jpmcb/gopherlogs"
+	"github.com/jpmcb/gopherlogs/pkg/colors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-sauced/pizza-cli/v2/cmd/offboard"
+	"github.com/open-sauced/pizza-cli/v2/pkg/backup"
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+	"github.com/open-sauced/pizza-cli/v2/pkg/logging"
+	"github.com/open-sauced/pizza-cli/v2/pkg/owners"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+type Options struct {
+	// the GitHub login of the user being onboarded
+	login string
+
+	// the emails the user commits with
+	emails []string
+
+	// the teams, as "@org/team", the user joins
+	teams []string
+
+	// the CODEOWNERS path patterns the user becomes a co-owner of
+	paths []string
+
+	// config file path
+	configPath string
+
+	// repository path
+	path string
+
+	// whether to only print the changes instead of writing them
+	dryRun bool
+
+	// whether to restore the files changed by the previous onboard
+	undo bool
+
+	// from global config
+	ttyDisabled bool
+	loglevel    int
+	logger      gopherlogs.Logger
+}
+
+const onboardLongDesc string = `CAUTION: Experimental Command. Adds a user to the ".sauced.yaml" config and "CODEOWNERS" files.
+
+The user's GitHub login is attributed the given emails. With "--team", the emails
+are also added to the team attributions. With "--paths", the user is added as a
+co-owner of every CODEOWNERS rule matching the given patterns. For a pattern that
+matches none, a new rule is added with the owners of the rule that covered it, so
+no one loses ownership. "**" matches across directories. In an OWNERS file
+generated with "pizza generate codeowners --owners-style-file", the user is added
+to the files matching the patterns, other OWNERS files are left unchanged.
+
+The previous versions of both files are backed up in "~/.pizza-cli/backups"
+and can be restored with "--undo".`
+
+const onboardExamples string = `  # Onboard a user to the repository in the current directory
+  $ pizza onboard jpmcb --email john@opensauced.pizza --path .
+
+  # Onboard a user to a team and make them a co-owner of the source code
+  $ pizza onboard jpmcb --email john@opensauced.pizza --team @open-sauced/engineering --paths 'src/**' --path .
+
+  # Preview the changes without writing them
+  $ pizza onboard jpmcb --email john@opensauced.pizza --path . --dry-run
+
+  # Restore the files changed by the previous onboard
+  $ pizza onboard --undo --path .`
+
+func NewOnboardCommand() *cobra.Command {
+	opts := &Options{}
+	cmd := &cobra.Command{
+		Use:     "onboard <username> [flags]",
+		Short:   "CAUTION: Experimental Command. Adds a user to the \".sauced.yaml\" config and \"CODEOWNERS\" files.",
+		Long:    onboardLongDesc,
+		Example: onboardExamples,
+		Args: func(cmd *cobra.Command, args []string) error {
+			undo, _ := cmd.Flags().GetBool("undo")
+			if undo {
+				if len(args) != 0 {
+					return errors.New("no arguments may be given with --undo")
+				}
+
+				return nil
+			}
+
+			if len(args) != 1 {
+				return errors.New("you must provide exactly one argument: the onboarding user's GitHub login")
+			}
+
+			opts.login = strings.TrimPrefix(args[0], "@")
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.ttyDisabled, _ = cmd.Flags().GetBool("tty-disable")
+			opts.configPath, _ = cmd.Flags().GetString("config")
+
+			opts.path, _ = cmd.Flags().GetString("path")
+			opts.emails, _ = cmd.Flags().GetStringSlice("email")
+			opts.teams, _ = cmd.Flags().GetStringSlice("team")
+			opts.paths, _ = cmd.Flags().GetStringSlice("paths")
+			opts.dryRun, _ = cmd.Flags().GetBool("dry-run")
+			opts.undo, _ = cmd.Flags().GetBool("undo")
+
+			loglevelS, _ := cmd.Flags().GetString("log-level")
+
+			switch loglevelS {
+			case "error":
+				opts.loglevel = logging.LogError
+			case "warn":
+				opts.loglevel = logging.LogWarn
+			case "info":
+				opts.loglevel = logging.LogInfo
+			case "debug":
+				opts.loglevel = logging.LogDebug
+			}
+
+			if !opts.undo && len(opts.emails) == 0 {
+				return errors.New("you must provide at least one --email for the onboarding user")
+			}
+
+			return run(opts)
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "the path to the repository (required)")
+	if err := cmd.MarkFlagRequired("path"); err != nil {
+		fmt.Printf("error MarkFlagRequired: %v", err)
+	}
+
+	cmd.Flags().StringSlice("email", []string{}, "An email the user commits with. May be given multiple times")
+	cmd.Flags().StringSlice("team", []string{}, "A team, like @org/team, to add the user's emails to. May be given multiple times")
+	cmd.Flags().StringSlice("paths", []string{}, "CODEOWNERS path patterns to add the user as a co-owner of, like 'src/**'")
+	cmd.Flags().Bool("dry-run", false, "Print a diff of the changes instead of writing them")
+	cmd.Flags().Bool("undo", false, "Restore the files changed by the previous onboard of the repository at the given path")
+	return cmd
+}
+
+func run(opts *Options) error {
+	var err error
+	opts.logger, err = gopherlogs.NewLogger(
+		gopherlogs.WithLogVerbosity(opts.loglevel),
+		gopherlogs.WithTty(!opts.ttyDisabled),
+	)
+	if err != nil {
+		return fmt.Errorf("could not build logger: %w", err)
+	}
+
+	opts.path, err = filepath.Abs(opts.path)
+	if err != nil {
+		return fmt.Errorf("error resolving absolute path: %w", err)
+	}
+
+	if opts.undo {
+		return undo(opts)
+	}
+
+	for _, email := range opts.emails {
+		if !strings.Contains(email, "@") || strings.HasPrefix(email, "@") {
+			return fmt.Errorf("invalid email: %s", email)
+		}
+	}
+
+	configPath := opts.configPath
+	if len(configPath) == 0 {
+		configPath = filepath.Join(opts.path, ".sauced.yaml")
+	}
+
+	// Unlike offboarding, a missing config is created rather than falling back to "~/.sauced.yaml"
+	originalConfig, err := os.ReadFile(configPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error reading config: %v", err)
+	}
+
+	spec := &config.Spec{}
+	if err := yaml.Unmarshal(originalConfig, spec); err != nil {
+		return fmt.Errorf("error loading config: %v", err)
+	}
+
+	// The config is only rewritten if the user is actually added to it
+	originalYAML, err := utils.OutputYAML(spec)
+	if err != nil {
+		return fmt.Errorf("error generating config file: %v", err)
+	}
+
+	teams := addAttributions(spec, opts.login, opts.emails, opts.teams)
+
+	newConfig, err := offboard.GenerateConfigFile(string(originalConfig), spec)
+	if err != nil {
+		return fmt.Errorf("error generating config file: %v", err)
+	}
+
+	if updatedYAML, _ := utils.OutputYAML(spec); updatedYAML == originalYAML {
+		newConfig = string(originalConfig)
+	}
+
+	ownersPath, originalOwners, err := offboard.FindOwnersFile(opts.path)
+	if err != nil {
+		return fmt.Errorf("error generating owners file: %v", err)
+	}
+
+	newOwners := originalOwners
+	var coOwned, added []string
+	if len(opts.paths) > 0 {
+		format := offboard.DetectOwnersFormat(ownersPath, originalOwners)
+
+		switch {
+		case ownersPath == "":
+			ownersPath = filepath.Join(opts.path, "CODEOWNERS")
+			newOwners, coOwned, added = addOwner(originalOwners, "@"+opts.login, opts.paths)
+		case format == offboard.UnsupportedOwnersFormat:
+			opts.logger.V(logging.LogWarn).Style(0, colors.FgYellow).Infof("Leaving %s unchanged: only OWNERS files generated with \"--owners-style-file\" can be onboarded to\n", ownersPath)
+			ownersPath = ""
+		case format == offboard.OwnersStyleFormat:
+			var unmatched []string
+			owner := owners.Owner{Name: opts.login, Email: opts.emails[0]}
+			newOwners, coOwned, unmatched, err = addOwnersStyleOwner(originalOwners, owner, opts.emails, opts.paths)
+			if err != nil {
+				return fmt.Errorf("error generating owners file: %v", err)
+			}
+
+			for _, glob := range unmatched {
+				opts.logger.V(logging.LogWarn).Style(0, colors.FgYellow).Infof("No file in %s matches: %s\n", ownersPath, glob)
+			}
+		default:
+			newOwners, coOwned, added = addOwner(originalOwners, "@"+opts.login, opts.paths)
+		}
+	}
+
+	if opts.dryRun {
+		fmt.Print(utils.UnifiedDiff(configPath, configPath, string(originalConfig), newConfig))
+		if ownersPath != "" {
+			fmt.Print(utils.UnifiedDiff(ownersPath, ownersPath, originalOwners, newOwners))
+		}
+
+		return nil
+	}
+
+	configChanged := newConfig != string(originalConfig)
+	ownersChanged := ownersPath != "" && newOwners != originalOwners
+
+	if !configChanged && !ownersChanged {
+		opts.logger.V(logging.LogInfo).Style(0, colors.Reset).Infof("Nothing to change, %s is already onboarded\n", opts.login)
+		return nil
+	}
+
+	var backupPaths []string
+	if configChanged {
+		backupPaths = append(backupPaths, configPath)
+	}
+	if ownersChanged {
+		backupPaths = append(backupPaths, ownersPath)
+	}
+
+	manifest, err := backup.Create("onboard", opts.path, backupPaths)
+	if err != nil {
+		return fmt.Errorf("error backing up files: %v", err)
+	}
+	opts.logger.V(logging.LogDebug).Style(0, colors.FgBlue).Infof("Backed up files to: %s\n", manifest.Dir())
+
+	if configChanged {
+		if err := os.WriteFile(configPath, []byte(newConfig), 0600); err != nil {
+			return fmt.Errorf("error generating config file: %v", err)
+		}
+	}
+
+	if ownersChanged {
+		if err := os.WriteFile(ownersPath, []byte(newOwners), 0600); err != nil {
+			return fmt.Errorf("error generating owners file: %v", err)
+		}
+	}
+
+	for _, team := range teams {
+		opts.logger.V(logging.LogInfo).Style(0, colors.Reset).Infof("Added to team: %s\n", team)
+	}
+
+	for _, pattern := range coOwned {
+		opts.logger.V(logging.LogInfo).Style(0, colors.Reset).Infof("Co-owner of: %s\n", pattern)
+	}
+
+	for _, pattern := range added {
+		opts.logger.V(logging.LogInfo).Style(0, colors.Reset).Infof("Added rule: %s\n", pattern)
+	}
+
+	opts.logger.V(logging.LogInfo).Style(0, colors.FgGreen).Infof("Onboarded: %s\nUndo with: pizza onboard --undo --path %s\n", opts.login, opts.path)
+	return nil
+}
+
+// undo restores the files changed by the previous onboard of the repository
+func undo(opts *Options) error {
+	manifest, err := backup.Latest("onboard", opts.path)
+	if err != nil {
+		return fmt.Errorf("could not undo onboard for %s: %w", opts.path, err)
+	}
+
+	if err := manifest.Restore(); err != nil {
+		return fmt.Errorf("could not restore backup %s: %w", manifest.ID, err)
+	}
+
+	for _, file := range manifest.Files {
+		opts.logger.V(logging.LogInfo).Style(0, colors.FgGreen).Infof("Restored: %s\n", file.Path)
+	}
+
+	return nil
+}
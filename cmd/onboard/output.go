@@ -0,0 +1,206 @@
+package onboard
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	ownersfile "github.com/open-sauced/pizza-cli/v2/pkg/codeowners"
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+	"github.com/open-sauced/pizza-cli/v2/pkg/owners"
+)
+
+// escapedChar matches a backslash escaped character in a CODEOWNERS pattern
+var escapedChar = regexp.MustCompile(`\\(.)`)
+
+// addAttributions attributes the emails to the login and adds them to each of
+// the teams. The names of the teams the emails were added to are returned.
+func addAttributions(spec *config.Spec, login string, emails []string, teams []string) []string {
+	if spec.Attributions == nil {
+		spec.Attributions = make(map[string][]string)
+	}
+
+	// Reuse an existing attribution, even if its case differs
+	if name, ok := spec.ResolveIdentity(login); ok {
+		login = name
+	}
+
+	addEmails(spec, login, emails)
+
+	var joined []string
+	for _, team := range teams {
+		team = strings.TrimPrefix(team, "@")
+		if addEmails(spec, team, emails) {
+			joined = append(joined, team)
+		}
+	}
+
+	return joined
+}
+
+// addEmails adds any of the emails not yet attributed to the name, returning
+// whether any were added
+func addEmails(spec *config.Spec, name string, emails []string) bool {
+	added := false
+	for _, email := range emails {
+		exists := slices.ContainsFunc(spec.Attributions[name], func(e string) bool {
+			return config.NormalizeEmail(e) == config.NormalizeEmail(email)
+		})
+
+		if !exists {
+			spec.Attributions[name] = append(spec.Attributions[name], email)
+			added = true
+		}
+	}
+
+	return added
+}
+
+// addOwner returns the contents of the owners file with the owner added to
+// every rule matching one of the globs. A new rule is added for each glob
+// that matches no rule. As the last matching rule of a CODEOWNERS file wins,
+// it's inserted right after the last rule already covering the glob, with that
+// rule's owners, so the owner becomes a co-owner without overriding any rule.
+// Globs no rule covers are inserted before every rule instead. The patterns of
+// the rules the owner was added to and the newly added patterns are returned.
+func addOwner(owners string, owner string, globs []string) (string, []string, []string) {
+	file := ownersfile.ParseString(owners)
+
+	// The last rule inserted at each place, to keep new rules in order
+	inserted := make(map[*ownersfile.Line]*ownersfile.Line)
+
+	var coOwned, added []string
+	for _, glob := range globs {
+		matched := false
+		var covering *ownersfile.Line
+
+		for _, rule := range file.Rules() {
+			if coversPattern(rule.Pattern, glob) {
+				covering = rule
+			}
+
+			if !matchPattern(glob, rule.Pattern) {
+				continue
+			}
+
+			matched = true
+			if rule.AddOwner(owner) {
+				coOwned = append(coOwned, rule.Pattern)
+			}
+		}
+
+		if matched {
+			continue
+		}
+
+		if covering != nil && covering.HasOwner(owner) {
+			// The owner already owns every path of the glob
+			continue
+		}
+
+		pattern := ownersfile.EscapePattern(glob)
+		rule := ownersfile.NewRule(pattern, []string{owner})
+		if covering != nil {
+			rule.Owners = append(slices.Clone(covering.Owners), owner)
+		}
+
+		rules := file.Rules()
+		switch {
+		case inserted[covering] != nil:
+			file.InsertAfter(inserted[covering], rule)
+		case covering != nil:
+			file.InsertAfter(covering, rule)
+		case len(rules) > 0:
+			file.InsertBefore(rules[0], rule)
+		default:
+			file.Append(rule)
+		}
+		inserted[covering] = rule
+
+		added = append(added, pattern)
+	}
+
+	return file.String(), coOwned, added
+}
+
+// addOwnersStyleOwner returns the contents of the OWNERS style file with the
+// owner added to every file matching one of the globs, unless the file is
+// already owned through one of the emails. As the file lists files rather than
+// patterns, no entries are added: the globs that match no file are returned
+// along with the files the owner was added to.
+func addOwnersStyleOwner(contents string, owner owners.Owner, emails []string, globs []string) (string, []string, []string, error) {
+	file, err := owners.ParseGenerated(contents)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	var coOwned, unmatched []string
+	for _, glob := range globs {
+		matched := false
+
+		for _, entry := range file.Entries {
+			if !matchPattern(glob, entry.Path) {
+				continue
+			}
+
+			matched = true
+			owned := slices.ContainsFunc(emails, func(email string) bool {
+				return entry.HasOwner(owners.Owner{Email: email})
+			})
+
+			if !owned && entry.AddOwner(owner) {
+				coOwned = append(coOwned, entry.Path)
+			}
+		}
+
+		if !matched {
+			unmatched = append(unmatched, glob)
+		}
+	}
+
+	return file.String(), coOwned, unmatched, nil
+}
+
+// matchPattern checks if a CODEOWNERS rule pattern falls under the glob.
+// "**" matches any number of directories, "*" and "?" match within a single
+// path segment, and leading slashes and backslash escapes are ignored.
+func matchPattern(glob string, pattern string) bool {
+	normalize := func(s string) string {
+		s = escapedChar.ReplaceAllString(s, "$1")
+		return strings.TrimPrefix(s, "/")
+	}
+
+	glob, pattern = normalize(glob), normalize(pattern)
+	if glob == pattern {
+		return true
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expr.WriteString("[^/]*")
+		case glob[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(glob[i])))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String()).MatchString(pattern)
+}
+
+// coversPattern checks if a CODEOWNERS rule pattern matches every path the
+// glob matches. The glob is matched against the rule as a path in which "*"
+// and "?" are literal characters and "**" stands for nested directories.
+func coversPattern(pattern string, glob string) bool {
+	glob = strings.TrimPrefix(escapedChar.ReplaceAllString(glob, "$1"), "/")
+	glob = strings.ReplaceAll(glob, "**", "*/*")
+
+	return ownersfile.Matcher(pattern)(glob)
+}
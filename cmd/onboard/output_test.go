@@ -0,0 +1,147 @@
+package onboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+	"github.com/open-sauced/pizza-cli/v2/pkg/owners"
+)
+
+func TestAddAttributions(t *testing.T) {
+	t.Parallel()
+
+	spec := &config.Spec{
+		Attributions: map[string][]string{
+			"JPMCB":                   {"john@opensauced.pizza"},
+			"open-sauced/engineering": {"coding@zeu.dev"},
+		},
+	}
+
+	teams := addAttributions(spec, "jpmcb", []string{"John@OpenSauced.pizza", "hello@johncodes.com"}, []string{"@open-sauced/engineering", "@open-sauced/docs"})
+
+	assert.Equal(t, []string{"open-sauced/engineering", "open-sauced/docs"}, teams)
+	assert.Equal(t, map[string][]string{
+		"JPMCB":                   {"john@opensauced.pizza", "hello@johncodes.com"},
+		"open-sauced/engineering": {"coding@zeu.dev", "John@OpenSauced.pizza", "hello@johncodes.com"},
+		"open-sauced/docs":        {"John@OpenSauced.pizza", "hello@johncodes.com"},
+	}, spec.Attributions)
+}
+
+func TestAddOwner(t *testing.T) {
+	t.Parallel()
+
+	owners := `# comment
+src/a.go @zeucapua
+src/nested/\(b\).go @jpmcb # already an owner
+docs/readme.md @zeucapua
+`
+
+	result, coOwned, added := addOwner(owners, "@jpmcb", []string{"src/**", "api/**"})
+
+	// No rule covers "api/**", so its rule comes first and overrides nothing
+	expected := `# comment
+api/** @jpmcb
+src/a.go @zeucapua @jpmcb
+src/nested/\(b\).go @jpmcb # already an owner
+docs/readme.md @zeucapua
+`
+
+	assert.Equal(t, expected, result)
+	assert.Equal(t, []string{"src/a.go"}, coOwned)
+	assert.Equal(t, []string{"api/**"}, added)
+}
+
+func TestAddOwnerCoveredByBroaderRule(t *testing.T) {
+	t.Parallel()
+
+	owners := `* @open-sauced/engineering
+*.md @open-sauced/docs
+docs/ @zeucapua
+`
+
+	result, coOwned, added := addOwner(owners, "@jpmcb", []string{"src/**", "my notes/#1", "docs/guides/**"})
+
+	// The new rules keep the owners of the rules they override, and come before
+	// the more specific rules that still win
+	expected := `* @open-sauced/engineering
+src/** @open-sauced/engineering @jpmcb
+my\ notes/\#1 @open-sauced/engineering @jpmcb
+*.md @open-sauced/docs
+docs/ @zeucapua
+docs/guides/** @zeucapua @jpmcb
+`
+
+	assert.Equal(t, expected, result)
+	assert.Empty(t, coOwned)
+	assert.Equal(t, []string{"src/**", `my\ notes/\#1`, "docs/guides/**"}, added)
+
+	// Owners of the broader rule already own the paths
+	result, _, added = addOwner(owners, "@zeucapua", []string{"docs/guides/**"})
+	assert.Equal(t, owners, result)
+	assert.Empty(t, added)
+}
+
+func TestCoversPattern(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, coversPattern("*", "src/**"))
+	assert.True(t, coversPattern("src/", "src/a/**"))
+	assert.True(t, coversPattern("/src/**", "src/a/*.go"))
+	assert.True(t, coversPattern("*.go", "src/*.go"))
+	assert.True(t, coversPattern(`my\ docs/`, "my docs/**"))
+	assert.False(t, coversPattern("*.go", "src/**"))
+	assert.False(t, coversPattern("docs/*", "docs/**"))
+	assert.False(t, coversPattern("/src/", "lib/src/**"))
+	assert.False(t, coversPattern("src/a.go", "src/**"))
+}
+
+func TestMatchPattern(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, matchPattern("src/**", "src/a/b/c.go"))
+	assert.True(t, matchPattern("src/*.go", "/src/a.go"))
+	assert.False(t, matchPattern("src/*.go", "src/a/b.go"))
+	assert.True(t, matchPattern("*.md", `\(home\).md`))
+	assert.True(t, matchPattern("docs/", "docs/"))
+	assert.False(t, matchPattern("docs/**", "src/docs/a.md"))
+}
+
+func TestAddOwnersStyleOwner(t *testing.T) {
+	t.Parallel()
+
+	contents := `README.md
+  - Zeu Capua
+    - coding@zeu.dev
+src/main.go
+  - Zeu Capua
+    - coding@zeu.dev
+src/util.go
+  - John McBride
+    - hello@johncodes.com
+`
+
+	owner := owners.Owner{Name: "jpmcb", Email: "john@opensauced.pizza"}
+	result, coOwned, unmatched, err := addOwnersStyleOwner(contents, owner, []string{"john@opensauced.pizza", "hello@johncodes.com"}, []string{"src/**", "api/**"})
+	require.NoError(t, err)
+
+	// Files are never added and the owner's other emails count as owning a file
+	expected := `README.md
+  - Zeu Capua
+    - coding@zeu.dev
+src/main.go
+  - Zeu Capua
+    - coding@zeu.dev
+  - jpmcb
+    - john@opensauced.pizza
+src/util.go
+  - John McBride
+    - hello@johncodes.com
+`
+
+	assert.Equal(t, expected, result)
+	assert.Equal(t, []string{"src/main.go"}, coOwned)
+	assert.Equal(t, []string{"api/**"}, unmatched)
+}
@@ -2,16 +2,27 @@
 package root
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
+	"github.com/open-sauced/pizza-cli/v2/api"
+	apiauth "github.com/open-sauced/pizza-cli/v2/api/auth"
 	"github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	cliconfig "github.com/open-sauced/pizza-cli/v2/cmd/config"
+	"github.com/open-sauced/pizza-cli/v2/cmd/dev"
 	"github.com/open-sauced/pizza-cli/v2/cmd/docs"
 	"github.com/open-sauced/pizza-cli/v2/cmd/generate"
 	"github.com/open-sauced/pizza-cli/v2/cmd/insights"
+	"github.com/open-sauced/pizza-cli/v2/cmd/lists"
 	"github.com/open-sauced/pizza-cli/v2/cmd/offboard"
+	"github.com/open-sauced/pizza-cli/v2/cmd/onboard"
 	"github.com/open-sauced/pizza-cli/v2/cmd/version"
+	"github.com/open-sauced/pizza-cli/v2/cmd/workspace"
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
 	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
 )
 
@@ -22,6 +33,14 @@ func NewRootCommand() (*cobra.Command, error) {
 		Short: "OpenSauced CLI",
 		Long:  "A command line utility for insights, metrics, and generating CODEOWNERS documentation for your open source projects",
 		RunE:  run,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			// Commands validating their arguments already have their defaults
+			if cmd.Args != nil {
+				return nil
+			}
+
+			return applyFlagDefaults(cmd)
+		},
 		Args: func(cmd *cobra.Command, _ []string) error {
 			betaFlag := cmd.Flags().Lookup(constants.FlagNameBeta)
 			if betaFlag.Changed {
@@ -40,18 +59,34 @@ func NewRootCommand() (*cobra.Command, error) {
 	cmd.PersistentFlags().StringP("config", "c", "", "The codeowners config")
 	cmd.PersistentFlags().StringP("log-level", "l", "info", "The logging level. Options: error, warn, info, debug")
 	cmd.PersistentFlags().Bool("tty-disable", false, "Disable log stylization. Suitable for CI/CD and automation")
+	cmd.PersistentFlags().Duration(constants.FlagNameAPITimeout, api.DefaultTimeout, "How long an API call may take, including retries of transient errors. 0 means no timeout")
+	cmd.PersistentFlags().Int(constants.FlagNameConcurrency, api.DefaultConcurrency, "How many API requests may be in flight at once. Requests also slow down when the API's rate limit is running out")
+	cmd.PersistentFlags().Bool(constants.FlagNameCache, false, "Cache API responses in \"~/.pizza-cli/cache\". Enable it for every command with \"pizza config set cache true\"")
+	cmd.PersistentFlags().Bool(constants.FlagNameNoCache, false, "Don't read or write cached API responses, even when caching is enabled")
+	cmd.PersistentFlags().Bool(constants.FlagNameRefresh, false, "Check every cached API response with the API instead of using it while it's fresh")
+	cmd.PersistentFlags().String(constants.FlagNameCredentialStore, apiauth.CredentialStoreFile, fmt.Sprintf("Where the login session is stored. One of: (%s)", strings.Join(apiauth.CredentialStores, ", ")))
+	cmd.PersistentFlags().String(constants.FlagNameCredentialKeyFile, "", fmt.Sprintf("Key file for the %q credential store, instead of the %s environment variable", apiauth.CredentialStoreEncryptedFile, apiauth.PassphraseEnvVar))
 
 	cmd.AddCommand(auth.NewLoginCommand())
+	cmd.AddCommand(auth.NewLogoutCommand())
+	cmd.AddCommand(auth.NewAuthCommand())
+	cmd.AddCommand(cliconfig.NewConfigCommand())
+	cmd.AddCommand(dev.NewDevCommand())
 	cmd.AddCommand(generate.NewGenerateCommand())
 	cmd.AddCommand(insights.NewInsightsCommand())
+	cmd.AddCommand(lists.NewListsCommand())
 	cmd.AddCommand(version.NewVersionCommand())
 	cmd.AddCommand(offboard.NewConfigCommand())
+	cmd.AddCommand(onboard.NewOnboardCommand())
+	cmd.AddCommand(workspace.NewWorkspaceCommand())
 
 	// The docs command is hidden as it's only used by the pizza-cli maintainers
 	docsCmd := docs.NewDocsCommand()
 	docsCmd.Hidden = true
 	cmd.AddCommand(docsCmd)
 
+	applyFlagDefaultsBeforeArgs(cmd)
+
 	err := cmd.PersistentFlags().MarkHidden(constants.FlagNameEndpoint)
 	if err != nil {
 		return nil, fmt.Errorf("error marking %s as hidden: %w", constants.FlagNameEndpoint, err)
@@ -68,3 +103,56 @@ func NewRootCommand() (*cobra.Command, error) {
 func run(cmd *cobra.Command, _ []string) error {
 	return cmd.Help()
 }
+
+// applyFlagDefaultsBeforeArgs applies the flag defaults of the command and its
+// subcommands before their arguments are validated, as cobra validates them
+// before running any PreRun hook. Commands without an Args validator get
+// their defaults in the root's PersistentPreRunE instead.
+func applyFlagDefaultsBeforeArgs(cmd *cobra.Command) {
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(cmd *cobra.Command, argv []string) error {
+			if err := applyFlagDefaults(cmd); err != nil {
+				return err
+			}
+
+			return args(cmd, argv)
+		}
+	}
+
+	for _, child := range cmd.Commands() {
+		applyFlagDefaultsBeforeArgs(child)
+	}
+}
+
+// applyFlagDefaults fills in any flags not explicitly given on the command line
+// from "PIZZA_*" environment variables and the global CLI config file.
+// The precedence is: flag, then env, then file.
+//
+// Defaulted flags aren't marked as changed, so commands can still tell the
+// flags the user gave apart.
+func applyFlagDefaults(cmd *cobra.Command) error {
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+
+	commandPath := cliconfig.CommandPath(cmd)
+
+	var errs []error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed || f.Name == "help" {
+			return
+		}
+
+		value, ok := cliConfig.LookupFlagDefault(commandPath, f.Name)
+		if !ok {
+			return
+		}
+
+		if err := f.Value.Set(value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid default for flag --%s: %w", f.Name, err))
+		}
+	})
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+package root
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFlagDefaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("PIZZA_FILE", "repos.yaml")
+	t.Setenv("PIZZA_USERS", "jpmcb,zeucapua")
+	t.Setenv("PIZZA_PUBLIC", "true")
+	t.Setenv("PIZZA_YES", "true")
+
+	var file string
+	var users []string
+	var public, yes, publicChanged bool
+
+	// execute runs the command of a new root command with the arguments. Only
+	// the "test" command validates its arguments.
+	execute := func(args ...string) error {
+		file, users, public, yes, publicChanged = "", nil, false, false, false
+
+		newTestCommand := func(name string) *cobra.Command {
+			cmd := &cobra.Command{
+				Use: name,
+				RunE: func(cmd *cobra.Command, _ []string) error {
+					publicChanged = cmd.Flags().Changed("public")
+					return nil
+				},
+			}
+			cmd.Flags().StringVar(&file, "file", "", "")
+			cmd.Flags().StringSliceVar(&users, "users", nil, "")
+			cmd.Flags().BoolVar(&public, "public", false, "")
+			cmd.Flags().BoolVar(&yes, "yes", false, "")
+			return cmd
+		}
+
+		withArgs := newTestCommand("test")
+		withArgs.Args = func(_ *cobra.Command, args []string) error {
+			if file == "" && len(args) == 0 {
+				return errors.New("must specify arguments or --file")
+			}
+			return nil
+		}
+
+		root, err := NewRootCommand()
+		require.NoError(t, err)
+		root.AddCommand(withArgs, newTestCommand("other"))
+		applyFlagDefaultsBeforeArgs(withArgs)
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(args)
+
+		return root.Execute()
+	}
+
+	for _, name := range []string{"test", "other"} {
+		// The defaults are applied before the arguments are validated
+		require.NoError(t, execute(name))
+
+		assert.Equal(t, "repos.yaml", file)
+		assert.Equal(t, []string{"jpmcb", "zeucapua"}, users, "defaults are applied once")
+		assert.True(t, public)
+		assert.False(t, publicChanged, "defaulted flags aren't changed")
+		assert.False(t, yes, "confirmations have no defaults")
+	}
+
+	// Explicit flags take precedence
+	require.NoError(t, execute("test", "--users", "bdougie", "--public=false"))
+	assert.Equal(t, []string{"bdougie"}, users)
+	assert.False(t, public)
+	assert.True(t, publicChanged)
+}
@@ -0,0 +1,73 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type createOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Name and Description are those of the new workspace
+	Name        string
+	Description string
+
+	// Repos are the GitHub URLs or full names of the workspace's repositories
+	Repos []string
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the created workspace is written to
+	out io.Writer
+}
+
+// NewCreateCommand returns a new cobra command for 'pizza workspace create'
+func NewCreateCommand() *cobra.Command {
+	opts := &createOptions{}
+	cmd := &cobra.Command{
+		Use:   "create name [flags]",
+		Short: "Create a workspace",
+		Example: `  # Create a workspace with two repositories
+  $ pizza workspace create "Pizza CLI" --repo open-sauced/pizza-cli --repo https://github.com/open-sauced/app`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Name = args[0]
+			opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Description, "description", "d", "", "Description of the workspace")
+	cmd.Flags().StringSliceVar(&opts.Repos, "repo", nil, "GitHub URL or owner/name of a repository to add to the workspace. Repeat it to add several")
+	return cmd
+}
+
+func (opts *createOptions) run(ctx context.Context) error {
+	repos, err := RepoFullNames(opts.Repos)
+	if err != nil {
+		return err
+	}
+
+	workspace, _, err := opts.APIClient.WorkspacesService.CreateWorkspaceForUser(ctx, opts.Name, opts.Description, repos)
+	if err != nil {
+		return fmt.Errorf("could not create workspace %s: %w", opts.Name, err)
+	}
+
+	return writeWorkspace(ctx, opts.APIClient, workspace.ID, opts.Output, opts.out)
+}
@@ -0,0 +1,76 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+type deleteOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// Yes skips asking for confirmation
+	Yes bool
+
+	// in is where the confirmation is read from, out where it's asked on
+	in  io.Reader
+	out io.Writer
+}
+
+// NewDeleteCommand returns a new cobra command for 'pizza workspace delete'
+func NewDeleteCommand() *cobra.Command {
+	opts := &deleteOptions{}
+	cmd := &cobra.Command{
+		Use:   "delete workspace [flags]",
+		Short: "Delete a workspace, along with its contributor insights",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Workspace = args[0]
+			opts.in = cmd.InOrStdin()
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Yes, constants.FlagNameYes, "y", false, "Delete the workspace without asking for confirmation")
+	return cmd
+}
+
+func (opts *deleteOptions) run(ctx context.Context) error {
+	workspace, err := FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	if !opts.Yes {
+		ok, err := utils.Confirm(opts.in, opts.out, fmt.Sprintf("Delete workspace %s (%s) and its contributor insights?", workspace.Name, workspace.ID))
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	_, err = opts.APIClient.WorkspacesService.DeleteWorkspace(ctx, workspace.ID)
+	if err != nil {
+		return fmt.Errorf("could not delete workspace %s: %w", workspace.Name, err)
+	}
+
+	fmt.Fprintf(opts.out, "Deleted workspace %s (%s)\n", workspace.Name, workspace.ID)
+	return nil
+}
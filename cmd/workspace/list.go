@@ -0,0 +1,72 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type listOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the workspaces are written to
+	out io.Writer
+}
+
+// NewListCommand returns a new cobra command for 'pizza workspace list'
+func NewListCommand() *cobra.Command {
+	opts := &listOptions{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List your workspaces",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+func (opts *listOptions) run(ctx context.Context) error {
+	data, err := services.All(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]workspaces.DbWorkspace, services.MetaData, error) {
+		resp, _, err := opts.APIClient.WorkspacesService.GetWorkspaces(ctx, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return resp.Data, resp.Meta, nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not list workspaces: %w", err)
+	}
+
+	output, err := newWorkspaceSummaries(data).BuildOutput(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(opts.out, output)
+	return nil
+}
@@ -0,0 +1,97 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type addMemberOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// Logins are the GitHub logins of the new members
+	Logins []string
+
+	// Role is the role of the new members
+	Role string
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the updated workspace is written to
+	out io.Writer
+}
+
+// NewAddMemberCommand returns a new cobra command for 'pizza workspace add-member'
+func NewAddMemberCommand() *cobra.Command {
+	opts := &addMemberOptions{}
+	cmd := &cobra.Command{
+		Use:   "add-member workspace login... [flags]",
+		Short: "Add GitHub users as members of a workspace",
+		Example: `  # Let two users edit a workspace
+  $ pizza workspace add-member "Pizza CLI" jpmcb nickytonline --role editor`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Workspace = args[0]
+			opts.Logins = args[1:]
+			opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Role, "role", workspaces.RoleViewer, "Role of the new members. One of: (owner, editor, viewer)")
+	return cmd
+}
+
+func (opts *addMemberOptions) run(ctx context.Context) error {
+	switch opts.Role {
+	case workspaces.RoleOwner, workspaces.RoleEditor, workspaces.RoleViewer:
+	default:
+		return fmt.Errorf("invalid role: %s, accepts (owner, editor, viewer)", opts.Role)
+	}
+
+	workspace, err := FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	userIDs := make([]int, 0, len(opts.Logins))
+	for _, login := range opts.Logins {
+		user, _, err := opts.APIClient.UsersService.FindOneByUsername(ctx, login)
+		if errors.Is(err, services.ErrNotFound) {
+			return fmt.Errorf("user %s is either non-existent or has not been indexed yet", login)
+		}
+		if err != nil {
+			return fmt.Errorf("could not get user %s: %w", login, err)
+		}
+
+		userIDs = append(userIDs, user.ID)
+	}
+
+	_, _, err = opts.APIClient.WorkspacesService.AddWorkspaceMembers(ctx, workspace.ID, userIDs, opts.Role)
+	if err != nil {
+		return fmt.Errorf("could not add members to workspace %s: %w", workspace.Name, err)
+	}
+
+	return writeWorkspace(ctx, opts.APIClient, workspace.ID, opts.Output, opts.out)
+}
@@ -0,0 +1,180 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	bubblesTable "github.com/charmbracelet/bubbles/table"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+// workspaceSummary is a workspace as listed by "pizza workspace list"
+type workspaceSummary struct {
+	ID        string    `json:"id" yaml:"id"`
+	Name      string    `json:"name" yaml:"name"`
+	IsPublic  bool      `json:"is_public" yaml:"is_public"`
+	Members   int       `json:"members" yaml:"members"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+type workspaceSummaries []workspaceSummary
+
+func newWorkspaceSummaries(data []workspaces.DbWorkspace) workspaceSummaries {
+	summaries := make(workspaceSummaries, 0, len(data))
+	for _, workspace := range data {
+		summaries = append(summaries, workspaceSummary{
+			ID:        workspace.ID,
+			Name:      workspace.Name,
+			IsPublic:  workspace.IsPublic,
+			Members:   len(workspace.Members),
+			UpdatedAt: workspace.UpdatedAt,
+		})
+	}
+
+	return summaries
+}
+
+func (ws workspaceSummaries) BuildOutput(format string) (string, error) {
+	switch format {
+	case constants.OutputTable:
+		return ws.OutputTable(), nil
+	case constants.OutputJSON:
+		return utils.OutputJSON(ws)
+	case constants.OutputYAML:
+		return utils.OutputYAML(ws)
+	default:
+		return "", fmt.Errorf("unknown output format %s", format)
+	}
+}
+
+func (ws workspaceSummaries) OutputTable() string {
+	rows := make([]bubblesTable.Row, 0, len(ws))
+	for _, w := range ws {
+		rows = append(rows, bubblesTable.Row{
+			w.ID,
+			w.Name,
+			strconv.FormatBool(w.IsPublic),
+			strconv.Itoa(w.Members),
+			w.UpdatedAt.Format(time.DateOnly),
+		})
+	}
+
+	titles := []string{"ID", "Name", "Public", "Members", "Updated"}
+	columns := make([]bubblesTable.Column, 0, len(titles))
+	for i, title := range titles {
+		columns = append(columns, bubblesTable.Column{Title: title, Width: utils.GetMaxTableColumnWidth(rows, i, title)})
+	}
+
+	return utils.OutputTable(rows, columns)
+}
+
+// workspaceDetails is a workspace as shown by "pizza workspace show" and the
+// commands changing it
+type workspaceDetails struct {
+	ID           string          `json:"id" yaml:"id"`
+	Name         string          `json:"name" yaml:"name"`
+	Description  string          `json:"description" yaml:"description"`
+	IsPublic     bool            `json:"is_public" yaml:"is_public"`
+	Repositories []string        `json:"repositories" yaml:"repositories"`
+	Members      []memberDetails `json:"members" yaml:"members"`
+	URL          string          `json:"url" yaml:"url"`
+	CreatedAt    time.Time       `json:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at" yaml:"updated_at"`
+}
+
+type memberDetails struct {
+	UserID int    `json:"user_id" yaml:"user_id"`
+	Role   string `json:"role" yaml:"role"`
+}
+
+// getWorkspaceDetails gets the repositories of the workspace, to show them
+// along with the rest of it
+func getWorkspaceDetails(ctx context.Context, client *api.Client, workspace *workspaces.DbWorkspace) (*workspaceDetails, error) {
+	repos, err := services.All(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]workspaces.DbWorkspaceRepo, services.MetaData, error) {
+		resp, _, err := client.WorkspacesService.GetWorkspaceRepos(ctx, workspace.ID, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return resp.Data, resp.Meta, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get repositories of workspace %s: %w", workspace.Name, err)
+	}
+
+	details := &workspaceDetails{
+		ID:           workspace.ID,
+		Name:         workspace.Name,
+		Description:  workspace.Description,
+		IsPublic:     workspace.IsPublic,
+		Repositories: []string{},
+		Members:      []memberDetails{},
+		URL:          fmt.Sprintf("%s/workspaces/%s", constants.AppURL, workspace.ID),
+		CreatedAt:    workspace.CreatedAt,
+		UpdatedAt:    workspace.UpdatedAt,
+	}
+	for _, repo := range repos {
+		details.Repositories = append(details.Repositories, repo.Repo.FullName)
+	}
+	for _, member := range workspace.Members {
+		details.Members = append(details.Members, memberDetails{UserID: member.UserID, Role: member.Role})
+	}
+
+	return details, nil
+}
+
+func (wd *workspaceDetails) BuildOutput(format string) (string, error) {
+	switch format {
+	case constants.OutputTable:
+		return wd.OutputTable(), nil
+	case constants.OutputJSON:
+		return utils.OutputJSON(wd)
+	case constants.OutputYAML:
+		return utils.OutputYAML(wd)
+	default:
+		return "", fmt.Errorf("unknown output format %s", format)
+	}
+}
+
+func (wd *workspaceDetails) OutputTable() string {
+	rows := []bubblesTable.Row{
+		{"ID", wd.ID},
+		{"Description", wd.Description},
+		{"Public", strconv.FormatBool(wd.IsPublic)},
+		{"Members", strconv.Itoa(len(wd.Members))},
+	}
+
+	// One repository per row, the first next to the title
+	for i, repo := range wd.Repositories {
+		title := ""
+		if i == 0 {
+			title = "Repositories"
+		}
+		rows = append(rows, bubblesTable.Row{title, repo})
+	}
+	if len(wd.Repositories) == 0 {
+		rows = append(rows, bubblesTable.Row{"Repositories", "none"})
+	}
+
+	rows = append(rows, bubblesTable.Row{"URL", wd.URL})
+
+	columns := []bubblesTable.Column{
+		{
+			Title: "Workspace",
+			Width: utils.GetMaxTableColumnWidth(rows, 0, "Workspace"),
+		},
+		{
+			Title: wd.Name,
+			Width: utils.GetMaxTableColumnWidth(rows, 1, wd.Name),
+		},
+	}
+
+	return utils.OutputTable(rows, columns)
+}
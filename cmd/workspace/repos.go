@@ -0,0 +1,98 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type reposOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// Repos are the GitHub URLs or full names of the repositories
+	Repos []string
+
+	// Remove removes the repositories instead of adding them
+	Remove bool
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the updated workspace is written to
+	out io.Writer
+}
+
+// NewAddRepoCommand returns a new cobra command for 'pizza workspace add-repo'
+func NewAddRepoCommand() *cobra.Command {
+	return newReposCommand(&cobra.Command{
+		Use:   "add-repo workspace repo...",
+		Short: "Add repositories to a workspace",
+		Example: `  # Add repositories by URL or by owner/name
+  $ pizza workspace add-repo "Pizza CLI" https://github.com/open-sauced/app open-sauced/pizza-cli`,
+	}, false)
+}
+
+// NewRemoveRepoCommand returns a new cobra command for 'pizza workspace remove-repo'
+func NewRemoveRepoCommand() *cobra.Command {
+	return newReposCommand(&cobra.Command{
+		Use:     "remove-repo workspace repo...",
+		Aliases: []string{"rm-repo"},
+		Short:   "Remove repositories from a workspace",
+	}, true)
+}
+
+func newReposCommand(cmd *cobra.Command, remove bool) *cobra.Command {
+	opts := &reposOptions{Remove: remove}
+	cmd.Args = cobra.MinimumNArgs(2)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		apiClient, err := authcmd.NewAPIClient(cmd)
+		if err != nil {
+			return err
+		}
+		opts.APIClient = apiClient
+		opts.Workspace = args[0]
+		opts.Repos = args[1:]
+		opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+		opts.out = cmd.OutOrStdout()
+
+		return opts.run(cmd.Context())
+	}
+
+	return cmd
+}
+
+func (opts *reposOptions) run(ctx context.Context) error {
+	repos, err := RepoFullNames(opts.Repos)
+	if err != nil {
+		return err
+	}
+
+	workspace, err := FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	if opts.Remove {
+		_, err = opts.APIClient.WorkspacesService.RemoveWorkspaceRepos(ctx, workspace.ID, repos)
+		if err != nil {
+			return fmt.Errorf("could not remove repositories from workspace %s: %w", workspace.Name, err)
+		}
+	} else {
+		_, err = opts.APIClient.WorkspacesService.AddWorkspaceRepos(ctx, workspace.ID, repos)
+		if err != nil {
+			return fmt.Errorf("could not add repositories to workspace %s: %w", workspace.Name, err)
+		}
+	}
+
+	return writeWorkspace(ctx, opts.APIClient, workspace.ID, opts.Output, opts.out)
+}
@@ -0,0 +1,81 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type showOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the workspace is written to
+	out io.Writer
+}
+
+// NewShowCommand returns a new cobra command for 'pizza workspace show'
+func NewShowCommand() *cobra.Command {
+	opts := &showOptions{}
+	cmd := &cobra.Command{
+		Use:   "show workspace",
+		Short: "Show a workspace along with its repositories",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Workspace = args[0]
+			opts.Output, _ = cmd.Flags().GetString(constants.FlagNameOutput)
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+func (opts *showOptions) run(ctx context.Context) error {
+	workspace, err := FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	return writeWorkspace(ctx, opts.APIClient, workspace.ID, opts.Output, opts.out)
+}
+
+// writeWorkspace writes the workspace with the given ID, as it's now
+func writeWorkspace(ctx context.Context, client *api.Client, workspaceID, format string, out io.Writer) error {
+	workspace, _, err := client.WorkspacesService.GetWorkspace(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("could not get workspace %s: %w", workspaceID, err)
+	}
+
+	details, err := getWorkspaceDetails(ctx, client, workspace)
+	if err != nil {
+		return err
+	}
+
+	output, err := details.BuildOutput(format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, output)
+	return nil
+}
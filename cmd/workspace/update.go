@@ -0,0 +1,99 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces"
+	authcmd "github.com/open-sauced/pizza-cli/v2/cmd/auth"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+type updateOptions struct {
+	// APIClient is the http client for making calls to the open-sauced api
+	APIClient *api.Client
+
+	// Workspace is the ID or name of the workspace
+	Workspace string
+
+	// Update has the fields of the workspace that are changed
+	Update workspaces.UpdateWorkspaceRequest
+
+	// Output is the formatting style for command output
+	Output string
+
+	// out is where the updated workspace is written to
+	out io.Writer
+}
+
+// NewUpdateCommand returns a new cobra command for 'pizza workspace update'
+func NewUpdateCommand() *cobra.Command {
+	opts := &updateOptions{}
+	cmd := &cobra.Command{
+		Use:   "update workspace [flags]",
+		Short: "Rename a workspace, change its description, or make it public or private",
+		Example: `  # Rename a workspace and make it public
+  $ pizza workspace update "Pizza CLI" --name "Pizza" --public`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			if flags.Changed("name") {
+				name, _ := flags.GetString("name")
+				opts.Update.Name = &name
+			}
+			if flags.Changed("description") {
+				description, _ := flags.GetString("description")
+				opts.Update.Description = &description
+			}
+			if flags.Changed("public") || flags.Changed("private") {
+				isPublic, _ := flags.GetBool("public")
+				if flags.Changed("private") {
+					isPrivate, _ := flags.GetBool("private")
+					isPublic = !isPrivate
+				}
+				opts.Update.IsPublic = &isPublic
+			}
+
+			apiClient, err := authcmd.NewAPIClient(cmd)
+			if err != nil {
+				return err
+			}
+			opts.APIClient = apiClient
+			opts.Workspace = args[0]
+			opts.Output, _ = flags.GetString(constants.FlagNameOutput)
+			opts.out = cmd.OutOrStdout()
+
+			return opts.run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().String("name", "", "New name of the workspace")
+	cmd.Flags().StringP("description", "d", "", "New description of the workspace")
+	cmd.Flags().Bool("public", false, "Make the workspace public")
+	cmd.Flags().Bool("private", false, "Make the workspace private")
+	cmd.MarkFlagsMutuallyExclusive("public", "private")
+	return cmd
+}
+
+func (opts *updateOptions) run(ctx context.Context) error {
+	if opts.Update == (workspaces.UpdateWorkspaceRequest{}) {
+		return errors.New("nothing to update, set at least one of --name, --description, --public, or --private")
+	}
+
+	workspace, err := FindWorkspace(ctx, opts.APIClient, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = opts.APIClient.WorkspacesService.UpdateWorkspace(ctx, workspace.ID, opts.Update)
+	if err != nil {
+		return fmt.Errorf("could not update workspace %s: %w", workspace.Name, err)
+	}
+
+	return writeWorkspace(ctx, opts.APIClient, workspace.ID, opts.Output, opts.out)
+}
@@ -0,0 +1,109 @@
+// Package workspace contains the commands managing the OpenSauced workspaces
+// of the logged in user
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/services"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
+)
+
+// DefaultName is the name of the workspace "pizza generate insight" adds its
+// Contributor Insights to unless another one is chosen
+const DefaultName = "Pizza CLI"
+
+// NewWorkspaceCommand returns a new cobra command for 'pizza workspace'
+func NewWorkspaceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "workspace <command> [flags]",
+		Aliases: []string{"workspaces"},
+		Short:   "Manage your OpenSauced workspaces",
+		Long: `Manage the OpenSauced workspaces of the logged in user: list, create, update, and
+delete them, and manage their repositories and members.
+
+Workspaces are referred to by their ID or their name.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().StringP(constants.FlagNameOutput, "o", constants.OutputTable, "The formatting for command output. One of: (table, yaml, json)")
+	cmd.AddCommand(NewListCommand())
+	cmd.AddCommand(NewCreateCommand())
+	cmd.AddCommand(NewShowCommand())
+	cmd.AddCommand(NewUpdateCommand())
+	cmd.AddCommand(NewDeleteCommand())
+	cmd.AddCommand(NewAddRepoCommand())
+	cmd.AddCommand(NewRemoveRepoCommand())
+	cmd.AddCommand(NewAddMemberCommand())
+	return cmd
+}
+
+// FindWorkspace returns the workspace of the authenticated user with the
+// given ID or name. Names must be unambiguous.
+func FindWorkspace(ctx context.Context, client *api.Client, ref string) (*workspaces.DbWorkspace, error) {
+	var matches []workspaces.DbWorkspace
+	err := services.ForEach(ctx, services.PageOptions{}, func(ctx context.Context, page, limit int) ([]workspaces.DbWorkspace, services.MetaData, error) {
+		resp, _, err := client.WorkspacesService.GetWorkspaces(ctx, page, limit)
+		if err != nil {
+			return nil, services.MetaData{}, err
+		}
+
+		return resp.Data, resp.Meta, nil
+	}, func(workspace workspaces.DbWorkspace) error {
+		if workspace.ID == ref {
+			matches = []workspaces.DbWorkspace{workspace}
+			return services.ErrStopIteration
+		}
+
+		if workspace.Name == ref {
+			matches = append(matches, workspace)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no workspace with the ID or name %q", ref)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d workspaces are named %q, use the ID of one of them instead", len(matches), ref)
+	}
+}
+
+// RepoFullNames returns the full names of the repositories, given as GitHub
+// URLs or as full names like "open-sauced/pizza-cli"
+func RepoFullNames(repos []string) ([]string, error) {
+	fullNames := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		if !strings.Contains(repo, "://") {
+			owner, name, ok := strings.Cut(repo, "/")
+			if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+				return nil, fmt.Errorf("invalid repository %q, expected a GitHub URL or owner/name", repo)
+			}
+
+			fullNames = append(fullNames, repo)
+			continue
+		}
+
+		owner, name, err := utils.GetOwnerAndRepoFromURL(repo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository %q: %w", repo, err)
+		}
+		fullNames = append(fullNames, owner+"/"+name)
+	}
+
+	return fullNames, nil
+}
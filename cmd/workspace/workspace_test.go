@@ -0,0 +1,137 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-sauced/pizza-cli/v2/api"
+	"github.com/open-sauced/pizza-cli/v2/api/mock/server"
+	"github.com/open-sauced/pizza-cli/v2/api/services/workspaces"
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+func decodeDetails(t *testing.T, out *bytes.Buffer) workspaceDetails {
+	var details workspaceDetails
+	require.NoError(t, json.Unmarshal(out.Bytes(), &details))
+	out.Reset()
+
+	return details
+}
+
+func TestWorkspaceCommands(t *testing.T) {
+	t.Parallel()
+	client := server.NewTestClient(t, server.SampleDataset(), "token")
+	ctx := context.Background()
+	var out bytes.Buffer
+
+	create := &createOptions{
+		APIClient:   client,
+		Name:        "Pizza CLI",
+		Description: "A workspace for the Pizza CLI",
+		Repos:       []string{"https://github.com/open-sauced/pizza-cli"},
+		Output:      constants.OutputJSON,
+		out:         &out,
+	}
+	require.NoError(t, create.run(ctx))
+	created := decodeDetails(t, &out)
+	assert.Equal(t, "Pizza CLI", created.Name)
+	assert.Equal(t, []string{"open-sauced/pizza-cli"}, created.Repositories)
+	assert.Equal(t, "https://app.opensauced.pizza/workspaces/"+created.ID, created.URL)
+
+	addRepo := &reposOptions{APIClient: client, Workspace: "Pizza CLI", Repos: []string{"open-sauced/app"}, Output: constants.OutputJSON, out: &out}
+	require.NoError(t, addRepo.run(ctx))
+	assert.Equal(t, []string{"open-sauced/pizza-cli", "open-sauced/app"}, decodeDetails(t, &out).Repositories)
+
+	removeRepo := &reposOptions{APIClient: client, Workspace: created.ID, Repos: []string{"https://github.com/open-sauced/pizza-cli"}, Remove: true, Output: constants.OutputJSON, out: &out}
+	require.NoError(t, removeRepo.run(ctx))
+	assert.Equal(t, []string{"open-sauced/app"}, decodeDetails(t, &out).Repositories)
+
+	addMember := &addMemberOptions{APIClient: client, Workspace: "Pizza CLI", Logins: []string{"jpmcb"}, Role: workspaces.RoleEditor, Output: constants.OutputJSON, out: &out}
+	require.NoError(t, addMember.run(ctx))
+	assert.Contains(t, decodeDetails(t, &out).Members, memberDetails{UserID: 23109390, Role: workspaces.RoleEditor})
+
+	name := "Pizza"
+	isPublic := true
+	update := &updateOptions{
+		APIClient: client,
+		Workspace: "Pizza CLI",
+		Update:    workspaces.UpdateWorkspaceRequest{Name: &name, IsPublic: &isPublic},
+		Output:    constants.OutputJSON,
+		out:       &out,
+	}
+	require.NoError(t, update.run(ctx))
+	updated := decodeDetails(t, &out)
+	assert.Equal(t, "Pizza", updated.Name)
+	assert.True(t, updated.IsPublic)
+	assert.Equal(t, "A workspace for the Pizza CLI", updated.Description)
+
+	list := &listOptions{APIClient: client, Output: constants.OutputTable, out: &out}
+	require.NoError(t, list.run(ctx))
+	assert.Contains(t, out.String(), "OpenSauced")
+	assert.Contains(t, out.String(), created.ID+" Pizza")
+	out.Reset()
+
+	// Declining leaves the workspace be
+	del := &deleteOptions{APIClient: client, Workspace: "Pizza", in: strings.NewReader("n\n"), out: &out}
+	require.NoError(t, del.run(ctx))
+	_, err := FindWorkspace(ctx, client, "Pizza")
+	require.NoError(t, err)
+	out.Reset()
+
+	del = &deleteOptions{APIClient: client, Workspace: "Pizza", in: strings.NewReader("y\n"), out: &out}
+	require.NoError(t, del.run(ctx))
+	assert.Contains(t, out.String(), "Deleted workspace Pizza ("+created.ID+")")
+
+	_, err = FindWorkspace(ctx, client, "Pizza")
+	require.EqualError(t, err, `no workspace with the ID or name "Pizza"`)
+}
+
+func TestFindWorkspaceAmbiguous(t *testing.T) {
+	t.Parallel()
+	client := server.NewTestClient(t, server.SampleDataset(), "token")
+	ctx := context.Background()
+
+	_, _, err := client.WorkspacesService.CreateWorkspaceForUser(ctx, "OpenSauced", "", nil)
+	require.NoError(t, err)
+
+	_, err = FindWorkspace(ctx, client, "OpenSauced")
+	require.EqualError(t, err, `2 workspaces are named "OpenSauced", use the ID of one of them instead`)
+
+	workspace, err := FindWorkspace(ctx, client, "4b3e6d0a-6f1c-4d8e-9a5b-2f7c1e0d9a11")
+	require.NoError(t, err)
+	assert.Equal(t, "OpenSauced", workspace.Name)
+}
+
+func TestWorkspaceCommandsLoggedOut(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+
+	list := &listOptions{APIClient: server.NewTestClient(t, server.SampleDataset(), ""), Output: constants.OutputTable, out: &out}
+	err := list.run(context.Background())
+	require.ErrorIs(t, err, api.ErrUnauthorized)
+	assert.Equal(t, 1, strings.Count(err.Error(), `"pizza login"`), "the login hint is given once")
+}
+
+func TestUpdateNothing(t *testing.T) {
+	t.Parallel()
+
+	update := &updateOptions{APIClient: server.NewTestClient(t, server.SampleDataset(), "token"), Workspace: "OpenSauced"}
+	require.EqualError(t, update.run(context.Background()), "nothing to update, set at least one of --name, --description, --public, or --private")
+}
+
+func TestRepoFullNames(t *testing.T) {
+	t.Parallel()
+
+	names, err := RepoFullNames([]string{"open-sauced/pizza-cli", "https://github.com/open-sauced/app"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"open-sauced/pizza-cli", "open-sauced/app"}, names)
+
+	_, err = RepoFullNames([]string{"pizza-cli"})
+	require.EqualError(t, err, `invalid repository "pizza-cli", expected a GitHub URL or owner/name`)
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
 
 	"github.com/open-sauced/pizza-cli/v2/cmd/root"
 	"github.com/open-sauced/pizza-cli/v2/pkg/utils"
@@ -14,7 +16,11 @@ func main() {
 		log.Fatal(err)
 	}
 	utils.SetupRootCommand(rootCmd)
-	err = rootCmd.Execute()
+
+	// Cancel in flight API requests on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err = rootCmd.ExecuteContext(ctx)
+	stop()
 	if err != nil {
 		os.Exit(1)
 	}
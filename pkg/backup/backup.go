@@ -0,0 +1,214 @@
+// Package backup keeps copies of files before file-editing commands change
+// them so the changes can be undone
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/config"
+)
+
+const (
+	backupsDirName   = "backups"
+	manifestFileName = "manifest.json"
+
+	// idLayout is the time layout for backup IDs which sort chronologically
+	idLayout = "20060102T150405.000000000Z"
+)
+
+// ErrNoBackup is returned when there is no backup to restore
+var ErrNoBackup = errors.New("no backup found")
+
+// Manifest describes a single backup: the command that created it,
+// the scope it applies to (typically the repository path), and the files it holds
+type Manifest struct {
+	ID        string    `json:"id"`
+	Command   string    `json:"command"`
+	Scope     string    `json:"scope"`
+	CreatedAt time.Time `json:"created_at"`
+	Files     []File    `json:"files"`
+
+	// dir is the directory on disk holding this backup
+	dir string
+}
+
+// File is a single file held in a backup
+type File struct {
+	// Path is the absolute path of the original file
+	Path string `json:"path"`
+
+	// Existed denotes if the original file existed when the backup was made.
+	// Restoring a file that did not exist removes it.
+	Existed bool `json:"existed"`
+
+	// Name is the name of the copy within the backup directory
+	Name string `json:"name,omitempty"`
+
+	// Mode is the permission bits of the original file
+	Mode os.FileMode `json:"mode,omitempty"`
+}
+
+// Create copies the given files into a new backup under "~/.pizza-cli/backups"
+func Create(command, scope string, paths []string) (*Manifest, error) {
+	backupsDir, err := getBackupsDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	id := now.Format(idLayout)
+	manifest := &Manifest{
+		ID:        id,
+		Command:   command,
+		Scope:     scope,
+		CreatedAt: now,
+		dir:       filepath.Join(backupsDir, id),
+	}
+
+	if err := os.MkdirAll(manifest.dir, 0700); err != nil {
+		return nil, fmt.Errorf("could not create backup directory: %w", err)
+	}
+
+	for i, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving absolute path: %s - %w", path, err)
+		}
+
+		file := File{Path: absPath}
+
+		data, err := os.ReadFile(absPath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("could not read file to back up: %s - %w", absPath, err)
+		default:
+			info, err := os.Stat(absPath)
+			if err != nil {
+				return nil, fmt.Errorf("could not stat file to back up: %s - %w", absPath, err)
+			}
+
+			file.Existed = true
+			file.Mode = info.Mode().Perm()
+			file.Name = strconv.Itoa(i) + "-" + filepath.Base(absPath)
+
+			if err := os.WriteFile(filepath.Join(manifest.dir, file.Name), data, 0600); err != nil {
+				return nil, fmt.Errorf("could not write backup of: %s - %w", absPath, err)
+			}
+		}
+
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling backup manifest failed: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(manifest.dir, manifestFileName), data, 0600); err != nil {
+		return nil, fmt.Errorf("could not write backup manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// Latest returns the most recent backup made by the given command for the given scope.
+// ErrNoBackup is returned if there is none.
+func Latest(command, scope string) (*Manifest, error) {
+	backupsDir, err := getBackupsDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(backupsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoBackup
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read backups directory: %w", err)
+	}
+
+	// Backup IDs are timestamps: sort them newest first
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() > entries[j].Name()
+	})
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(backupsDir, entry.Name())
+		data, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+		if err != nil {
+			continue
+		}
+
+		var manifest Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			continue
+		}
+
+		if manifest.Command == command && manifest.Scope == scope {
+			manifest.dir = dir
+			return &manifest, nil
+		}
+	}
+
+	return nil, ErrNoBackup
+}
+
+// Restore puts every file in the backup back in its original location and
+// then deletes the backup
+func (m *Manifest) Restore() error {
+	for _, file := range m.Files {
+		if !file.Existed {
+			if err := os.Remove(file.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("could not remove: %s - %w", file.Path, err)
+			}
+
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(m.dir, file.Name))
+		if err != nil {
+			return fmt.Errorf("could not read backup of: %s - %w", file.Path, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(file.Path), os.ModePerm); err != nil {
+			return fmt.Errorf("could not create directory for: %s - %w", file.Path, err)
+		}
+
+		mode := file.Mode
+		if mode == 0 {
+			mode = 0600
+		}
+
+		if err := os.WriteFile(file.Path, data, mode); err != nil {
+			return fmt.Errorf("could not restore: %s - %w", file.Path, err)
+		}
+	}
+
+	return os.RemoveAll(m.dir)
+}
+
+// Dir returns the directory on disk holding the backup
+func (m *Manifest) Dir() string {
+	return m.dir
+}
+
+func getBackupsDirectory() (string, error) {
+	configDir, err := config.GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, backupsDirName), nil
+}
@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndRestore(t *testing.T) {
+	// Backups live in the user's home directory
+	t.Setenv("HOME", t.TempDir())
+
+	repoDir := t.TempDir()
+	existingPath := filepath.Join(repoDir, "CODEOWNERS")
+	newPath := filepath.Join(repoDir, ".sauced.yaml")
+
+	require.NoError(t, os.WriteFile(existingPath, []byte("* @jpmcb\n"), 0644))
+
+	_, err := Latest("offboard", repoDir)
+	require.ErrorIs(t, err, ErrNoBackup)
+
+	manifest, err := Create("offboard", repoDir, []string{existingPath, newPath})
+	require.NoError(t, err)
+	assert.Len(t, manifest.Files, 2)
+
+	// Simulate the command editing and creating files
+	require.NoError(t, os.WriteFile(existingPath, []byte("*\n"), 0644))
+	require.NoError(t, os.WriteFile(newPath, []byte("attribution: {}\n"), 0600))
+
+	_, err = Latest("onboard", repoDir)
+	require.ErrorIs(t, err, ErrNoBackup)
+
+	latest, err := Latest("offboard", repoDir)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.ID, latest.ID)
+
+	require.NoError(t, latest.Restore())
+
+	data, err := os.ReadFile(existingPath)
+	require.NoError(t, err)
+	assert.Equal(t, "* @jpmcb\n", string(data))
+
+	info, err := os.Stat(existingPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	_, err = os.Stat(newPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// Restoring consumes the backup
+	_, err = Latest("offboard", repoDir)
+	require.ErrorIs(t, err, ErrNoBackup)
+}
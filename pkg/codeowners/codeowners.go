@@ -0,0 +1,409 @@
+// Package codeowners parses and serializes GitHub style CODEOWNERS files.
+//
+// A parsed File keeps every line, including comments and blank lines, so that
+// serializing an unmodified File reproduces the original contents exactly.
+// Only the lines changed through the API are re-rendered.
+package codeowners
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// escapedChar matches a backslash escaped character in a pattern
+var escapedChar = regexp.MustCompile(`\\(.)`)
+
+// Kind is the kind of a single line in a CODEOWNERS file
+type Kind int
+
+const (
+	// KindBlank is an empty or whitespace only line
+	KindBlank Kind = iota
+
+	// KindComment is a line holding only a "#" comment
+	KindComment
+
+	// KindRule is a line with a pattern, its owners, and an optional trailing comment
+	KindRule
+)
+
+// File is a parsed CODEOWNERS file
+type File struct {
+	Lines []*Line
+}
+
+// Line is a single line in a CODEOWNERS file
+type Line struct {
+	Kind Kind
+
+	// Pattern is the path pattern of a rule, exactly as written in the file
+	// (including any backslash escapes)
+	Pattern string
+
+	// Owners are the "@login", "@org/team", or email owners of a rule
+	Owners []string
+
+	// Comment is the text of a comment line or the trailing comment of a
+	// rule, including the leading "#"
+	Comment string
+
+	// raw is the original text of the line. It is cleared once the line is
+	// modified so the line is rendered from its fields instead.
+	raw string
+
+	// crlf denotes that the line ended with a carriage return
+	crlf bool
+}
+
+// Parse reads and parses a CODEOWNERS file
+func Parse(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CODEOWNERS: %w", err)
+	}
+
+	return ParseString(string(data)), nil
+}
+
+// ParseString parses the contents of a CODEOWNERS file. Parsing never fails:
+// any line that isn't blank or a comment is treated as a rule.
+func ParseString(contents string) *File {
+	f := &File{}
+
+	for _, text := range strings.Split(contents, "\n") {
+		f.Lines = append(f.Lines, parseLine(text))
+	}
+
+	return f
+}
+
+func parseLine(text string) *Line {
+	l := &Line{raw: text}
+
+	if strings.HasSuffix(text, "\r") {
+		l.crlf = true
+		text = strings.TrimSuffix(text, "\r")
+	}
+
+	trimmed := strings.TrimSpace(text)
+	switch {
+	case trimmed == "":
+		l.Kind = KindBlank
+		return l
+	case strings.HasPrefix(trimmed, "#"):
+		l.Kind = KindComment
+		l.Comment = trimmed
+		return l
+	}
+
+	l.Kind = KindRule
+
+	tokens, comment := tokenize(trimmed)
+	l.Pattern = tokens[0]
+	l.Owners = tokens[1:]
+	l.Comment = comment
+
+	return l
+}
+
+// tokenize splits a rule on unescaped whitespace, stopping at an unescaped "#"
+// that starts a token which begins the trailing comment.
+func tokenize(text string) ([]string, string) {
+	var tokens []string
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case c == '\\' && i+1 < len(text):
+			current.WriteByte(c)
+			current.WriteByte(text[i+1])
+			i++
+		case c == ' ' || c == '\t':
+			flush()
+		case c == '#' && current.Len() == 0 && len(tokens) > 0:
+			return tokens, strings.TrimSpace(text[i:])
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	flush()
+	return tokens, ""
+}
+
+// NewRule returns a new rule line for the given pattern and owners
+func NewRule(pattern string, owners []string) *Line {
+	return &Line{
+		Kind:    KindRule,
+		Pattern: pattern,
+		Owners:  owners,
+	}
+}
+
+// NewComment returns a new comment line. A "# " prefix is added if the text
+// doesn't already start with "#".
+func NewComment(text string) *Line {
+	if !strings.HasPrefix(text, "#") {
+		text = strings.TrimRight("# "+text, " ")
+	}
+
+	return &Line{
+		Kind:    KindComment,
+		Comment: text,
+	}
+}
+
+// NewBlank returns a new blank line
+func NewBlank() *Line {
+	return &Line{Kind: KindBlank}
+}
+
+// String renders the line. Unmodified lines are returned exactly as parsed.
+func (l *Line) String() string {
+	if l.raw != "" || (l.Kind == KindBlank && !l.crlf) {
+		return l.raw
+	}
+
+	var text string
+	switch l.Kind {
+	case KindComment:
+		text = l.Comment
+	case KindRule:
+		text = strings.Join(append([]string{l.Pattern}, l.Owners...), " ")
+		if l.Comment != "" {
+			text += " " + l.Comment
+		}
+	}
+
+	if l.crlf {
+		text += "\r"
+	}
+
+	return text
+}
+
+// SetOwners replaces the owners of a rule
+func (l *Line) SetOwners(owners []string) {
+	l.Owners = owners
+	l.raw = ""
+}
+
+// HasOwner checks if the rule has the given owner. See OwnerEqual for how owners are compared.
+func (l *Line) HasOwner(owner string) bool {
+	return slices.ContainsFunc(l.Owners, func(o string) bool {
+		return OwnerEqual(o, owner)
+	})
+}
+
+// RemoveOwner removes the given owner from the rule, returning whether it was present
+func (l *Line) RemoveOwner(owner string) bool {
+	owners := slices.DeleteFunc(slices.Clone(l.Owners), func(o string) bool {
+		return OwnerEqual(o, owner)
+	})
+
+	if len(owners) == len(l.Owners) {
+		return false
+	}
+
+	l.SetOwners(owners)
+	return true
+}
+
+// AddOwner appends the given owner to the rule if it isn't already present,
+// returning whether it was added
+func (l *Line) AddOwner(owner string) bool {
+	if l.HasOwner(owner) {
+		return false
+	}
+
+	l.SetOwners(append(slices.Clone(l.Owners), owner))
+	return true
+}
+
+// String renders the whole file
+func (f *File) String() string {
+	lines := make([]string, 0, len(f.Lines))
+	for _, l := range f.Lines {
+		lines = append(lines, l.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Rules returns the rule lines of the file in order
+func (f *File) Rules() []*Line {
+	var rules []*Line
+	for _, l := range f.Lines {
+		if l.Kind == KindRule {
+			rules = append(rules, l)
+		}
+	}
+
+	return rules
+}
+
+// Owners returns every unique owner in the file, sorted
+func (f *File) Owners() []string {
+	seen := make(map[string]struct{})
+	var owners []string
+
+	for _, rule := range f.Rules() {
+		for _, owner := range rule.Owners {
+			key := strings.ToLower(owner)
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				owners = append(owners, owner)
+			}
+		}
+	}
+
+	sort.Strings(owners)
+	return owners
+}
+
+// Append adds lines to the end of the file. When the file ends with a
+// trailing newline, the lines are inserted before it.
+func (f *File) Append(lines ...*Line) {
+	if n := len(f.Lines); n > 0 && f.Lines[n-1].Kind == KindBlank && f.Lines[n-1].raw == "" {
+		f.Lines = append(f.Lines[:n-1], append(lines, f.Lines[n-1])...)
+		return
+	}
+
+	f.Lines = append(f.Lines, lines...)
+}
+
+// InsertBefore adds lines right before the given line of the file, or at the
+// end of the file if the line isn't part of it
+func (f *File) InsertBefore(at *Line, lines ...*Line) {
+	i := slices.Index(f.Lines, at)
+	if i < 0 {
+		f.Append(lines...)
+		return
+	}
+
+	f.Lines = slices.Insert(f.Lines, i, lines...)
+}
+
+// InsertAfter adds lines right after the given line of the file, or at the
+// end of the file if the line isn't part of it
+func (f *File) InsertAfter(at *Line, lines ...*Line) {
+	i := slices.Index(f.Lines, at)
+	if i < 0 {
+		f.Append(lines...)
+		return
+	}
+
+	f.Lines = slices.Insert(f.Lines, i+1, lines...)
+}
+
+// EscapePattern escapes the whitespace and "#" characters of a path pattern
+// with a backslash, so the pattern is written as a single token that isn't
+// read as a comment. Characters that are already escaped are kept as is.
+func EscapePattern(pattern string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			escaped.WriteByte(c)
+			escaped.WriteByte(pattern[i+1])
+			i++
+		case c == ' ' || c == '\t' || c == '#':
+			escaped.WriteByte('\\')
+			escaped.WriteByte(c)
+		default:
+			escaped.WriteByte(c)
+		}
+	}
+
+	return escaped.String()
+}
+
+// Matcher returns a function checking if a path, relative to the root of the
+// repository, matches the pattern of a rule.
+//
+// Like in ".gitignore" files, patterns without a slash before their end match
+// at any depth, patterns matching a directory match everything in it, and a
+// trailing "/*" only matches the files directly in a directory. "**" matches
+// across directories, "*" and "?" match within a single path segment, and
+// backslash escapes are ignored.
+func Matcher(pattern string) func(path string) bool {
+	pattern = escapedChar.ReplaceAllString(pattern, "$1")
+
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	directFiles := strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "**/*")
+	pattern = strings.Trim(pattern, "/")
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+
+	if directFiles {
+		expr.WriteString("$")
+	} else {
+		expr.WriteString("(/.*)?$")
+	}
+
+	re := regexp.MustCompile(expr.String())
+	return func(path string) bool {
+		return re.MatchString(strings.TrimPrefix(path, "/"))
+	}
+}
+
+// IsTeam checks if an owner is a GitHub team, like "@org/team"
+func IsTeam(owner string) bool {
+	return strings.HasPrefix(owner, "@") && strings.Contains(owner, "/")
+}
+
+// IsEmail checks if an owner is an email address rather than a GitHub handle
+func IsEmail(owner string) bool {
+	return !strings.HasPrefix(owner, "@") && strings.Contains(owner, "@")
+}
+
+// Login returns the GitHub login or team of an owner without the leading "@".
+// Emails are returned unchanged.
+func Login(owner string) string {
+	return strings.TrimPrefix(owner, "@")
+}
+
+// OwnerEqual compares two owners. GitHub handles are compared case
+// insensitively and with or without the leading "@". Emails are compared
+// case insensitively.
+func OwnerEqual(a, b string) bool {
+	if IsEmail(a) || IsEmail(b) {
+		return strings.EqualFold(a, b)
+	}
+
+	return strings.EqualFold(Login(a), Login(b))
+}
@@ -0,0 +1,177 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCodeowners = `# This file is generated automatically by OpenSauced pizza-cli. DO NOT EDIT. Stay saucy!
+#
+# Generated with command:
+# $ pizza generate codeowners pizza-cli/
+
+*.go @jpmcb @open-sauced/engineering
+docs/ john@opensauced.pizza   @bobby # docs team
+path/to/\(home\).go @brandonroberts
+path\ with\ spaces/ @zeucapua
+\#not-a-comment @nickytonline
+unowned.txt
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	f := ParseString(testCodeowners)
+
+	rules := f.Rules()
+	require.Len(t, rules, 6)
+
+	assert.Equal(t, "*.go", rules[0].Pattern)
+	assert.Equal(t, []string{"@jpmcb", "@open-sauced/engineering"}, rules[0].Owners)
+
+	assert.Equal(t, "docs/", rules[1].Pattern)
+	assert.Equal(t, []string{"john@opensauced.pizza", "@bobby"}, rules[1].Owners)
+	assert.Equal(t, "# docs team", rules[1].Comment)
+
+	assert.Equal(t, `path/to/\(home\).go`, rules[2].Pattern)
+	assert.Equal(t, `path\ with\ spaces/`, rules[3].Pattern)
+	assert.Equal(t, `\#not-a-comment`, rules[4].Pattern)
+
+	assert.Equal(t, "unowned.txt", rules[5].Pattern)
+	assert.Empty(t, rules[5].Owners)
+
+	assert.Equal(t, KindComment, f.Lines[0].Kind)
+	assert.Equal(t, KindBlank, f.Lines[4].Kind)
+
+	assert.Equal(t, []string{"@bobby", "@brandonroberts", "@jpmcb", "@nickytonline", "@open-sauced/engineering", "@zeucapua", "john@opensauced.pizza"}, f.Owners())
+
+	// Unmodified files round trip exactly
+	assert.Equal(t, testCodeowners, f.String())
+}
+
+func TestRemoveOwner(t *testing.T) {
+	t.Parallel()
+
+	f := ParseString("a.go @bob @bobby @alice\nb.go @Bob # comment\r\nc.go @alice\n")
+
+	for _, rule := range f.Rules() {
+		rule.RemoveOwner("bob")
+	}
+
+	// Only exact handles are removed, every other owner is kept, and untouched lines are left as is
+	assert.Equal(t, "a.go @bobby @alice\nb.go # comment\r\nc.go @alice\n", f.String())
+}
+
+func TestAppend(t *testing.T) {
+	t.Parallel()
+
+	f := ParseString("a.go @alice\n")
+	f.Append(NewComment("added by onboard"), NewRule("b.go", []string{"@bob"}))
+
+	assert.Equal(t, "a.go @alice\n# added by onboard\nb.go @bob\n", f.String())
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	f := ParseString("# owners\na.go @alice\nc.go @carol\n")
+	rules := f.Rules()
+	f.InsertBefore(rules[0], NewRule("*", []string{"@team"}))
+	f.InsertAfter(rules[0], NewRule("b.go", []string{"@bob"}))
+	f.InsertAfter(&Line{}, NewRule("d.go", []string{"@dave"}))
+
+	assert.Equal(t, "# owners\n* @team\na.go @alice\nb.go @bob\nc.go @carol\nd.go @dave\n", f.String())
+}
+
+func TestEscapePattern(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "src/**", EscapePattern("src/**"))
+	assert.Equal(t, `my\ docs/\#1/`, EscapePattern("my docs/#1/"))
+	assert.Equal(t, `already\ escaped\#`, EscapePattern(`already\ escaped\#`))
+
+	// Escaped patterns are parsed back as a single pattern
+	f := ParseString(EscapePattern("#notes and todos") + " @alice\n")
+	assert.Equal(t, []string{"@alice"}, f.Rules()[0].Owners)
+}
+
+func TestMatcher(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*", "src/a.go", true},
+		{"*.go", "src/nested/a.go", true},
+		{"*.go", "README.md", false},
+		{"/docs/", "docs/guides/setup.md", true},
+		{"/docs/", "src/docs/a.md", false},
+		{"docs/", "src/docs/a.md", true},
+		{"docs/*", "docs/a.md", true},
+		{"docs/*", "docs/guides/setup.md", false},
+		{"src/**/test.go", "src/a/b/test.go", true},
+		{`my\ docs/\#1.md`, "my docs/#1.md", true},
+		{"src/a.go", "/src/a.go", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Matcher(tt.pattern)(tt.path), "%s matching %s", tt.pattern, tt.path)
+	}
+}
+
+func TestOwnerHelpers(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsTeam("@open-sauced/engineering"))
+	assert.False(t, IsTeam("@jpmcb"))
+	assert.True(t, IsEmail("john@opensauced.pizza"))
+	assert.False(t, IsEmail("@jpmcb"))
+	assert.Equal(t, "jpmcb", Login("@jpmcb"))
+	assert.True(t, OwnerEqual("@JPMCB", "jpmcb"))
+	assert.True(t, OwnerEqual("John@OpenSauced.pizza", "john@opensauced.pizza"))
+	assert.False(t, OwnerEqual("@john", "john@opensauced.pizza"))
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(testCodeowners)
+	f.Add("a.go @bob @bobby\r\nb.go # comment\n\n")
+	f.Add(`path\ with\ spaces/ @a #c`)
+
+	f.Fuzz(func(t *testing.T, contents string) {
+		parsed := ParseString(contents)
+
+		// Serializing an unmodified file must reproduce it exactly
+		if got := parsed.String(); got != contents {
+			t.Fatalf("round trip mismatch:\n%q\n%q", contents, got)
+		}
+
+		// Re-rendering every rule from its fields must parse back to the same rules
+		for _, rule := range parsed.Rules() {
+			rule.SetOwners(rule.Owners)
+		}
+
+		reparsed := ParseString(parsed.String())
+		before, after := parsed.Rules(), reparsed.Rules()
+		if len(before) != len(after) {
+			t.Fatalf("rule count mismatch: %d != %d", len(before), len(after))
+		}
+
+		for i := range before {
+			if before[i].Pattern != after[i].Pattern ||
+				before[i].Comment != after[i].Comment ||
+				len(before[i].Owners) != len(after[i].Owners) {
+				t.Fatalf("rule mismatch:\n%#v\n%#v", before[i], after[i])
+			}
+
+			for j := range before[i].Owners {
+				if before[i].Owners[j] != after[i].Owners[j] {
+					t.Fatalf("owner mismatch:\n%#v\n%#v", before[i], after[i])
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	cliConfigFileName = "config.yaml"
+
+	// EnvPrefix is the prefix for environment variables that provide
+	// defaults for CLI flags. Example: PIZZA_LOG_LEVEL=debug
+	EnvPrefix = "PIZZA_"
+)
+
+// flagsWithoutDefaults are only ever taken from the command line, so that a
+// stray environment variable or config value can't confirm destructive changes
+var flagsWithoutDefaults = []string{"yes"}
+
+// CLIConfig is the global configuration file for the Pizza CLI which lives in
+// the config directory as "config.yaml". It provides default values for flags.
+//
+// Keys are either a bare flag name, which applies to every command with that
+// flag, or a flag name scoped to a command path joined with dots:
+//
+//	log-level: debug
+//	insights.contributors.range: 90
+type CLIConfig struct {
+	values map[string]string
+	path   string
+}
+
+// LoadCLIConfig loads the global CLI config from the Pizza CLI config directory.
+// A missing file is not an error and returns an empty config.
+func LoadCLIConfig() (*CLIConfig, error) {
+	configDir, err := GetConfigDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	return loadCLIConfigAtPath(filepath.Join(configDir, cliConfigFileName))
+}
+
+func loadCLIConfigAtPath(path string) (*CLIConfig, error) {
+	c := &CLIConfig{
+		values: make(map[string]string),
+		path:   path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading CLI config at: %s - %w", path, err)
+	}
+
+	raw := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("error unmarshaling CLI config at: %s - %w", path, err)
+	}
+
+	for key, value := range raw {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			c.values[key] = strings.Join(items, ",")
+		default:
+			c.values[key] = fmt.Sprint(v)
+		}
+	}
+
+	return c, nil
+}
+
+// Path returns the location of the CLI config file on disk
+func (c *CLIConfig) Path() string {
+	return c.path
+}
+
+// Get returns the value set for the given key
+func (c *CLIConfig) Get(key string) (string, bool) {
+	value, ok := c.values[key]
+	return value, ok
+}
+
+// Set sets the value for the given key. Save must be called to persist it.
+func (c *CLIConfig) Set(key, value string) {
+	c.values[key] = value
+}
+
+// Unset removes the given key, returning whether it was set.
+// Save must be called to persist the change.
+func (c *CLIConfig) Unset(key string) bool {
+	_, ok := c.values[key]
+	delete(c.values, key)
+	return ok
+}
+
+// Keys returns all set keys in sorted order
+func (c *CLIConfig) Keys() []string {
+	keys := make([]string, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// Save writes the CLI config back to disk
+func (c *CLIConfig) Save() error {
+	data, err := yaml.Marshal(c.values)
+	if err != nil {
+		return fmt.Errorf("error marshaling CLI config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return fmt.Errorf("error creating CLI config directory: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, data, 0600); err != nil {
+		return fmt.Errorf("error writing CLI config at: %s - %w", c.path, err)
+	}
+
+	return nil
+}
+
+// LookupFlagDefault finds the default value for a flag on the command at the
+// given path (excluding the root command). The precedence is:
+//
+//  1. The command scoped environment variable. Example: PIZZA_INSIGHTS_CONTRIBUTORS_RANGE
+//  2. The global environment variable. Example: PIZZA_RANGE
+//  3. The command scoped key in the CLI config file. Example: insights.contributors.range
+//  4. The global key in the CLI config file. Example: range
+//
+// Explicitly given flags always take precedence and should not be looked up.
+// Flags that can't have defaults, like "yes", are never found.
+func (c *CLIConfig) LookupFlagDefault(commandPath []string, flagName string) (string, bool) {
+	if !HasDefault(flagName) {
+		return "", false
+	}
+
+	scopedKey := ScopedKey(commandPath, flagName)
+
+	if value, ok := os.LookupEnv(EnvName(scopedKey)); ok && len(commandPath) > 0 {
+		return value, true
+	}
+
+	if value, ok := os.LookupEnv(EnvName(flagName)); ok {
+		return value, true
+	}
+
+	if value, ok := c.values[scopedKey]; ok {
+		return value, true
+	}
+
+	value, ok := c.values[flagName]
+	return value, ok
+}
+
+// HasDefault checks if the flag may have a default from the environment or
+// the CLI config file
+func HasDefault(flagName string) bool {
+	return !slices.Contains(flagsWithoutDefaults, flagName)
+}
+
+// ScopedKey builds the CLI config key for a flag on the command at the given path
+func ScopedKey(commandPath []string, flagName string) string {
+	return strings.Join(slices.Concat(commandPath, []string{flagName}), ".")
+}
+
+// EnvName builds the environment variable name for a CLI config key
+func EnvName(key string) string {
+	replacer := strings.NewReplacer("-", "_", ".", "_")
+	return EnvPrefix + strings.ToUpper(replacer.Replace(key))
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupFlagDefault(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	fileContents := `log-level: warn
+range: 7
+insights.contributors.range: 90
+users:
+  - jpmcb
+  - zeucapua`
+	require.NoError(t, os.WriteFile(configPath, []byte(fileContents), 0600))
+
+	cliConfig, err := loadCLIConfigAtPath(configPath)
+	require.NoError(t, err)
+
+	contributorsPath := []string{"insights", "contributors"}
+	repositoriesPath := []string{"insights", "repositories"}
+
+	value, ok := cliConfig.LookupFlagDefault(contributorsPath, "range")
+	assert.True(t, ok)
+	assert.Equal(t, "90", value, "scoped file key beats global file key")
+
+	value, _ = cliConfig.LookupFlagDefault(repositoriesPath, "range")
+	assert.Equal(t, "7", value)
+
+	value, _ = cliConfig.LookupFlagDefault(repositoriesPath, "users")
+	assert.Equal(t, "jpmcb,zeucapua", value)
+
+	_, ok = cliConfig.LookupFlagDefault(repositoriesPath, "output")
+	assert.False(t, ok)
+
+	t.Setenv("PIZZA_LOG_LEVEL", "debug")
+	value, _ = cliConfig.LookupFlagDefault(repositoriesPath, "log-level")
+	assert.Equal(t, "debug", value, "env beats file")
+
+	t.Setenv("PIZZA_RANGE", "30")
+	value, _ = cliConfig.LookupFlagDefault(contributorsPath, "range")
+	assert.Equal(t, "30", value, "global env beats scoped file key")
+
+	t.Setenv("PIZZA_INSIGHTS_CONTRIBUTORS_RANGE", "60")
+	value, _ = cliConfig.LookupFlagDefault(contributorsPath, "range")
+	assert.Equal(t, "60", value, "scoped env beats global env")
+}
+
+func TestLookupFlagDefaultPrecedence(t *testing.T) {
+	commandPath := []string{"insights", "contributors"}
+	tests := []struct {
+		name      string
+		scopedEnv string
+		globalEnv string
+		file      string
+		want      string
+		wantFound bool
+	}{
+		{
+			name:      "nothing set",
+			wantFound: false,
+		},
+		{
+			name:      "global file key",
+			file:      "range: 7\n",
+			want:      "7",
+			wantFound: true,
+		},
+		{
+			name:      "scoped file key beats global file key",
+			file:      "range: 7\ninsights.contributors.range: 90\n",
+			want:      "90",
+			wantFound: true,
+		},
+		{
+			name:      "scoped file key of another command is ignored",
+			file:      "range: 7\ninsights.repositories.range: 90\n",
+			want:      "7",
+			wantFound: true,
+		},
+		{
+			name:      "global env beats scoped file key",
+			globalEnv: "30",
+			file:      "range: 7\ninsights.contributors.range: 90\n",
+			want:      "30",
+			wantFound: true,
+		},
+		{
+			name:      "scoped env beats global env",
+			scopedEnv: "60",
+			globalEnv: "30",
+			file:      "range: 7\ninsights.contributors.range: 90\n",
+			want:      "60",
+			wantFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.file), 0600))
+
+			if tt.scopedEnv != "" {
+				t.Setenv("PIZZA_INSIGHTS_CONTRIBUTORS_RANGE", tt.scopedEnv)
+			}
+			if tt.globalEnv != "" {
+				t.Setenv("PIZZA_RANGE", tt.globalEnv)
+			}
+
+			cliConfig, err := loadCLIConfigAtPath(configPath)
+			require.NoError(t, err)
+
+			value, ok := cliConfig.LookupFlagDefault(commandPath, "range")
+			assert.Equal(t, tt.wantFound, ok)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestLookupFlagDefaultConfirmations(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("yes: true\nlists.delete.yes: true\n"), 0600))
+	t.Setenv("PIZZA_YES", "1")
+	t.Setenv("PIZZA_LISTS_DELETE_YES", "1")
+
+	cliConfig, err := loadCLIConfigAtPath(configPath)
+	require.NoError(t, err)
+
+	_, ok := cliConfig.LookupFlagDefault([]string{"lists", "delete"}, "yes")
+	assert.False(t, ok)
+	assert.False(t, HasDefault("yes"))
+	assert.True(t, HasDefault("range"))
+}
+
+func TestCLIConfigSave(t *testing.T) {
+	t.Parallel()
+	configPath := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+	cliConfig, err := loadCLIConfigAtPath(configPath)
+	require.NoError(t, err)
+	assert.Empty(t, cliConfig.Keys())
+
+	cliConfig.Set("disable-telemetry", "true")
+	cliConfig.Set("output", "json")
+	assert.True(t, cliConfig.Unset("output"))
+	assert.False(t, cliConfig.Unset("output"))
+	require.NoError(t, cliConfig.Save())
+
+	reloaded, err := loadCLIConfigAtPath(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"disable-telemetry"}, reloaded.Keys())
+
+	value, ok := reloaded.Get("disable-telemetry")
+	assert.True(t, ok)
+	assert.Equal(t, "true", value)
+}
@@ -13,6 +13,8 @@ import (
 // If the provided path does not exist or doesn't contain a ".sauced.yaml" file,
 // "~/.sauced.yaml" from the fallback path, which is the user's home directory, is used.
 //
+// Any configs listed under "include" are loaded and merged into the returned Spec.
+//
 // This function returns the config Spec, the location the spec was loaded from, and an error
 func LoadConfig(path string) (*Spec, string, error) {
 	givenPathSpec, givenLoadedPath, givenPathErr := loadSpecAtPath(path)
@@ -46,6 +48,11 @@ func loadSpecAtPath(path string) (*Spec, string, error) {
 		return nil, "", fmt.Errorf("error unmarshaling config at: %s - %w", absPath, err)
 	}
 
+	err = config.resolveIncludes(absPath, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("error resolving includes for config at: %s - %w", absPath, err)
+	}
+
 	return config, absPath, nil
 }
 
@@ -1,6 +1,11 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -93,3 +98,101 @@ attribution:
 		assert.Equal(t, []string{"coding@zeu.dev"}, config.Attributions["zeucapua"])
 	})
 }
+
+func TestLoadConfigIncludes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Local include is merged", func(t *testing.T) {
+		t.Parallel()
+		tmpDir := t.TempDir()
+
+		sharedContents := `attribution:
+  jpmcb:
+    - jpmcb@opensauced.pizza
+  zeucapua:
+    - coding@zeu.dev
+attribution-fallback:
+  - open-sauced/engineering`
+
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "shared", "team.yaml"), []byte(sharedContents), 0600))
+
+		fileContents := `include:
+  - shared/team.yaml
+attribution:
+  jpmcb:
+    - john@opensauced.pizza`
+
+		configFilePath := filepath.Join(tmpDir, ".sauced.yaml")
+		require.NoError(t, os.WriteFile(configFilePath, []byte(fileContents), 0600))
+
+		config, _, err := LoadConfig(configFilePath)
+		require.NoError(t, err)
+
+		assert.Len(t, config.Attributions, 2)
+		assert.Equal(t, []string{"john@opensauced.pizza", "jpmcb@opensauced.pizza"}, config.Attributions["jpmcb"])
+		assert.Equal(t, []string{"coding@zeu.dev"}, config.Attributions["zeucapua"])
+		assert.Equal(t, []string{"open-sauced/engineering"}, config.AttributionFallback)
+	})
+
+	t.Run("Checksum mismatch", func(t *testing.T) {
+		t.Parallel()
+		tmpDir := t.TempDir()
+
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "team.yaml"), []byte("attribution: {}"), 0600))
+
+		fileContents := `include:
+  - source: team.yaml
+    sha256: 0000000000000000000000000000000000000000000000000000000000000000`
+
+		configFilePath := filepath.Join(tmpDir, ".sauced.yaml")
+		require.NoError(t, os.WriteFile(configFilePath, []byte(fileContents), 0600))
+
+		_, _, err := loadSpecAtPath(configFilePath)
+		require.ErrorContains(t, err, "checksum mismatch")
+	})
+
+	t.Run("Include cycle", func(t *testing.T) {
+		t.Parallel()
+		tmpDir := t.TempDir()
+
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "a.yaml"), []byte("include: [b.yaml]"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "b.yaml"), []byte("include: [a.yaml]"), 0600))
+
+		_, _, err := loadSpecAtPath(filepath.Join(tmpDir, "a.yaml"))
+		require.ErrorContains(t, err, "include cycle detected")
+	})
+}
+
+func TestLoadConfigRemoteInclude(t *testing.T) {
+	// The include cache lives in the user's home directory
+	t.Setenv("HOME", t.TempDir())
+
+	remoteContents := `attribution:
+  brandonroberts:
+    - robertsbt@gmail.com`
+	sum := sha256.Sum256([]byte(remoteContents))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(remoteContents))
+	}))
+
+	tmpDir := t.TempDir()
+	fileContents := fmt.Sprintf(`include:
+  - source: %s/attributions.yaml
+    sha256: %s`, server.URL, hex.EncodeToString(sum[:]))
+
+	configFilePath := filepath.Join(tmpDir, ".sauced.yaml")
+	require.NoError(t, os.WriteFile(configFilePath, []byte(fileContents), 0600))
+
+	config, _, err := loadSpecAtPath(configFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"robertsbt@gmail.com"}, config.Attributions["brandonroberts"])
+
+	// With the server gone, the cached copy is used
+	server.Close()
+
+	config, _, err = loadSpecAtPath(configFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"robertsbt@gmail.com"}, config.Attributions["brandonroberts"])
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// noreplyEmail matches GitHub's "users.noreply.github.com" commit emails, both
+// the "login@" and "12345+login@" forms
+var noreplyEmail = regexp.MustCompile(`^(?:\d+\+)?([a-z\d](?:[a-z\d-]*[a-z\d])?)@users\.noreply\.github\.com$`)
+
+// NormalizeEmail returns the email in the form used to compare emails:
+// surrounding whitespace is removed and it is case folded
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NoreplyLogin returns the GitHub login of a GitHub noreply email, or an empty
+// string if the email isn't one
+func NoreplyLogin(email string) string {
+	match := noreplyEmail.FindStringSubmatch(NormalizeEmail(email))
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+// IsTeamName checks if an attribution name is a GitHub team, like "org/team"
+func IsTeamName(name string) bool {
+	return strings.Contains(name, "/")
+}
+
+// AttributedNames returns the sorted attribution names the given commit email
+// is attributed to. Emails are compared case insensitively and GitHub noreply
+// emails are also attributed to the attribution name matching their login.
+func (s *Spec) AttributedNames(email string) []string {
+	email = NormalizeEmail(email)
+	login := NoreplyLogin(email)
+
+	var names []string
+	for name, emails := range s.Attributions {
+		matches := login != "" && strings.EqualFold(name, login)
+		if !matches {
+			matches = slices.ContainsFunc(emails, func(e string) bool {
+				return NormalizeEmail(e) == email
+			})
+		}
+
+		if matches {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// ResolveIdentity returns the attribution name of a person given as a GitHub
+// login (with or without a leading "@") or as an email. Logins are compared
+// case insensitively and teams are never returned, since an email listed
+// under a team only makes the person a member of it.
+func (s *Spec) ResolveIdentity(user string) (string, bool) {
+	if strings.Contains(strings.TrimPrefix(user, "@"), "@") {
+		for _, name := range s.AttributedNames(user) {
+			if !IsTeamName(name) {
+				return name, true
+			}
+		}
+
+		return "", false
+	}
+
+	login := strings.TrimPrefix(user, "@")
+	for name := range s.Attributions {
+		if !IsTeamName(name) && strings.EqualFold(name, login) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// TeamMembers returns the sorted attribution names of the people who are
+// members of the team, given as "org/team" with or without a leading "@".
+// Like in ResolveIdentity, a person is a member when one of their emails is
+// listed under the team. Teams are compared case insensitively.
+func (s *Spec) TeamMembers(team string) []string {
+	team = strings.TrimPrefix(team, "@")
+
+	var members []string
+	for name, emails := range s.Attributions {
+		if !IsTeamName(name) || !strings.EqualFold(name, team) {
+			continue
+		}
+
+		for _, email := range emails {
+			for _, member := range s.AttributedNames(email) {
+				if !IsTeamName(member) && !slices.Contains(members, member) {
+					members = append(members, member)
+				}
+			}
+		}
+	}
+
+	sort.Strings(members)
+	return members
+}
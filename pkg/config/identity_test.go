@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributedNames(t *testing.T) {
+	t.Parallel()
+
+	spec := &Spec{
+		Attributions: map[string][]string{
+			"jpmcb":                   {"John@OpenSauced.pizza"},
+			"open-sauced/engineering": {"john@opensauced.pizza", "brandon@opensauced.pizza"},
+			"zeucapua":                {"coding@zeu.dev"},
+		},
+	}
+
+	assert.Equal(t, []string{"jpmcb", "open-sauced/engineering"}, spec.AttributedNames(" JOHN@opensauced.pizza "))
+	assert.Equal(t, []string{"zeucapua"}, spec.AttributedNames("12345+ZeuCapua@users.noreply.github.com"))
+	assert.Empty(t, spec.AttributedNames("unknown@example.com"))
+
+	name, ok := spec.ResolveIdentity("john@opensauced.pizza")
+	require.True(t, ok)
+	assert.Equal(t, "jpmcb", name)
+
+	name, ok = spec.ResolveIdentity("@JPMCB")
+	require.True(t, ok)
+	assert.Equal(t, "jpmcb", name)
+
+	// Only members of a team, not people
+	_, ok = spec.ResolveIdentity("brandon@opensauced.pizza")
+	assert.False(t, ok)
+
+	_, ok = spec.ResolveIdentity("open-sauced/engineering")
+	assert.False(t, ok)
+
+	// Brandon has no attribution of their own
+	assert.Equal(t, []string{"jpmcb"}, spec.TeamMembers("@Open-Sauced/Engineering"))
+	assert.Empty(t, spec.TeamMembers("open-sauced/design"))
+}
+
+func TestNoreplyLogin(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "jpmcb", NoreplyLogin("jpmcb@users.noreply.github.com"))
+	assert.Equal(t, "jpmcb", NoreplyLogin("23109390+jpmcb@users.noreply.github.com"))
+	assert.Equal(t, "", NoreplyLogin("jpmcb@opensauced.pizza"))
+}
+
+func TestMailmap(t *testing.T) {
+	t.Parallel()
+
+	mailmap, err := ParseMailmap(strings.NewReader(`# comment
+John McBride <john@opensauced.pizza> <john@personal.com>
+<brandon@opensauced.pizza> Brandon <brandon@old.com>
+Zeu Capua <coding@zeu.dev>
+`))
+	require.NoError(t, err)
+
+	name, email := mailmap.Lookup("jpmcb", "JOHN@personal.com")
+	assert.Equal(t, "John McBride", name)
+	assert.Equal(t, "john@opensauced.pizza", email)
+
+	// Entries with a commit name only match that name
+	name, email = mailmap.Lookup("Someone", "brandon@old.com")
+	assert.Equal(t, "Someone", name)
+	assert.Equal(t, "brandon@old.com", email)
+
+	_, email = mailmap.Lookup("brandon", "brandon@old.com")
+	assert.Equal(t, "brandon@opensauced.pizza", email)
+
+	name, email = mailmap.Lookup("zeu", "coding@zeu.dev")
+	assert.Equal(t, "Zeu Capua", name)
+	assert.Equal(t, "coding@zeu.dev", email)
+
+	var empty *Mailmap
+	name, email = empty.Lookup("jpmcb", "john@personal.com")
+	assert.Equal(t, "jpmcb", name)
+	assert.Equal(t, "john@personal.com", email)
+}
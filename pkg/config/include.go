@@ -0,0 +1,236 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	includeCacheDirName = "includes"
+
+	// maxIncludeDepth guards against runaway nesting of included configs
+	maxIncludeDepth = 8
+)
+
+// includeHTTPClient is the client used for fetching remote includes
+var includeHTTPClient = &http.Client{
+	Timeout: time.Second * 10,
+}
+
+// resolveIncludes loads every config listed in the spec's "include" list and
+// merges its attributions into the spec. The location is the absolute path
+// or URL the spec itself was loaded from and is used to resolve relative sources.
+func (s *Spec) resolveIncludes(location string, seen []string) error {
+	if len(seen) > maxIncludeDepth {
+		return fmt.Errorf("includes nested deeper than %d levels at: %s", maxIncludeDepth, location)
+	}
+
+	seen = append(seen, location)
+
+	for _, include := range s.Includes {
+		source, err := resolveIncludeSource(location, include.Source)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(seen, source) {
+			return fmt.Errorf("include cycle detected: %s -> %s", strings.Join(seen, " -> "), source)
+		}
+
+		data, err := readInclude(source, include.SHA256)
+		if err != nil {
+			return fmt.Errorf("could not load include %s: %w", include.Source, err)
+		}
+
+		included := &Spec{}
+		if err := yaml.Unmarshal(data, included); err != nil {
+			return fmt.Errorf("error unmarshaling include at: %s - %w", source, err)
+		}
+
+		if err := included.resolveIncludes(source, seen); err != nil {
+			return err
+		}
+
+		s.merge(included)
+	}
+
+	return nil
+}
+
+// merge folds the other spec's attributions into this one. Emails already
+// attributed to a username are not duplicated and the fallback of this spec
+// is kept if it has one.
+func (s *Spec) merge(other *Spec) {
+	if s.Attributions == nil && len(other.Attributions) > 0 {
+		s.Attributions = make(map[string][]string)
+	}
+
+	for username, emails := range other.Attributions {
+		for _, email := range emails {
+			if !slices.Contains(s.Attributions[username], email) {
+				s.Attributions[username] = append(s.Attributions[username], email)
+			}
+		}
+	}
+
+	if len(s.AttributionFallback) == 0 {
+		s.AttributionFallback = other.AttributionFallback
+	}
+}
+
+// resolveIncludeSource turns an include source into an absolute path or URL
+// based on the location of the config that included it
+func resolveIncludeSource(location, source string) (string, error) {
+	// Single letter schemes are Windows drive letters, not URLs
+	u, err := url.Parse(source)
+	if err == nil && len(u.Scheme) > 1 {
+		switch u.Scheme {
+		case "file":
+			return filepath.Clean(u.Path), nil
+		case "http", "https":
+			return u.String(), nil
+		default:
+			return "", fmt.Errorf("unsupported include scheme %q in: %s", u.Scheme, source)
+		}
+	}
+
+	// Relative sources in a remote config resolve against its URL
+	if base, err := url.Parse(location); err == nil && (base.Scheme == "http" || base.Scheme == "https") {
+		ref, err := url.Parse(filepath.ToSlash(source))
+		if err != nil {
+			return "", fmt.Errorf("error parsing include: %s - %w", source, err)
+		}
+
+		return base.ResolveReference(ref).String(), nil
+	}
+
+	if strings.HasPrefix(source, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not get user home directory: %w", err)
+		}
+
+		source = filepath.Join(homeDir, source[2:])
+	}
+
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(filepath.Dir(location), source)
+	}
+
+	return filepath.Clean(source), nil
+}
+
+// readInclude reads the contents of a resolved include source and verifies
+// them against the pinned checksum, if any
+func readInclude(source, checksum string) ([]byte, error) {
+	if !isRemoteSource(source) {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+
+		return data, verifyChecksum(data, checksum)
+	}
+
+	data, fetchErr := fetchRemoteInclude(source)
+	if fetchErr == nil {
+		if err := verifyChecksum(data, checksum); err != nil {
+			return nil, err
+		}
+
+		// Caching is best effort: failing to write only loses offline support
+		_ = writeCachedInclude(source, data)
+		return data, nil
+	}
+
+	// Fall back to the last good copy when the source is unreachable
+	data, err := readCachedInclude(source)
+	if err != nil {
+		return nil, fmt.Errorf("%w - no cached copy available: %w", fetchErr, err)
+	}
+
+	return data, verifyChecksum(data, checksum)
+}
+
+func isRemoteSource(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
+
+func fetchRemoteInclude(source string) ([]byte, error) {
+	resp, err := includeHTTPClient.Get(source)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching include: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching include failed with status code: %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func verifyChecksum(data []byte, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(actual, strings.TrimPrefix(checksum, "sha256:")) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", checksum, actual)
+	}
+
+	return nil
+}
+
+// includeCachePath returns the on disk location in the Pizza CLI config
+// directory of the cached copy of a remote include
+func includeCachePath(source string) (string, error) {
+	configDir, err := GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(source))
+	return filepath.Join(configDir, includeCacheDirName, hex.EncodeToString(sum[:])+".yaml"), nil
+}
+
+func readCachedInclude(source string) ([]byte, error) {
+	cachePath, err := includeCachePath(source)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(cachePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("include has never been fetched: %s", source)
+	}
+
+	return data, err
+}
+
+func writeCachedInclude(source string, data []byte) error {
+	cachePath, err := includeCachePath(source)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(cachePath, data, 0600)
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Mailmap maps the names and emails used in commits to canonical identities,
+// following the git ".mailmap" format. A nil Mailmap maps nothing.
+type Mailmap struct {
+	entries []mailmapEntry
+}
+
+type mailmapEntry struct {
+	properName  string
+	properEmail string
+	commitName  string
+	commitEmail string
+}
+
+// LoadMailmap loads the ".mailmap" file at the root of the repository at the
+// given path. An empty Mailmap is returned if the repository has none.
+func LoadMailmap(repoPath string) (*Mailmap, error) {
+	file, err := os.Open(filepath.Join(repoPath, ".mailmap"))
+	if errors.Is(err, os.ErrNotExist) {
+		return &Mailmap{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening .mailmap: %w", err)
+	}
+	defer file.Close()
+
+	return ParseMailmap(file)
+}
+
+// ParseMailmap parses the contents of a ".mailmap" file. Each line may be any of:
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+func ParseMailmap(r io.Reader) (*Mailmap, error) {
+	m := &Mailmap{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		var names, emails []string
+		for {
+			start := strings.Index(line, "<")
+			end := strings.Index(line, ">")
+			if start < 0 || end < start {
+				break
+			}
+
+			names = append(names, strings.TrimSpace(line[:start]))
+			emails = append(emails, strings.TrimSpace(line[start+1:end]))
+			line = line[end+1:]
+		}
+
+		switch len(emails) {
+		case 1:
+			m.entries = append(m.entries, mailmapEntry{
+				properName:  names[0],
+				commitEmail: emails[0],
+			})
+		case 2:
+			m.entries = append(m.entries, mailmapEntry{
+				properName:  names[0],
+				properEmail: emails[0],
+				commitName:  names[1],
+				commitEmail: emails[1],
+			})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading .mailmap: %w", err)
+	}
+
+	return m, nil
+}
+
+// Lookup returns the canonical name and email for a commit's name and email.
+// Entries that also match the commit name take precedence, like in git.
+func (m *Mailmap) Lookup(name, email string) (string, string) {
+	if m == nil {
+		return name, email
+	}
+
+	var match *mailmapEntry
+	for i := range m.entries {
+		entry := &m.entries[i]
+		if !strings.EqualFold(entry.commitEmail, email) {
+			continue
+		}
+
+		if entry.commitName == "" && match == nil {
+			match = entry
+		} else if entry.commitName != "" && strings.EqualFold(entry.commitName, name) {
+			match = entry
+			break
+		}
+	}
+
+	if match == nil {
+		return name, email
+	}
+
+	if match.properName != "" {
+		name = match.properName
+	}
+
+	if match.properEmail != "" {
+		email = match.properEmail
+	}
+
+	return name, email
+}
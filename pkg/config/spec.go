@@ -1,5 +1,11 @@
 package config
 
+import (
+	"errors"
+
+	"gopkg.in/yaml.v3"
+)
+
 // The configuration specification
 type Spec struct {
 
@@ -12,4 +18,56 @@ type Spec struct {
 	// AttributionFallback is the default username/group(s) to attribute to the filename
 	// if no other attributions were found.
 	AttributionFallback []string `yaml:"attribution-fallback"`
+
+	// Includes are other config files whose attributions are merged into this
+	// spec at load time. Each may be a local path (relative to the including
+	// file), a "file://" URL, or an "https://" URL.
+	Includes []Include `yaml:"include,omitempty"`
+}
+
+// Include is a single entry in a config's "include" list. In YAML, it may be
+// given as a plain string source or as a mapping with a pinned checksum:
+//
+//	include:
+//	  - ../shared/.sauced.yaml
+//	  - source: https://example.com/attributions.yaml
+//	    sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+type Include struct {
+	// Source is the local path or URL of the included config
+	Source string `yaml:"source"`
+
+	// SHA256 is the optional, hex encoded sha256 checksum the included file
+	// contents must match
+	SHA256 string `yaml:"sha256,omitempty"`
+}
+
+// UnmarshalYAML allows an Include to be given as either a scalar source or a mapping
+func (i *Include) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		i.Source = value.Value
+		return nil
+	}
+
+	type rawInclude Include
+	var raw rawInclude
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	if raw.Source == "" {
+		return errors.New("include entry is missing a 'source'")
+	}
+
+	*i = Include(raw)
+	return nil
+}
+
+// MarshalYAML writes an Include back out as a scalar source when no checksum is pinned
+func (i Include) MarshalYAML() (interface{}, error) {
+	if i.SHA256 == "" {
+		return i.Source, nil
+	}
+
+	type rawInclude Include
+	return rawInclude(i), nil
 }
@@ -4,4 +4,8 @@ const (
 	EndpointProd  = "https://api.opensauced.pizza"
 	EndpointBeta  = "https://beta.api.opensauced.pizza"
 	EndpointTools = "https://opensauced.tools"
+
+	// AppURL is the OpenSauced web app, where workspaces and their
+	// contributor insights are viewed
+	AppURL = "https://app.opensauced.pizza"
 )
@@ -1,11 +1,20 @@
 package constants
 
 const (
-	FlagNameBeta      = "beta"
-	FlagNameEndpoint  = "endpoint"
-	FlagNameFile      = "file"
-	FlagNameOutput    = "output"
-	FlagNameRange     = "range"
-	FlagNameTelemetry = "disable-telemetry"
-	FlagNameWait      = "wait"
+	FlagNameAPITimeout        = "api-timeout"
+	FlagNameBeta              = "beta"
+	FlagNameCache             = "cache"
+	FlagNameConcurrency       = "concurrency"
+	FlagNameCredentialKeyFile = "credential-key-file"
+	FlagNameCredentialStore   = "credential-store"
+	FlagNameEndpoint          = "endpoint"
+	FlagNameFile              = "file"
+	FlagNameNoCache           = "no-cache"
+	FlagNameOutput            = "output"
+	FlagNameRange             = "range"
+	FlagNameRefresh           = "refresh"
+	FlagNameTelemetry         = "disable-telemetry"
+	FlagNameWait              = "wait"
+	FlagNameWorkspace         = "workspace"
+	FlagNameYes               = "yes"
 )
@@ -0,0 +1,196 @@
+package owners
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GeneratedFile is an OWNERS file written by "pizza generate codeowners
+// --owners-style-file". Every file path is followed by a list of the names of
+// its owners, each with a nested list holding their email:
+//
+//	src/main.go
+//	  - John McBride
+//	    - john@opensauced.pizza
+//
+// Serializing an unmodified GeneratedFile reproduces the original contents
+// exactly. Only the entries changed through the API are re-rendered.
+type GeneratedFile struct {
+	Entries []*Entry
+
+	// trailer are the comment and blank lines after the last entry
+	trailer []string
+}
+
+// Entry is a file path of a generated OWNERS file and its owners
+type Entry struct {
+	Path   string
+	Owners []Owner
+
+	// leading are the comment and blank lines before the entry
+	leading []string
+
+	// raw are the original lines of the entry. They are cleared once the entry
+	// is modified so the entry is rendered from its fields instead.
+	raw []string
+}
+
+// Owner is the name and email of an owner in a generated OWNERS file. Either
+// may be empty, like for the attribution fallback.
+type Owner struct {
+	Name  string
+	Email string
+}
+
+// ParseGenerated parses the contents of an OWNERS file written by "pizza
+// generate codeowners --owners-style-file"
+func ParseGenerated(contents string) (*GeneratedFile, error) {
+	f := &GeneratedFile{}
+
+	var pending []string
+	var entry *Entry
+	nameIndent := 0
+
+	for i, text := range strings.Split(contents, "\n") {
+		line := strings.TrimSuffix(text, "\r")
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			pending = append(pending, text)
+		case indent == 0:
+			if strings.HasSuffix(trimmed, ":") {
+				return nil, fmt.Errorf("line %d: expected a file path, found the YAML key %s", i+1, trimmed)
+			}
+
+			entry = &Entry{
+				Path:    trimmed,
+				leading: pending,
+				raw:     []string{text},
+			}
+			f.Entries = append(f.Entries, entry)
+			pending = nil
+		case strings.HasPrefix(trimmed, "-"):
+			if entry == nil {
+				return nil, fmt.Errorf("line %d: list item without a file path", i+1)
+			}
+
+			value := strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			entry.raw = append(append(entry.raw, pending...), text)
+			pending = nil
+
+			if n := len(entry.Owners); n > 0 && indent > nameIndent {
+				if entry.Owners[n-1].Email != "" {
+					return nil, fmt.Errorf("line %d: %s already has an email", i+1, entry.Owners[n-1].Name)
+				}
+
+				entry.Owners[n-1].Email = value
+				continue
+			}
+
+			entry.Owners = append(entry.Owners, Owner{Name: value})
+			nameIndent = indent
+		default:
+			return nil, fmt.Errorf("line %d: expected a file path or a list item", i+1)
+		}
+	}
+
+	f.trailer = pending
+	return f, nil
+}
+
+// IsGenerated checks if the contents of an OWNERS file were written by "pizza
+// generate codeowners --owners-style-file" rather than being a Kubernetes style
+// OWNERS file or a CODEOWNERS file
+func IsGenerated(contents string) bool {
+	f, err := ParseGenerated(contents)
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(f.Entries, func(e *Entry) bool {
+		return len(e.Owners) > 0
+	})
+}
+
+// String renders the whole file
+func (f *GeneratedFile) String() string {
+	var lines []string
+	for _, e := range f.Entries {
+		lines = append(lines, e.leading...)
+
+		if e.raw != nil {
+			lines = append(lines, e.raw...)
+			continue
+		}
+
+		lines = append(lines, e.Path)
+		for _, owner := range e.Owners {
+			lines = append(lines, "  - "+owner.Name, "    - "+owner.Email)
+		}
+	}
+
+	return strings.Join(append(lines, f.trailer...), "\n")
+}
+
+// Owners returns the unique emails of the owners in the file, sorted. The
+// names aren't GitHub logins, but those of the commit authors.
+func (f *GeneratedFile) Owners() []string {
+	var emails []string
+	for _, e := range f.Entries {
+		for _, owner := range e.Owners {
+			if owner.Email != "" {
+				emails = append(emails, owner.Email)
+			}
+		}
+	}
+
+	return unique(emails)
+}
+
+// RemoveOwners removes the owners for which remove returns true, returning
+// the removed owners
+func (e *Entry) RemoveOwners(remove func(Owner) bool) []Owner {
+	var removed, kept []Owner
+	for _, owner := range e.Owners {
+		if remove(owner) {
+			removed = append(removed, owner)
+		} else {
+			kept = append(kept, owner)
+		}
+	}
+
+	if len(removed) > 0 {
+		e.Owners = kept
+		e.raw = nil
+	}
+
+	return removed
+}
+
+// HasOwner checks if the owner is listed for the entry. Owners are the same
+// if their emails are equal, ignoring case, or if neither has an email and
+// their names are equal.
+func (e *Entry) HasOwner(owner Owner) bool {
+	return slices.ContainsFunc(e.Owners, func(o Owner) bool {
+		if o.Email != "" || owner.Email != "" {
+			return strings.EqualFold(o.Email, owner.Email)
+		}
+
+		return strings.EqualFold(o.Name, owner.Name)
+	})
+}
+
+// AddOwner appends the owner to the entry if it isn't already listed,
+// returning whether it was added
+func (e *Entry) AddOwner(owner Owner) bool {
+	if e.HasOwner(owner) {
+		return false
+	}
+
+	e.Owners = append(slices.Clone(e.Owners), owner)
+	e.raw = nil
+	return true
+}
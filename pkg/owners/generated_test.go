@@ -0,0 +1,91 @@
+package owners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGenerated = `# This file is generated automatically by OpenSauced pizza-cli. DO NOT EDIT. Stay saucy!
+#
+# Generated with command:
+# $ pizza generate codeowners pizza-cli/ --owners-style-file true
+
+README.md
+  - John McBride
+    - john@opensauced.pizza
+  - Zeu Capua
+    - coding@zeu.dev
+go.mod
+  -
+    -
+src/main.go
+  - John McBride
+    - John@OpenSauced.pizza
+`
+
+func TestParseGenerated(t *testing.T) {
+	t.Parallel()
+
+	f, err := ParseGenerated(testGenerated)
+	require.NoError(t, err)
+
+	require.Len(t, f.Entries, 3)
+	assert.Equal(t, "README.md", f.Entries[0].Path)
+	assert.Equal(t, []Owner{{"John McBride", "john@opensauced.pizza"}, {"Zeu Capua", "coding@zeu.dev"}}, f.Entries[0].Owners)
+	assert.Equal(t, []Owner{{}}, f.Entries[1].Owners)
+	assert.Equal(t, []string{"coding@zeu.dev", "john@opensauced.pizza"}, f.Owners())
+
+	// Unmodified files are rendered as is
+	assert.Equal(t, testGenerated, f.String())
+
+	_, err = ParseGenerated("approvers:\n  - jpmcb\n")
+	require.Error(t, err)
+	_, err = ParseGenerated("  - jpmcb\n")
+	require.Error(t, err)
+}
+
+func TestIsGenerated(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsGenerated(testGenerated))
+	assert.False(t, IsGenerated(testOwners))
+	assert.False(t, IsGenerated("README.md @jpmcb\n* @open-sauced/engineering\n"))
+	assert.False(t, IsGenerated(""))
+}
+
+func TestEditGenerated(t *testing.T) {
+	t.Parallel()
+
+	f, err := ParseGenerated(testGenerated)
+	require.NoError(t, err)
+
+	removed := f.Entries[0].RemoveOwners(func(owner Owner) bool {
+		return owner.Email == "john@opensauced.pizza"
+	})
+	assert.Equal(t, []Owner{{"John McBride", "john@opensauced.pizza"}}, removed)
+
+	assert.False(t, f.Entries[0].AddOwner(Owner{"zeucapua", "Coding@Zeu.dev"}))
+	assert.True(t, f.Entries[2].AddOwner(Owner{"zeucapua", "coding@zeu.dev"}))
+
+	expected := `# This file is generated automatically by OpenSauced pizza-cli. DO NOT EDIT. Stay saucy!
+#
+# Generated with command:
+# $ pizza generate codeowners pizza-cli/ --owners-style-file true
+
+README.md
+  - Zeu Capua
+    - coding@zeu.dev
+go.mod
+  -
+    -
+src/main.go
+  - John McBride
+    - John@OpenSauced.pizza
+  - zeucapua
+    - coding@zeu.dev
+`
+
+	assert.Equal(t, expected, f.String())
+}
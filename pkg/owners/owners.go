@@ -0,0 +1,119 @@
+// Package owners parses Kubernetes style OWNERS and OWNERS_ALIASES files, and
+// the OWNERS files generated by "pizza generate codeowners --owners-style-file".
+//
+// A Kubernetes style OWNERS file is YAML listing the approvers and reviewers of
+// the directory it's in, optionally per file pattern under "filters". Aliases
+// defined in the OWNERS_ALIASES file at the root of the repository stand for
+// lists of logins.
+package owners
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is a parsed OWNERS file
+type File struct {
+	Approvers []string `yaml:"approvers"`
+	Reviewers []string `yaml:"reviewers"`
+
+	// EmeritusApprovers were approvers once and don't own anything anymore
+	EmeritusApprovers []string `yaml:"emeritus_approvers"`
+
+	// Filters are the approvers and reviewers of the files matching a
+	// regular expression
+	Filters map[string]Filter `yaml:"filters"`
+}
+
+// Filter is the approvers and reviewers of the files matching a filter
+type Filter struct {
+	Approvers []string `yaml:"approvers"`
+	Reviewers []string `yaml:"reviewers"`
+}
+
+// Parse reads and parses an OWNERS file
+func Parse(r io.Reader) (*File, error) {
+	f := &File{}
+	if err := yaml.NewDecoder(r).Decode(f); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error parsing OWNERS file: %w", err)
+	}
+
+	return f, nil
+}
+
+// Owners returns every unique approver and reviewer in the file, including
+// those of its filters, sorted. Emeritus approvers are left out.
+func (f *File) Owners() []string {
+	owners := append(append([]string{}, f.Approvers...), f.Reviewers...)
+	for _, filter := range f.Filters {
+		owners = append(owners, filter.Approvers...)
+		owners = append(owners, filter.Reviewers...)
+	}
+
+	return unique(owners)
+}
+
+// Aliases maps the aliases of an OWNERS_ALIASES file to the logins they stand for
+type Aliases map[string][]string
+
+// ParseAliases reads and parses an OWNERS_ALIASES file
+func ParseAliases(r io.Reader) (Aliases, error) {
+	var file struct {
+		Aliases Aliases `yaml:"aliases"`
+	}
+	if err := yaml.NewDecoder(r).Decode(&file); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error parsing OWNERS_ALIASES file: %w", err)
+	}
+
+	return file.Aliases, nil
+}
+
+// Expand replaces the aliases among the owners with the logins they stand
+// for, returning every unique owner sorted. Aliases are compared case
+// insensitively.
+func (a Aliases) Expand(owners []string) []string {
+	var expanded []string
+	for _, owner := range owners {
+		logins, ok := a.lookup(owner)
+		if !ok {
+			expanded = append(expanded, owner)
+			continue
+		}
+
+		expanded = append(expanded, logins...)
+	}
+
+	return unique(expanded)
+}
+
+func (a Aliases) lookup(owner string) ([]string, bool) {
+	for alias, logins := range a {
+		if strings.EqualFold(alias, owner) {
+			return logins, true
+		}
+	}
+
+	return nil, false
+}
+
+// unique returns the owners without duplicates, which differ in case at most,
+// sorted
+func unique(owners []string) []string {
+	seen := make(map[string]struct{})
+	result := []string{}
+
+	for _, owner := range owners {
+		key := strings.ToLower(owner)
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			result = append(result, owner)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,57 @@
+package owners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOwners = `# See the OWNERS docs at https://go.k8s.io/owners
+approvers:
+  - jpmcb
+  - sig-cli-leads
+reviewers:
+  - zeucapua
+  - JPMCB
+emeritus_approvers:
+  - bdougie
+filters:
+  "\\.md$":
+    reviewers:
+      - nickytonline
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	f, err := Parse(strings.NewReader(testOwners))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"jpmcb", "sig-cli-leads"}, f.Approvers)
+	assert.Equal(t, []string{"bdougie"}, f.EmeritusApprovers)
+	assert.Equal(t, []string{"nickytonline"}, f.Filters[`\.md$`].Reviewers)
+	assert.Equal(t, []string{"jpmcb", "nickytonline", "sig-cli-leads", "zeucapua"}, f.Owners())
+
+	// Empty files own nothing
+	f, err = Parse(strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Empty(t, f.Owners())
+
+	_, err = Parse(strings.NewReader("README.md\n  - John\n"))
+	require.Error(t, err)
+}
+
+func TestAliases(t *testing.T) {
+	t.Parallel()
+
+	aliases, err := ParseAliases(strings.NewReader(`aliases:
+  sig-cli-leads:
+    - brandonroberts
+    - jpmcb
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"brandonroberts", "jpmcb", "zeucapua"}, aliases.Expand([]string{"jpmcb", "SIG-CLI-leads", "zeucapua"}))
+}
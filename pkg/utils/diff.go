@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change
+const diffContextLines = 3
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// UnifiedDiff returns a unified diff, like "diff -u", that turns the "from"
+// contents into the "to" contents. An empty string is returned when they are identical.
+func UnifiedDiff(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+
+	var lines []diffLine
+	for _, d := range diff.Do(from, to) {
+		op := byte(' ')
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			op = '-'
+		case diffmatchpatch.DiffInsert:
+			op = '+'
+		}
+
+		for _, text := range strings.SplitAfter(d.Text, "\n") {
+			if text != "" {
+				lines = append(lines, diffLine{op: op, text: text})
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+
+	for start := 0; start < len(lines); {
+		// Find the next change
+		for start < len(lines) && lines[start].op == ' ' {
+			start++
+		}
+		if start == len(lines) {
+			break
+		}
+
+		// Extend the hunk until there is a run of unchanged lines
+		// long enough to separate it from the next change
+		end := start
+		for end < len(lines) {
+			if lines[end].op != ' ' {
+				end++
+				continue
+			}
+
+			run := end
+			for run < len(lines) && lines[run].op == ' ' {
+				run++
+			}
+			if run == len(lines) || run-end > 2*diffContextLines {
+				break
+			}
+			end = run
+		}
+
+		hunkStart := max(start-diffContextLines, 0)
+		hunkEnd := min(end+diffContextLines, len(lines))
+		writeHunk(&b, lines, hunkStart, hunkEnd)
+
+		start = hunkEnd
+	}
+
+	return b.String()
+}
+
+func writeHunk(b *strings.Builder, lines []diffLine, start, end int) {
+	// Line numbers of the hunk in the "from" and "to" files are 1 based
+	fromLine, toLine := 1, 1
+	for _, l := range lines[:start] {
+		if l.op != '+' {
+			fromLine++
+		}
+		if l.op != '-' {
+			toLine++
+		}
+	}
+
+	fromCount, toCount := 0, 0
+	for _, l := range lines[start:end] {
+		if l.op != '+' {
+			fromCount++
+		}
+		if l.op != '-' {
+			toCount++
+		}
+	}
+
+	if fromCount == 0 {
+		fromLine--
+	}
+	if toCount == 0 {
+		toLine--
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount))
+	for _, l := range lines[start:end] {
+		b.WriteByte(l.op)
+		b.WriteString(l.text)
+		if !strings.HasSuffix(l.text, "\n") {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the start and length of one side of a hunk. Like diff, the
+// length is omitted when it's 1.
+func hunkRange(line, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+
+	return fmt.Sprintf("%d,%d", line, count)
+}
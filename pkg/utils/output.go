@@ -31,10 +31,12 @@ func OutputTable(rows []bubblesTable.Row, columns []bubblesTable.Column) string
 		Header:   lipgloss.NewStyle().Bold(true).PaddingRight(1),
 		Selected: lipgloss.NewStyle(),
 	}
+	// The height includes the header row, so the last row would be cut off
+	// without the extra line
 	table := bubblesTable.New(
 		bubblesTable.WithRows(rows),
 		bubblesTable.WithColumns(columns),
-		bubblesTable.WithHeight(len(rows)),
+		bubblesTable.WithHeight(len(rows)+1),
 		bubblesTable.WithStyles(styles),
 	)
 	return table.View()
@@ -50,3 +52,15 @@ func GetMaxTableRowWidth(rows []bubblesTable.Row) int {
 	}
 	return maxRowWidth
 }
+
+// GetMaxTableColumnWidth returns the width of the widest cell in the given
+// column, or of its title if that's wider
+func GetMaxTableColumnWidth(rows []bubblesTable.Row, column int, title string) int {
+	maxWidth := len(title)
+	for i := range rows {
+		if width := len(rows[i][column]); width > maxWidth {
+			maxWidth = width
+		}
+	}
+	return maxWidth
+}
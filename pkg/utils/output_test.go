@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"testing"
+
+	bubblesTable "github.com/charmbracelet/bubbles/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputTable(t *testing.T) {
+	t.Parallel()
+
+	rows := []bubblesTable.Row{{"jpmcb", "12"}, {"zeucapua", "7"}, {"bdougie", "3"}}
+	columns := []bubblesTable.Column{{Title: "Login", Width: 10}, {Title: "PRs", Width: 3}}
+
+	// Every row is shown below the header
+	output := OutputTable(rows, columns)
+	assert.Contains(t, output, "Login")
+	for _, row := range rows {
+		assert.Contains(t, output, row[0])
+	}
+}
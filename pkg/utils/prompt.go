@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/open-sauced/pizza-cli/v2/pkg/constants"
+)
+
+// Confirm asks the question on out and reads a yes or no answer from in
+func Confirm(in io.Reader, out io.Writer, question string) (bool, error) {
+	fmt.Fprintf(out, "%s (y/n): ", question)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || answer == "") {
+		return false, fmt.Errorf("could not read answer, use --%s to skip the question: %w", constants.FlagNameYes, err)
+	}
+
+	switch strings.TrimSpace(answer) {
+	case "y", "Y", "yes":
+		return true, nil
+	case "n", "N", "no":
+		return false, nil
+	default:
+		return false, errors.New("invalid answer. Please enter y or n")
+	}
+}